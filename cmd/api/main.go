@@ -2,16 +2,22 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"math/rand"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"github.com/dailyhot/api/internal/buildinfo"
 	"github.com/dailyhot/api/internal/cache"
 	"github.com/dailyhot/api/internal/config"
 	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/routes"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
@@ -22,6 +28,11 @@ import (
 )
 
 func main() {
+	// 命令行参数
+	// --selftest: 逐个抓取所有平台一次,输出可用性报告后退出,有平台失败时退出码为 1,便于 CI 或巡检使用
+	selfTest := flag.Bool("selftest", false, "探测所有平台的可用性,输出报告后退出")
+	flag.Parse()
+
 	// 1. 加载配置
 	cfg, err := config.Load("")
 	if err != nil {
@@ -37,8 +48,11 @@ func main() {
 	}
 	defer logger.Sync() // 程序退出前刷新日志缓冲区
 
+	build := buildinfo.Get()
 	logger.Info("应用启动中...",
-		zap.String("version", "1.0.0"),
+		zap.String("version", build.Version),
+		zap.String("commit", build.Commit),
+		zap.String("build_time", build.BuildTime),
 		zap.Int("port", cfg.Server.Port),
 	)
 
@@ -49,8 +63,21 @@ func main() {
 	}
 	defer cacheManager.Close() // 程序退出前关闭缓存
 
+	// 3.5. 依赖预检: Redis 连通性与关键上游域名解析
+	// Prefork 模式下只在主进程中预检,避免重复输出
+	if cfg.Precheck.Enabled && !fiber.IsChild() {
+		runPrecheck(cfg, cacheManager)
+	}
+
+	// 根 context: 收到关闭信号时取消,通知预热、后台刷新等后台任务停止
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
+	defer cancelRoot()
+
+	// 后台任务(后台刷新等),优雅关闭时等待其退出
+	var background sync.WaitGroup
+
 	// 4. 创建数据获取服务
-	fetcher := service.NewFetcher(cacheManager)
+	fetcher := service.NewFetcher(cacheManager, cfg)
 
 	// 5. 创建路由注册表
 	registry := routes.NewRegistry(fetcher)
@@ -64,16 +91,20 @@ func main() {
 	registry.Register(routes.NewKuaishouHandler(fetcher)) // 快手
 
 	// 社交平台
-	registry.Register(routes.NewWeiboHandler(fetcher)) // 微博
-	registry.Register(routes.NewZhihuHandler(fetcher)) // 知乎
+	registry.Register(routes.NewWeiboHandler(fetcher))       // 微博
+	registry.Register(routes.NewZhihuHandler(fetcher))       // 知乎
+	registry.Register(routes.NewXiaohongshuHandler(fetcher)) // 小红书
 
 	// 搜索引擎
 	registry.Register(routes.NewBaiduHandler(fetcher)) // 百度
 
 	// 开发者社区
-	registry.Register(routes.NewGitHubHandler(fetcher)) // GitHub
-	registry.Register(routes.NewJuejinHandler(fetcher)) // 掘金
-	registry.Register(routes.NewV2exHandler(fetcher))   // V2EX
+	registry.Register(routes.NewGitHubHandler(fetcher))       // GitHub
+	registry.Register(routes.NewGiteeHandler(fetcher))        // Gitee
+	registry.Register(routes.NewOschinaHandler(fetcher))      // 开源中国
+	registry.Register(routes.NewSegmentFaultHandler(fetcher)) // 思否
+	registry.Register(routes.NewJuejinHandler(fetcher))       // 掘金
+	registry.Register(routes.NewV2exHandler(fetcher))         // V2EX
 
 	// IT资讯/科技媒体
 	registry.Register(routes.NewIthomeHandler(fetcher))     // IT之家
@@ -83,6 +114,7 @@ func main() {
 	registry.Register(routes.NewTechCrunchHandler(fetcher)) // TechCrunch
 	registry.Register(routes.NewTheVergeHandler(fetcher))   // The Verge
 	registry.Register(routes.NewEngadgetHandler(fetcher))   // Engadget
+	registry.Register(routes.NewCnbetaHandler(fetcher))     // cnBeta
 
 	// 新闻资讯
 	registry.Register(routes.NewToutiaoHandler(fetcher))   // 今日头条
@@ -91,7 +123,8 @@ func main() {
 	registry.Register(routes.NewEconomistHandler(fetcher)) // The Economist
 
 	// 电影/娱乐
-	registry.Register(routes.NewDoubanHandler(fetcher)) // 豆瓣电影
+	registry.Register(routes.NewDoubanHandler(fetcher))      // 豆瓣电影
+	registry.Register(routes.NewDoubanEventHandler(fetcher)) // 豆瓣同城
 
 	// 数码社区
 	registry.Register(routes.NewCoolapkHandler(fetcher)) // 酷安
@@ -136,6 +169,7 @@ func main() {
 	registry.Register(routes.NewLolHandler(fetcher))      // 英雄联盟
 	registry.Register(routes.NewGameresHandler(fetcher))  // GameRes
 	registry.Register(routes.NewYystvHandler(fetcher))    // 游研社
+	registry.Register(routes.NewGamesHandler(fetcher))    // 米游社多游戏动态聚合
 
 	// 生活服务
 	registry.Register(routes.NewSmzdmHandler(fetcher))  // 什么值得买
@@ -153,15 +187,12 @@ func main() {
 	registry.Register(routes.NewWeatherAlarmHandler(fetcher))  // 中央气象台
 	registry.Register(routes.NewIthomeXijiayiHandler(fetcher)) // IT之家喜加一
 
-	logger.Info("路由注册完成", zap.Int("total", 61))
-
-	// 6.5. 启动缓存预热(后台协程,不阻塞启动)
-	go warmUpCacheAsync(registry)
+	logger.Info("路由注册完成", zap.Int("total", len(registry.GetHandlers())))
 
 	// 7. 创建 Fiber 应用
 	app := fiber.New(fiber.Config{
-		// 应用名称
-		AppName: "DailyHotApi v1.0.0",
+		// 应用名称(版本号通过 -ldflags 注入)
+		AppName: "DailyHotApi " + build.Version,
 
 		// 禁用启动横幅(可选)
 		DisableStartupMessage: false,
@@ -204,27 +235,57 @@ func main() {
 		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
 	}))
 
-	// Gzip 压缩
-	app.Use(compress.New(compress.Config{
-		Level: compress.LevelBestSpeed, // 平衡压缩率和速度
-	}))
+	// 响应压缩(gzip/br/deflate 根据 Accept-Encoding 自动协商)
+	// 底层 fasthttp 只压缩文本类响应,图片等二进制内容以及已设置 Content-Encoding 的响应不会被二次压缩
+	// SSE 是持续写入的流式响应,压缩会缓冲事件直到连接结束,需要跳过
+	if compress.Level(cfg.Server.CompressLevel) != compress.LevelDisabled {
+		// /all 聚合接口响应体较大,使用最高压缩率换取更小的传输体积
+		app.Use(compress.New(compress.Config{
+			Next: func(c *fiber.Ctx) bool {
+				return c.Path() != "/all"
+			},
+			Level: compress.LevelBestCompression,
+		}))
+
+		// 其他接口使用配置的压缩级别
+		app.Use(compress.New(compress.Config{
+			Next: func(c *fiber.Ctx) bool {
+				return c.Path() == "/all" || strings.HasPrefix(c.Path(), "/sse/")
+			},
+			Level: compress.Level(cfg.Server.CompressLevel),
+		}))
+	}
 
 	// Panic 恢复中间件
 	app.Use(recover.New())
 
 	// 请求日志中间件
 	app.Use(func(c *fiber.Ctx) error {
-		start := c.Context().Time()
+		start := time.Now()
 
 		// 执行下一个中间件/处理器
 		err := c.Next()
 
-		// 记录请求日志
-		logger.Info("请求",
+		// 成功的请求按 log.sampling 采样记录,出错的请求全量记录
+		failed := err != nil || c.Response().StatusCode() >= fiber.StatusBadRequest
+		if !failed && rand.Float64() >= cfg.Log.Sampling {
+			return err
+		}
+
+		// 记录请求日志(写入访问日志,出错的请求按 warn 级别)
+		platform, _ := c.Locals(service.PlatformContextKey).(string)
+		cacheHit, _ := c.Locals(service.CacheHitContextKey).(bool)
+		log := logger.Access().Info
+		if failed {
+			log = logger.Access().Warn
+		}
+		log("请求",
 			zap.String("method", c.Method()),
 			zap.String("path", c.Path()),
 			zap.Int("status", c.Response().StatusCode()),
-			zap.Duration("latency", c.Context().Time().Sub(start)),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("platform", platform),
+			zap.Bool("cache_hit", cacheHit),
 			zap.String("ip", c.IP()),
 		)
 
@@ -232,8 +293,53 @@ func main() {
 	})
 
 	// 9. 注册所有路由
+	// 只作用于平台路由的中间件(鉴权、限流等)可在此之前通过 registry.Use(...) 按顺序注册
+	for alias, target := range cfg.Alias.Routes {
+		registry.Alias(alias, target, cfg.Alias.Redirect)
+	}
+	if cfg.Admin.Enabled {
+		registry.EnableAdmin(cfg.Admin.Token)
+	}
 	registry.RegisterRoutes(app)
 
+	// 自检模式: 输出报告后直接退出,不启动服务
+	if *selfTest {
+		code := runSelfTest(registry)
+		cacheManager.Close()
+		logger.Sync()
+		os.Exit(code)
+	}
+
+	// 9.4. 启动缓存预热(后台协程,不阻塞启动)
+	// 预热在进程内调用平台路由,必须在注册路由之后启动
+	// Prefork 模式下每个子进程都会执行 main,只在主进程中预热,避免上游收到 N 倍请求;
+	// 数据写入共享的 Redis(L2),子进程首次请求时可直接命中
+	if !fiber.IsChild() {
+		go warmUpCacheAsync(rootCtx, registry)
+	}
+
+	// 9.5. 启动后台刷新与 webhook 推送
+	// Prefork 模式下只在主进程中运行,避免多个子进程重复推送
+	if cfg.Webhook.Enabled && !fiber.IsChild() {
+		startWebhook(rootCtx, &background, cfg, registry)
+	}
+
+	// 9.6. 启动 SSE 推送
+	// 订阅连接落在哪个进程就由哪个进程刷新,Prefork 模式下每个进程各自运行
+	if cfg.SSE.Enabled {
+		startSSE(rootCtx, &background, cfg, registry)
+	}
+
+	// 9.7. 启动快照定期写盘
+	// 抓取落在哪个进程就由哪个进程暂存,Prefork 模式下每个进程各自写盘
+	if snapshots := fetcher.Snapshots(); snapshots != nil {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			snapshots.Run(rootCtx)
+		}()
+	}
+
 	// 10. 启动服务器
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	logger.Info("服务器启动成功",
@@ -242,114 +348,221 @@ func main() {
 	)
 
 	// 11. 优雅关闭处理
+	// 顺序: 取消根 context 停止后台任务 -> 停止接收新请求并等待在途请求 -> 等待后台任务退出
+	// -> 等待在途抓取写入缓存 -> 写入暂存的快照,整体不超过 server.shutdown_timeout;之后由 main 中的 defer 关闭缓存
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
+
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 		<-sigChan
 
-		logger.Info("收到关闭信号,正在优雅关闭服务器...")
+		logger.Info("收到关闭信号,正在优雅关闭服务器...",
+			zap.Duration("timeout", cfg.Server.ShutdownTimeout),
+		)
+		cancelRoot()
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
 
 		// 关闭 Fiber 服务器
-		if err := app.Shutdown(); err != nil {
+		if err := app.ShutdownWithContext(ctx); err != nil {
 			logger.Error("服务器关闭失败", zap.Error(err))
 		}
 
-		logger.Info("服务器已关闭")
-		os.Exit(0)
+		if err := waitGroupWithContext(ctx, &background); err != nil {
+			logger.Warn("等待后台任务退出超时", zap.Error(err))
+		}
+
+		if err := fetcher.Wait(ctx); err != nil {
+			logger.Warn("等待在途抓取超时,部分抓取结果可能未写入缓存", zap.Error(err))
+		}
+
+		// 在途抓取结束后写入暂存的快照
+		if snapshots := fetcher.Snapshots(); snapshots != nil {
+			if err := snapshots.Flush(); err != nil {
+				logger.Warn("快照写盘失败", zap.Error(err))
+			}
+		}
 	}()
 
-	// 启动 HTTP 服务
+	// 启动 HTTP 服务,Shutdown 后 Listen 返回
 	if err := app.Listen(addr); err != nil {
 		logger.Fatal("服务器启动失败", zap.Error(err))
 	}
+
+	<-shutdownDone
+	logger.Info("服务器已关闭")
 }
 
-// warmUpCacheAsync 异步缓存预热函数
-// 在后台协程中通过 HTTP 请求预热热门平台的缓存数据
-// 目的: 冷启动时提前加载热门平台数据到缓存,提升首次请求响应速度
-func warmUpCacheAsync(registry *routes.Registry) {
-	// 定义需要预热的热门平台列表
-	// 优先级: 高热度平台优先加载
-	hotPlatforms := []string{
-		"/weibo",      // 微博热搜
-		"/toutiao",    // 今日头条
-		"/baidu",      // 百度热搜
-		"/bilibili",   // B站热榜
-		"/douyin",     // 抖音热点
-		"/github",     // GitHub趋势
-		"/csdn",       // CSDN热门
-		"/v2ex",       // V2EX最热
-		"/hackernews", // Hacker News
-		"/zhihu",      // 知乎热榜
+// waitGroupWithContext 等待 WaitGroup 归零,ctx 到期时返回 ctx.Err()
+func waitGroupWithContext(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	// 延迟启动预热,等待 HTTP 服务完全启动
-	// 并给予配置加载充足时间
-	time.Sleep(1 * time.Second)
+// runSelfTest 执行平台自检并输出报告,返回进程退出码
+// 最多 5 个平台并发,单个平台 30 秒超时
+func runSelfTest(registry *routes.Registry) int {
+	logger.Info("开始平台自检...", zap.Int("platforms", len(registry.GetHandlers())))
+	start := time.Now()
+
+	results := registry.SelfTest(context.Background(), 5, 30*time.Second)
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PLATFORM\tSTATUS\tCOUNT\tDURATION\tERROR")
+	for _, result := range results {
+		status := "OK"
+		if !result.Success {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			result.Platform, status, result.Count, result.Duration.Round(time.Millisecond), result.Error)
+	}
+	w.Flush()
 
-	logger.Info("开始缓存预热...", zap.Int("platforms", len(hotPlatforms)))
-	startTime := time.Now()
+	fmt.Printf("\n共 %d 个平台,成功 %d,失败 %d,总耗时 %s\n",
+		len(results), len(results)-failed, failed, time.Since(start).Round(time.Millisecond))
 
-	// 使用 WaitGroup 控制并发
-	// 最多同时 3 个协程进行预热,避免启动时资源占用过高
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 3) // 最多 3 个并发
-
-	// 对每个热门平台执行预热
-	for _, path := range hotPlatforms {
-		wg.Add(1)
-		go func(p string) {
-			defer wg.Done()
-
-			// 获取信号量(控制并发)
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			// 设置请求超时
-			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-			defer cancel()
-
-			// 发起 HTTP 请求进行预热
-			// 使用 HTTP 客户端而不是直接调用处理器,避免上下文问题
-			httpClient := registry.GetFetcher().GetHTTPClient()
-			if httpClient == nil {
-				logger.Warn("HTTP 客户端未初始化",
-					zap.String("platform", p),
-				)
-				return
-			}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
 
-			// 构造本地访问 URL
-			url := fmt.Sprintf("http://127.0.0.1:6688%s", p)
+// runPrecheck 执行依赖预检并汇总打印结果
+// 有失败项且配置了 precheck.fail_fast 时拒绝启动,否则只打印警告
+func runPrecheck(cfg *config.Config, cacheManager *cache.Manager) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Precheck.Timeout)
+	defer cancel()
+
+	results := service.Precheck(ctx, cfg, cacheManager)
+	failed := make([]string, 0)
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s %s", result.Kind, result.Target))
+			logger.Warn("依赖预检失败",
+				zap.String("kind", result.Kind),
+				zap.String("target", result.Target),
+				zap.Duration("latency", result.Latency),
+				zap.Error(result.Err),
+			)
+			continue
+		}
+		logger.Info("依赖预检通过",
+			zap.String("kind", result.Kind),
+			zap.String("target", result.Target),
+			zap.Duration("latency", result.Latency),
+		)
+	}
 
-			// 执行预热请求
-			_, err := httpClient.Get(url, map[string]string{
-				"User-Agent": "DailyHotApi/CacheWarmer",
-			})
+	if len(failed) == 0 {
+		logger.Info("依赖预检完成,全部通过", zap.Int("total", len(results)))
+		return
+	}
+	if cfg.Precheck.FailFast {
+		cacheManager.Close()
+		logger.Fatal("依赖预检失败,拒绝启动(precheck.fail_fast)",
+			zap.Int("total", len(results)),
+			zap.Strings("failed", failed),
+		)
+	}
+	logger.Warn("依赖预检完成,部分依赖不可用,服务将继续启动",
+		zap.Int("total", len(results)),
+		zap.Strings("failed", failed),
+	)
+}
 
-			if err != nil {
-				logger.Warn("缓存预热失败",
-					zap.String("platform", p),
-					zap.Error(err),
-				)
-				return
-			}
+// startWebhook 启动后台刷新,并在 Top N 变化时推送到订阅的 webhook
+// ctx 取消后后台刷新停止、在途推送中止,background 在刷新协程与推送都退出后归零
+func startWebhook(ctx context.Context, background *sync.WaitGroup, cfg *config.Config, registry *routes.Registry) {
+	notifier := service.NewWebhookNotifier(cfg.Webhook)
+	platforms := notifier.Platforms()
+	if len(platforms) == 0 {
+		logger.Warn("webhook 已启用但没有配置订阅,跳过后台刷新")
+		return
+	}
 
-			logger.Info("缓存预热成功",
-				zap.String("platform", p),
-				zap.Duration("elapsed", time.Since(startTime)),
-			)
+	refresher := service.NewRefresher(cfg.Webhook.Interval, func(ctx context.Context, platform string) ([]models.HotData, error) {
+		resp, err := registry.Dispatch(ctx, "/"+platform)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	})
+	refresher.Watch(platforms...)
+	refresher.OnRefresh(notifier.HandleRefresh)
 
-			// 释放上下文
-			_ = ctx
-		}(path)
-	}
+	logger.Info("webhook 推送已启用", zap.Strings("platforms", platforms))
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		defer notifier.Wait()
+		refresher.Start(ctx)
+	}()
+}
+
+// startSSE 启动 SSE 订阅中心及其后台刷新
+// 只刷新有订阅者的平台,且读取缓存数据,缓存过期后由正常的抓取流程回源;
+// ctx 取消后关闭订阅中心,所有 SSE 连接随之结束,避免阻塞服务关闭
+func startSSE(ctx context.Context, background *sync.WaitGroup, cfg *config.Config, registry *routes.Registry) {
+	refresher := service.NewRefresher(cfg.SSE.Interval, func(ctx context.Context, platform string) ([]models.HotData, error) {
+		resp, err := registry.Current(ctx, "/"+platform)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	})
+	hub := service.NewHub(cfg.SSE.MaxConnections, refresher)
+	refresher.OnRefresh(hub.HandleRefresh)
+	registry.EnableSSE(hub, cfg.SSE.Heartbeat)
+
+	logger.Info("SSE 推送已启用",
+		zap.Duration("interval", cfg.SSE.Interval),
+		zap.Int("max_connections", cfg.SSE.MaxConnections),
+	)
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		defer hub.Close()
+		refresher.Start(ctx)
+	}()
+}
+
+// warmUpCacheAsync 异步缓存预热函数
+// 在后台协程中预热热门平台的缓存数据
+// 目的: 冷启动时提前加载热门平台数据到缓存,提升首次请求响应速度
+// 已有缓存的平台通过一次批量读取跳过,其余平台在进程内抓取后一次批量写入缓存
+// 只应在单个进程中调用(Prefork 模式下为主进程)
+// ctx 取消(服务关闭)后不再发起新的预热请求
+func warmUpCacheAsync(rootCtx context.Context, registry *routes.Registry) {
+	targets := routes.DefaultWarmUpTargets
+
+	// 延迟启动预热,避免与服务启动争抢资源
+	time.Sleep(1 * time.Second)
+
+	logger.Info("开始缓存预热...", zap.Int("platforms", len(targets)))
+	startTime := time.Now()
 
-	// 等待所有预热协程完成
-	wg.Wait()
+	result := registry.WarmUp(rootCtx, targets)
 
 	logger.Info("缓存预热完成",
 		zap.Duration("total_time", time.Since(startTime)),
-		zap.Int("warmed_platforms", len(hotPlatforms)),
+		zap.Int("cached_platforms", result.Cached),
+		zap.Int("warmed_platforms", result.Warmed),
+		zap.Int("failed_platforms", result.Failed),
 	)
 }
@@ -0,0 +1,63 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// 构建信息,编译时通过 ldflags 注入,例如:
+//
+//	go build -ldflags "-X github.com/dailyhot/api/internal/buildinfo.Version=1.2.0 \
+//	  -X github.com/dailyhot/api/internal/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/dailyhot/api/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/api
+//
+// 未注入时(如 go run)使用下面的默认值,Commit 会尝试从 Go 自带的 VCS 信息中读取
+var (
+	Version   = "dev"     // 版本号
+	Commit    = "unknown" // Git 提交
+	BuildTime = "unknown" // 构建时间(UTC)
+)
+
+// Info 构建信息
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get 获取构建信息
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	// 未通过 ldflags 注入时,回退到 go build 记录的 VCS 信息
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "unknown" && setting.Value != "" {
+					info.Commit = shortCommit(setting.Value)
+				}
+			case "vcs.time":
+				if info.BuildTime == "unknown" && setting.Value != "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
+
+// shortCommit 截取提交哈希的前 7 位
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -2,7 +2,10 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/allegro/bigcache/v3"
@@ -12,6 +15,17 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrCacheMiss 两层缓存都不存在该键
+// 与 Redis 连接失败等后端错误区分,可用 errors.Is 判断
+var ErrCacheMiss = errors.New("缓存未命中")
+
+// ErrL2Disabled 未启用 L2 缓存(Redis)
+var ErrL2Disabled = errors.New("L2 缓存(Redis)未启用")
+
+// ErrTTLUnknown 键存在但无法得知剩余过期时间
+// BigCache 不支持单个键的 TTL,L1 中没有写入记录的条目(如重启前写入)返回该错误
+var ErrTTLUnknown = errors.New("无法获取缓存剩余时间")
+
 // Cache 缓存管理器接口
 // 定义了缓存的基本操作方法
 type Cache interface {
@@ -21,6 +35,12 @@ type Cache interface {
 	// Set 设置缓存数据
 	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
 
+	// MGet 批量获取缓存数据,只返回命中的键
+	MGet(ctx context.Context, keys []string) (map[string][]byte, error)
+
+	// MSet 批量设置缓存数据
+	MSet(ctx context.Context, items []Item) error
+
 	// Delete 删除缓存
 	Delete(ctx context.Context, key string) error
 
@@ -28,6 +48,13 @@ type Cache interface {
 	Close() error
 }
 
+// Item 批量写入的缓存条目
+type Item struct {
+	Key        string        // 缓存键
+	Value      []byte        // 缓存数据
+	Expiration time.Duration // 过期时间,0 表示使用默认值
+}
+
 // Manager 双层缓存管理器
 // 就像一个智能仓库管理系统:
 // - L1(BigCache): 超快的本地货架,但容量有限
@@ -38,6 +65,15 @@ type Manager struct {
 	cfg       *config.Config     // 配置信息
 	l1Enabled bool               // L1 是否启用
 	l2Enabled bool               // L2 是否启用
+	l2InitErr error              // L2 初始化失败的原因,失败后降级为仅使用 L1
+
+	l1Evictions atomic.Int64 // L1 因容量不足(达到 HardMaxCacheSize)被逐出的条目数
+	l1Expired   atomic.Int64 // L1 因过期被清理的条目数
+	l1Rejected  atomic.Int64 // L1 写入被拒绝的次数(如条目超过单个分片容量)
+
+	// l1WrittenAt L1 条目的写入时间: 键 -> time.Time,用于估算 L1 条目的剩余时间
+	// 条目被 BigCache 移除或被 Delete 删除时一并清理
+	l1WrittenAt sync.Map
 }
 
 // NewManager 创建缓存管理器
@@ -62,6 +98,7 @@ func NewManager(cfg *config.Config) (*Manager, error) {
 			// Redis 失败不影响整体运行,只记录警告
 			logger.Warn("L2 缓存(Redis)初始化失败", zap.Error(err))
 			m.l2Enabled = false
+			m.l2InitErr = err
 		} else {
 			logger.Info("L2 缓存(Redis)初始化成功")
 		}
@@ -94,6 +131,9 @@ func (m *Manager) initL1Cache() error {
 
 		// Verbose: 是否输出详细日志
 		Verbose: false,
+
+		// OnRemoveWithReason: 按移除原因统计逐出与过期次数,供 /stats 观察容量配置是否合适
+		OnRemoveWithReason: m.onL1Remove,
 	}
 
 	cache, err := bigcache.New(context.Background(), config)
@@ -105,6 +145,17 @@ func (m *Manager) initL1Cache() error {
 	return nil
 }
 
+// onL1Remove BigCache 条目被移除时的回调
+func (m *Manager) onL1Remove(key string, entry []byte, reason bigcache.RemoveReason) {
+	m.l1WrittenAt.Delete(key)
+	switch reason {
+	case bigcache.NoSpace:
+		m.l1Evictions.Add(1)
+	case bigcache.Expired:
+		m.l1Expired.Add(1)
+	}
+}
+
 // initL2Cache 初始化 Redis
 func (m *Manager) initL2Cache() error {
 	// 创建 Redis 客户端
@@ -130,11 +181,25 @@ func (m *Manager) initL2Cache() error {
 	return nil
 }
 
+// PingL2 检查 L2 缓存(Redis)的连通性
+// 未启用 Redis 时返回 ErrL2Disabled;启动时连接失败、已降级为仅 L1 时返回当时的错误
+func (m *Manager) PingL2(ctx context.Context) error {
+	if m.l2InitErr != nil {
+		return m.l2InitErr
+	}
+	if !m.l2Enabled {
+		return ErrL2Disabled
+	}
+	return m.l2Cache.Ping(ctx).Err()
+}
+
 // Get 获取缓存数据
 // 智能查找流程:
 // 1. 先查 L1(内存),超快
 // 2. L1 没有,查 L2(Redis)
 // 3. L2 有数据,回填到 L1,下次更快
+//
+// 两层都不存在时返回 ErrCacheMiss;L2 读取出错(如 Redis 故障)时返回包装后的真实错误
 func (m *Manager) Get(ctx context.Context, key string) ([]byte, error) {
 	// 1. 尝试从 L1 获取
 	if m.l1Enabled {
@@ -153,18 +218,19 @@ func (m *Manager) Get(ctx context.Context, key string) ([]byte, error) {
 			// L2 命中,回填到 L1
 			logger.Debug("L2 缓存命中", zap.String("key", key))
 			if m.l1Enabled {
-				_ = m.l1Cache.Set(key, data)
+				_ = m.setL1(key, data)
 			}
 			return data, nil
 		}
 		if err != redis.Nil {
 			// Redis 错误(非 key 不存在)
 			logger.Warn("L2 缓存读取失败", zap.String("key", key), zap.Error(err))
+			return nil, fmt.Errorf("L2 缓存读取失败: %w", err)
 		}
 	}
 
 	// 两层缓存都未命中
-	return nil, fmt.Errorf("缓存未命中: %s", key)
+	return nil, fmt.Errorf("%w: %s", ErrCacheMiss, key)
 }
 
 // Set 设置缓存数据
@@ -177,8 +243,17 @@ func (m *Manager) Set(ctx context.Context, key string, value []byte, expiration
 
 	// 写入 L1 缓存
 	if m.l1Enabled {
-		if err := m.l1Cache.Set(key, value); err != nil {
-			logger.Warn("L1 缓存写入失败", zap.String("key", key), zap.Error(err))
+		if err := m.setL1(key, value); err != nil {
+			if !errors.Is(err, bigcache.ErrEntryNotFound) {
+				// 条目超过单个分片容量(HardMaxCacheSize / 分片数)时会被直接拒绝,只能走 L2 或回源
+				m.l1Rejected.Add(1)
+			}
+			logger.Warn("L1 缓存写入失败",
+				zap.String("key", key),
+				zap.Int("size", len(value)),
+				zap.Int("hard_max_cache_size_mb", m.cfg.Cache.HardMaxCacheSize),
+				zap.Error(err),
+			)
 		} else {
 			logger.Debug("L1 缓存写入成功", zap.String("key", key))
 		}
@@ -196,6 +271,151 @@ func (m *Manager) Set(ctx context.Context, key string, value []byte, expiration
 	return nil
 }
 
+// setL1 写入 L1 缓存并记录写入时间
+func (m *Manager) setL1(key string, value []byte) error {
+	if err := m.l1Cache.Set(key, value); err != nil {
+		return err
+	}
+	m.l1WrittenAt.Store(key, time.Now())
+	return nil
+}
+
+// TTL 获取缓存键的剩余过期时间
+// 启用 L2 时以 Redis 的 TTL 为准(多实例共享,结果精确);键未设置过期时间时返回 -1。
+// L2 未启用、读取失败或不存在该键时按 L1 估算: BigCache 所有条目共用 default_expire 存活时间,
+// 剩余时间 = default_expire - 写入至今的时长,条目实际会在下一次清理(cleanup_interval)时才被移除,
+// 因此估算值可能偏小;已超时但尚未清理的条目返回 0
+//
+// 两层都不存在时返回 ErrCacheMiss;L1 中存在但没有写入记录时返回 ErrTTLUnknown
+func (m *Manager) TTL(ctx context.Context, key string) (time.Duration, error) {
+	if m.l2Enabled {
+		ttl, err := m.l2Cache.TTL(ctx, key).Result()
+		switch {
+		case err != nil:
+			logger.Warn("L2 缓存 TTL 读取失败", zap.String("key", key), zap.Error(err))
+		case ttl == -1:
+			return -1, nil
+		case ttl >= 0:
+			return ttl, nil
+		}
+	}
+
+	if !m.l1Enabled {
+		return 0, fmt.Errorf("%w: %s", ErrCacheMiss, key)
+	}
+	if _, err := m.l1Cache.Get(key); err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrCacheMiss, key)
+	}
+	writtenAt, ok := m.l1WrittenAt.Load(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrTTLUnknown, key)
+	}
+	remaining := m.cfg.Cache.DefaultExpire - time.Since(writtenAt.(time.Time))
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, nil
+}
+
+// MGet 批量获取缓存数据
+// L1 逐个查找,L1 未命中的键通过一次 Redis pipeline 从 L2 读取并回填 L1,
+// 聚合多个缓存键时只需一次 Redis 往返
+//
+// 返回命中的键 -> 数据,未命中的键不出现在结果中;
+// L2 读取出错时返回已命中的部分与包装后的错误
+func (m *Manager) MGet(ctx context.Context, keys []string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(keys))
+
+	// 1. 逐个查 L1
+	missing := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := result[key]; ok {
+			continue
+		}
+		if m.l1Enabled {
+			if data, err := m.l1Cache.Get(key); err == nil {
+				result[key] = data
+				continue
+			}
+		}
+		missing = append(missing, key)
+	}
+
+	if !m.l2Enabled || len(missing) == 0 {
+		return result, nil
+	}
+
+	// 2. L1 未命中的键一次性从 L2 读取
+	pipe := m.l2Cache.Pipeline()
+	cmds := make([]*redis.StringCmd, len(missing))
+	for i, key := range missing {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	// 单个键不存在时 Exec 返回 redis.Nil,以各命令自身的结果为准
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		logger.Warn("L2 缓存批量读取失败", zap.Int("keys", len(missing)), zap.Error(err))
+		return result, fmt.Errorf("L2 缓存批量读取失败: %w", err)
+	}
+
+	for i, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			continue
+		}
+		result[missing[i]] = data
+		if m.l1Enabled {
+			_ = m.setL1(missing[i], data)
+		}
+	}
+
+	logger.Debug("批量读取缓存",
+		zap.Int("keys", len(keys)),
+		zap.Int("hits", len(result)),
+		zap.Int("l2_lookups", len(missing)),
+	)
+	return result, nil
+}
+
+// MSet 批量设置缓存数据
+// L1 逐个写入,L2 通过一次 Redis pipeline 写入;与 Set 一样,写入失败只记录日志
+func (m *Manager) MSet(ctx context.Context, items []Item) error {
+	// 写入 L1 缓存
+	if m.l1Enabled {
+		for _, item := range items {
+			if err := m.setL1(item.Key, item.Value); err != nil {
+				if !errors.Is(err, bigcache.ErrEntryNotFound) {
+					m.l1Rejected.Add(1)
+				}
+				logger.Warn("L1 缓存写入失败",
+					zap.String("key", item.Key),
+					zap.Int("size", len(item.Value)),
+					zap.Int("hard_max_cache_size_mb", m.cfg.Cache.HardMaxCacheSize),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	// 写入 L2 缓存
+	if m.l2Enabled && len(items) > 0 {
+		pipe := m.l2Cache.Pipeline()
+		for _, item := range items {
+			expiration := item.Expiration
+			if expiration == 0 {
+				expiration = m.cfg.Cache.DefaultExpire
+			}
+			pipe.Set(ctx, item.Key, item.Value, expiration)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			logger.Warn("L2 缓存批量写入失败", zap.Int("keys", len(items)), zap.Error(err))
+		} else {
+			logger.Debug("L2 缓存批量写入成功", zap.Int("keys", len(items)))
+		}
+	}
+
+	return nil
+}
+
 // Delete 删除缓存
 // 同时删除两层缓存
 func (m *Manager) Delete(ctx context.Context, key string) error {
@@ -204,6 +424,7 @@ func (m *Manager) Delete(ctx context.Context, key string) error {
 		if err := m.l1Cache.Delete(key); err != nil {
 			logger.Warn("L1 缓存删除失败", zap.String("key", key), zap.Error(err))
 		}
+		m.l1WrittenAt.Delete(key)
 	}
 
 	// 删除 L2 缓存
@@ -244,12 +465,27 @@ func (m *Manager) GetStats() map[string]interface{} {
 
 	if m.l1Enabled && m.l1Cache != nil {
 		l1Stats := m.l1Cache.Stats()
+
+		// 命中率 = 命中次数 / 总查询次数
+		hitRate := 0.0
+		if total := l1Stats.Hits + l1Stats.Misses; total > 0 {
+			hitRate = float64(l1Stats.Hits) / float64(total)
+		}
+
 		stats["l1"] = map[string]interface{}{
 			"hits":       l1Stats.Hits,
 			"misses":     l1Stats.Misses,
+			"hit_rate":   hitRate,
 			"del_hits":   l1Stats.DelHits,
 			"del_misses": l1Stats.DelMisses,
 			"collisions": l1Stats.Collisions,
+			// 容量与逐出情况,可据此调整 cache.hard_max_cache_size
+			"len":                    m.l1Cache.Len(),
+			"capacity":               m.l1Cache.Capacity(), // 已分配的字节数
+			"hard_max_cache_size_mb": m.cfg.Cache.HardMaxCacheSize,
+			"evictions":              m.l1Evictions.Load(),
+			"expired":                m.l1Expired.Load(),
+			"rejected":               m.l1Rejected.Load(),
 		}
 	}
 
@@ -257,12 +493,19 @@ func (m *Manager) GetStats() map[string]interface{} {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
 
-		info, err := m.l2Cache.Info(ctx, "stats").Result()
-		if err == nil {
-			stats["l2"] = map[string]interface{}{
-				"info": info,
+		l2Stats := map[string]interface{}{
+			"pool": m.l2Cache.PoolStats(),
+		}
+
+		// INFO 全量信息较敏感,只有配置允许时才返回
+		if m.cfg.Redis.ExposeInfo {
+			info, err := m.l2Cache.Info(ctx, "stats").Result()
+			if err == nil {
+				l2Stats["info"] = info
 			}
 		}
+
+		stats["l2"] = l2Stats
 	}
 
 	return stats
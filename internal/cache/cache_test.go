@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dailyhot/api/internal/config"
+)
+
+// newTestManager 创建只启用 L1 的缓存管理器,mr 不为 nil 时同时启用 L2
+func newTestManager(t *testing.T, mr *miniredis.Miniredis) *Manager {
+	t.Helper()
+	cfg := &config.Config{
+		Cache: config.CacheConfig{
+			Enabled:          true,
+			DefaultExpire:    time.Minute,
+			CleanupInterval:  time.Minute,
+			MaxEntries:       100,
+			MaxEntrySize:     1024,
+			HardMaxCacheSize: 8,
+		},
+	}
+	if mr != nil {
+		port, _ := strconv.Atoi(mr.Port())
+		cfg.Redis = config.RedisConfig{
+			Enabled:  true,
+			Host:     mr.Host(),
+			Port:     port,
+			PoolSize: 2,
+			Timeout:  time.Second,
+		}
+	}
+
+	m, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Close() })
+	return m
+}
+
+func TestManagerGetMiss(t *testing.T) {
+	m := newTestManager(t, nil)
+	if _, err := m.Get(context.Background(), "nope"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("err = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestManagerMGetL1(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t, nil)
+	_ = m.Set(ctx, "a", []byte("1"), 0)
+	_ = m.Set(ctx, "b", []byte("2"), 0)
+
+	got, err := m.MGet(ctx, []string{"a", "b", "c", "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || string(got["a"]) != "1" || string(got["b"]) != "2" {
+		t.Errorf("MGet = %v, want a=1 b=2", got)
+	}
+}
+
+func TestManagerMGetL2(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	m := newTestManager(t, mr)
+
+	_ = m.Set(ctx, "a", []byte("1"), 0)
+	// 只存在于 L2 的键(如其他实例写入)
+	_ = mr.Set("b", "2")
+
+	got, err := m.MGet(ctx, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || string(got["a"]) != "1" || string(got["b"]) != "2" {
+		t.Fatalf("MGet = %v, want a=1 b=2", got)
+	}
+
+	// L2 命中的键已回填到 L1
+	mr.Del("b")
+	if data, err := m.Get(ctx, "b"); err != nil || string(data) != "2" {
+		t.Errorf("Get(b) after backfill = %q, %v", data, err)
+	}
+}
+
+func TestManagerMGetL2Error(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	m := newTestManager(t, mr)
+
+	_ = m.Set(ctx, "a", []byte("1"), 0)
+	mr.Close()
+
+	// L2 故障时返回错误,同时保留 L1 已命中的部分
+	got, err := m.MGet(ctx, []string{"a", "b"})
+	if err == nil {
+		t.Error("err = nil, want L2 error")
+	}
+	if len(got) != 1 || string(got["a"]) != "1" {
+		t.Errorf("MGet = %v, want a=1", got)
+	}
+}
+
+func TestManagerMSet(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	m := newTestManager(t, mr)
+
+	err := m.MSet(ctx, []Item{
+		{Key: "a", Value: []byte("1")},
+		{Key: "b", Value: []byte("2"), Expiration: 10 * time.Second},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 两层都已写入,未指定过期时间的条目使用默认值
+	if got, _ := mr.Get("a"); got != "1" {
+		t.Errorf("L2 a = %q, want 1", got)
+	}
+	if ttl := mr.TTL("a"); ttl != time.Minute {
+		t.Errorf("L2 TTL(a) = %s, want 1m", ttl)
+	}
+	if ttl := mr.TTL("b"); ttl != 10*time.Second {
+		t.Errorf("L2 TTL(b) = %s, want 10s", ttl)
+	}
+	mr.FlushAll()
+	got, err := m.MGet(ctx, []string{"a", "b"})
+	if err != nil || string(got["a"]) != "1" || string(got["b"]) != "2" {
+		t.Errorf("MGet from L1 = %v, %v, want a=1 b=2", got, err)
+	}
+}
+
+func TestManagerMSetL2Error(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	m := newTestManager(t, mr)
+	mr.Close()
+
+	// L2 故障时与 Set 一样只记录日志,L1 仍然写入
+	if err := m.MSet(ctx, []Item{{Key: "a", Value: []byte("1")}}); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if data, err := m.l1Cache.Get("a"); err != nil || string(data) != "1" {
+		t.Errorf("L1 a = %q, %v", data, err)
+	}
+}
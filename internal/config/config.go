@@ -14,6 +14,19 @@ type Config struct {
 	Cache  CacheConfig  `mapstructure:"cache"`  // 缓存配置
 	Redis  RedisConfig  `mapstructure:"redis"`  // Redis 配置
 	Log    LogConfig    `mapstructure:"log"`    // 日志配置
+	HTTP   HTTPConfig   `mapstructure:"http"`   // 上游 HTTP 客户端配置
+
+	Webhook  WebhookConfig  `mapstructure:"webhook"`  // 热榜变化推送配置
+	Snapshot SnapshotConfig `mapstructure:"snapshot"` // 抓取快照持久化配置
+	SSE      SSEConfig      `mapstructure:"sse"`      // SSE 实时推送配置
+	Precheck PrecheckConfig `mapstructure:"precheck"` // 启动依赖预检配置
+	Admin    AdminConfig    `mapstructure:"admin"`    // 管理接口配置
+
+	// Platforms 按平台覆盖的抓取配置: 平台路由名 -> 配置,如 platforms.bilibili.timeout
+	Platforms map[string]PlatformConfig `mapstructure:"platforms"`
+
+	// Alias 平台路由别名,兼容老客户端使用的路径
+	Alias AliasConfig `mapstructure:"alias"`
 }
 
 // ServerConfig 服务器配置
@@ -24,6 +37,14 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`  // 读取超时时间
 	WriteTimeout time.Duration `mapstructure:"write_timeout"` // 写入超时时间
 	Prefork      bool          `mapstructure:"prefork"`       // 是否启用多进程模式(提高并发性能)
+
+	// ShutdownTimeout 优雅关闭的最长等待时间
+	// 包括等待在途请求、后台任务与在途抓取写入缓存,超时后直接退出
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	// CompressLevel 响应压缩级别(gzip/br/deflate 按 Accept-Encoding 协商)
+	// -1: 关闭压缩, 0: 默认, 1: 最快速度, 2: 最高压缩率
+	CompressLevel int `mapstructure:"compress_level"`
 }
 
 // CacheConfig 内存缓存配置 (BigCache)
@@ -35,6 +56,13 @@ type CacheConfig struct {
 	MaxEntries       int           `mapstructure:"max_entries"`         // 最大条目数
 	MaxEntrySize     int           `mapstructure:"max_entry_size"`      // 单个条目最大大小(字节)
 	HardMaxCacheSize int           `mapstructure:"hard_max_cache_size"` // 缓存总大小上限(MB)
+
+	// StaleExpire 陈旧数据保留时间
+	// 抓取超时时,在这个时间内成功抓取过的数据仍可作为陈旧缓存返回
+	StaleExpire time.Duration `mapstructure:"stale_expire"`
+
+	// StaleMaxEntries 陈旧数据最多保留的缓存键数,超出时淘汰最久未使用的键
+	StaleMaxEntries int `mapstructure:"stale_max_entries"`
 }
 
 // RedisConfig Redis 配置
@@ -47,6 +75,10 @@ type RedisConfig struct {
 	DB       int           `mapstructure:"db"`        // 数据库编号(0-15)
 	PoolSize int           `mapstructure:"pool_size"` // 连接池大小
 	Timeout  time.Duration `mapstructure:"timeout"`   // 连接超时时间
+
+	// ExposeInfo 是否在 /stats 中返回 Redis INFO 全量信息
+	// INFO 里包含服务器版本、内存、客户端等敏感信息,默认关闭
+	ExposeInfo bool `mapstructure:"expose_info"`
 }
 
 // LogConfig 日志配置
@@ -59,6 +91,155 @@ type LogConfig struct {
 	MaxBackups int    `mapstructure:"max_backups"` // 保留的旧日志文件数量
 	MaxAge     int    `mapstructure:"max_age"`     // 日志文件保留天数
 	Compress   bool   `mapstructure:"compress"`    // 是否压缩旧日志
+
+	// Sampling 成功请求的访问日志采样率(0~1),1 表示全部记录;出错的请求始终全量记录
+	Sampling float64 `mapstructure:"sampling"`
+
+	// AccessLog 独立的访问日志,未配置 output_path 时访问日志与应用日志写在一起
+	AccessLog AccessLogConfig `mapstructure:"access_log"`
+}
+
+// AccessLogConfig 访问日志配置
+// 级别与轮转参数独立于应用日志
+type AccessLogConfig struct {
+	OutputPath string `mapstructure:"output_path"` // 访问日志文件路径,为空时写入应用日志
+	Level      string `mapstructure:"level"`       // 日志级别,出错(状态码 >= 400)的请求按 warn 记录
+	Format     string `mapstructure:"format"`      // 输出格式,为空时与 log.format 一致
+	MaxSize    int    `mapstructure:"max_size"`    // 单个日志文件最大大小(MB)
+	MaxBackups int    `mapstructure:"max_backups"` // 保留的旧日志文件数量
+	MaxAge     int    `mapstructure:"max_age"`     // 日志文件保留天数
+	Compress   bool   `mapstructure:"compress"`    // 是否压缩旧日志
+}
+
+// HTTPConfig 上游 HTTP 客户端配置
+type HTTPConfig struct {
+	Transport TransportConfig `mapstructure:"transport"` // 连接池参数
+
+	// ThrottleStatuses 视为上游风控的状态码,触发后该 host 进入冷却与降频,默认 429 与 403
+	ThrottleStatuses []int `mapstructure:"throttle_statuses"`
+}
+
+// TransportConfig HTTP 连接池配置
+// 调大每个 host 的空闲连接数可以提升连接复用,减少 TLS 握手和对上游的连接压力
+type TransportConfig struct {
+	MaxIdleConns        int           `mapstructure:"max_idle_conns"`          // 所有 host 的最大空闲连接数
+	MaxIdleConnsPerHost int           `mapstructure:"max_idle_conns_per_host"` // 单个 host 的最大空闲连接数
+	MaxConnsPerHost     int           `mapstructure:"max_conns_per_host"`      // 单个 host 的最大连接数,0 表示不限制
+	IdleConnTimeout     time.Duration `mapstructure:"idle_conn_timeout"`       // 空闲连接保留时间
+	TLSHandshakeTimeout time.Duration `mapstructure:"tls_handshake_timeout"`   // TLS 握手超时时间
+	ForceAttemptHTTP2   bool          `mapstructure:"force_attempt_http2"`     // 是否优先尝试 HTTP/2
+}
+
+// WebhookConfig 热榜变化推送配置
+// 后台定时刷新订阅的平台,Top N 条目发生变化时 POST 到对应的 webhook 地址
+type WebhookConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`  // 是否启用推送
+	Interval time.Duration `mapstructure:"interval"` // 后台刷新间隔
+	TopN     int           `mapstructure:"top_n"`    // 比较前 N 条的变化
+	Retries  int           `mapstructure:"retries"`  // 推送失败的重试次数
+	Timeout  time.Duration `mapstructure:"timeout"`  // 单次推送超时时间
+	Debounce time.Duration `mapstructure:"debounce"` // 防抖窗口,窗口内相同的变化只推送一次
+
+	// SeenTTL 已推送条目的记忆时长,期间重新进入 Top N 的条目不再作为新增推送;0 表示不去重
+	SeenTTL time.Duration `mapstructure:"seen_ttl"`
+	// SeenCapacity 每个平台在一个 seen_ttl 周期内预计出现的条目数,决定去重集合的内存占用
+	SeenCapacity int `mapstructure:"seen_capacity"`
+
+	// Subscriptions 订阅关系: 平台路由名 -> webhook 地址列表
+	// 例如 weibo: ["https://example.com/hook"]
+	Subscriptions map[string][]string `mapstructure:"subscriptions"`
+}
+
+// SnapshotConfig 抓取快照持久化配置
+// 开启后每次成功抓取的结果按 <dir>/<平台>/<yyyy-mm-dd-HH>.json 保存,便于历史回溯
+type SnapshotConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`        // 是否保存快照
+	Dir           string `mapstructure:"dir"`            // 快照目录
+	RetentionDays int    `mapstructure:"retention_days"` // 快照保留天数,过期的快照会被自动删除
+
+	// FlushInterval 快照写盘间隔: 抓取结果先暂存在内存中,按该间隔批量写入文件
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+// SSEConfig Server-Sent Events 实时推送配置
+// 有客户端订阅 /sse/<平台> 时,后台按 interval 刷新该平台,数据变化时推送给订阅者
+type SSEConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`         // 是否启用 /sse 推送
+	Interval       time.Duration `mapstructure:"interval"`        // 后台刷新间隔(读取缓存,缓存过期时才回源)
+	Heartbeat      time.Duration `mapstructure:"heartbeat"`       // 心跳间隔,保持连接不被代理断开
+	MaxConnections int           `mapstructure:"max_connections"` // 同时保持的最大连接数(所有平台合计)
+}
+
+// PrecheckConfig 启动依赖预检配置
+// 启动时 Ping Redis(仅启用时)并解析关键上游域名,汇总打印结果
+type PrecheckConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`   // 是否在启动时预检
+	FailFast bool          `mapstructure:"fail_fast"` // 预检失败时是否拒绝启动,默认只打印警告
+	Timeout  time.Duration `mapstructure:"timeout"`   // 预检整体超时时间
+	Hosts    []string      `mapstructure:"hosts"`     // 需要检查 DNS 解析的上游域名
+}
+
+// AdminConfig 管理接口配置
+// /admin/selftest 与 /admin/coverage 会集中抓取所有平台,默认关闭;开启时必须配置 token,
+// 请求需携带 Authorization: Bearer <token>
+type AdminConfig struct {
+	Enabled bool   `mapstructure:"enabled"` // 是否开启 /admin 管理接口
+	Token   string `mapstructure:"token"`   // 访问令牌
+}
+
+// AliasConfig 平台路由别名配置
+type AliasConfig struct {
+	Redirect bool              `mapstructure:"redirect"` // true 时 301 重定向到规范路径,否则内部转发
+	Routes   map[string]string `mapstructure:"routes"`   // 别名 -> 规范平台名,如 douban: douban-movie
+}
+
+// PlatformConfig 单个平台的抓取配置
+// 未配置的项使用全局默认值
+type PlatformConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"` // 单次抓取超时时间,默认取 HTTP 客户端超时
+
+	// MinInterval 最小回源间隔,距上次真实抓取未达间隔时即使缓存过期也返回陈旧数据
+	// 用于保护频率限制严格的上游,0 表示不限制
+	MinInterval time.Duration `mapstructure:"min_interval"`
+
+	// Cookie 抓取时携带的登录态 Cookie,仅支持的平台生效(如 hostloc),未配置时只抓取公开内容
+	Cookie string `mapstructure:"cookie"`
+
+	// Token 官方 API 的访问令牌,仅支持的平台生效(如 producthunt),未配置时使用公开数据源
+	Token string `mapstructure:"token"`
+
+	// Filter 条目过滤规则,与平台内置的广告识别规则合并生效
+	Filter FilterConfig `mapstructure:"filter"`
+
+	// FallbackURLs 备用源地址(如 RSSHub 实例、镜像站),主源失败或返回空数据时依次尝试
+	// 仅声明了备用源解析函数的平台生效
+	FallbackURLs []string `mapstructure:"fallback_urls"`
+
+	// Fields 响应条目的字段映射,兼容依赖旧字段名的前端
+	Fields FieldsConfig `mapstructure:"fields"`
+}
+
+// FieldsConfig 响应条目字段映射配置
+// 字段名为条目的 JSON 字段名(如 cover、mobileUrl),不区分大小写
+type FieldsConfig struct {
+	Aliases map[string][]string `mapstructure:"aliases"` // 规范字段 -> 同时输出的别名,如 cover: [pic, img]
+	Rename  map[string]string   `mapstructure:"rename"`  // 规范字段 -> 新名称,规范字段不再输出
+}
+
+// FilterConfig 条目过滤规则配置
+type FilterConfig struct {
+	IDs             []string `mapstructure:"ids"`              // ID 黑名单
+	TitlePatterns   []string `mapstructure:"title_patterns"`   // 标题正则,匹配即过滤
+	Flags           []string `mapstructure:"flags"`            // extra 中的标记字段,值为真时过滤
+	DisableDefaults bool     `mapstructure:"disable_defaults"` // 是否停用平台内置规则
+}
+
+// Platform 获取指定平台的配置,未配置时返回零值
+func (c *Config) Platform(name string) PlatformConfig {
+	if c == nil || c.Platforms == nil {
+		return PlatformConfig{}
+	}
+	return c.Platforms[name]
 }
 
 var globalConfig *Config
@@ -101,6 +282,11 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("解析配置失败: %w", err)
 	}
 
+	// 校验配置,非法配置直接拒绝启动
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("配置校验失败:\n%w", err)
+	}
+
 	globalConfig = &cfg
 	return &cfg, nil
 }
@@ -114,6 +300,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.read_timeout", 10*time.Second)
 	v.SetDefault("server.write_timeout", 10*time.Second)
 	v.SetDefault("server.prefork", false)
+	v.SetDefault("server.shutdown_timeout", 15*time.Second)
+	v.SetDefault("server.compress_level", 1) // 最快速度
 
 	// 内存缓存默认配置
 	v.SetDefault("cache.enabled", true)
@@ -122,6 +310,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("cache.max_entries", 10000)
 	v.SetDefault("cache.max_entry_size", 500)      // 500 字节
 	v.SetDefault("cache.hard_max_cache_size", 256) // 256 MB
+	v.SetDefault("cache.stale_expire", 1*time.Hour)
+	v.SetDefault("cache.stale_max_entries", 1000)
 
 	// Redis 默认配置
 	v.SetDefault("redis.enabled", false)
@@ -131,6 +321,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("redis.db", 0)
 	v.SetDefault("redis.pool_size", 10)
 	v.SetDefault("redis.timeout", 5*time.Second)
+	v.SetDefault("redis.expose_info", false)
 
 	// 日志默认配置
 	v.SetDefault("log.level", "info")
@@ -140,6 +331,64 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("log.max_backups", 5)
 	v.SetDefault("log.max_age", 30)
 	v.SetDefault("log.compress", true)
+	v.SetDefault("log.sampling", 1.0)
+	v.SetDefault("log.access_log.output_path", "")
+	v.SetDefault("log.access_log.level", "info")
+	v.SetDefault("log.access_log.format", "")
+	v.SetDefault("log.access_log.max_size", 100)
+	v.SetDefault("log.access_log.max_backups", 5)
+	v.SetDefault("log.access_log.max_age", 30)
+	v.SetDefault("log.access_log.compress", true)
+
+	// HTTP 连接池默认配置
+	v.SetDefault("http.transport.max_idle_conns", 200)
+	v.SetDefault("http.transport.max_idle_conns_per_host", 20)
+	v.SetDefault("http.transport.max_conns_per_host", 0)
+	v.SetDefault("http.transport.idle_conn_timeout", 90*time.Second)
+	v.SetDefault("http.transport.tls_handshake_timeout", 10*time.Second)
+	v.SetDefault("http.transport.force_attempt_http2", true)
+	v.SetDefault("http.throttle_statuses", []int{429, 403})
+
+	// 热榜变化推送默认配置
+	v.SetDefault("webhook.enabled", false)
+	v.SetDefault("webhook.interval", 5*time.Minute)
+	v.SetDefault("webhook.top_n", 10)
+	v.SetDefault("webhook.retries", 3)
+	v.SetDefault("webhook.timeout", 5*time.Second)
+	v.SetDefault("webhook.debounce", 30*time.Minute)
+	v.SetDefault("webhook.seen_ttl", 24*time.Hour)
+	v.SetDefault("webhook.seen_capacity", 10000)
+
+	// 抓取快照默认配置
+	v.SetDefault("snapshot.enabled", false)
+	v.SetDefault("snapshot.dir", "data/snapshots")
+	v.SetDefault("snapshot.retention_days", 7)
+	v.SetDefault("snapshot.flush_interval", time.Minute)
+
+	// SSE 推送默认配置
+	v.SetDefault("sse.enabled", true)
+	v.SetDefault("sse.interval", time.Minute)
+	v.SetDefault("sse.heartbeat", 15*time.Second)
+	v.SetDefault("sse.max_connections", 100)
+
+	// 管理接口默认关闭
+	v.SetDefault("admin.enabled", false)
+
+	// 启动依赖预检默认配置
+	v.SetDefault("precheck.enabled", true)
+	v.SetDefault("precheck.fail_fast", false)
+	v.SetDefault("precheck.timeout", 5*time.Second)
+	v.SetDefault("precheck.hosts", []string{
+		"api.bilibili.com",
+		"m.weibo.cn",
+		"api.zhihu.com",
+		"top.baidu.com",
+		"www.douyin.com",
+		"github.com",
+	})
+
+	// 平台路由别名默认配置
+	v.SetDefault("alias.redirect", false)
 }
 
 // Get 获取全局配置实例
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Validate 校验配置是否合法
+// 一次性返回所有错误,方便一次改完;任何一项不合法都应拒绝启动
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	// 服务器
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port 必须在 1-65535 之间,当前为 %d", c.Server.Port)
+	check(c.Server.ReadTimeout > 0, "server.read_timeout 必须大于 0,当前为 %s", c.Server.ReadTimeout)
+	check(c.Server.WriteTimeout > 0, "server.write_timeout 必须大于 0,当前为 %s", c.Server.WriteTimeout)
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout 必须大于 0,当前为 %s", c.Server.ShutdownTimeout)
+	check(c.Server.CompressLevel >= -1 && c.Server.CompressLevel <= 2, "server.compress_level 必须在 -1-2 之间,当前为 %d", c.Server.CompressLevel)
+
+	// 内存缓存
+	if c.Cache.Enabled {
+		check(c.Cache.DefaultExpire > 0, "cache.default_expire 必须大于 0,当前为 %s", c.Cache.DefaultExpire)
+		check(c.Cache.CleanupInterval > 0, "cache.cleanup_interval 必须大于 0,当前为 %s", c.Cache.CleanupInterval)
+		check(c.Cache.MaxEntries > 0, "cache.max_entries 必须大于 0,当前为 %d", c.Cache.MaxEntries)
+		check(c.Cache.MaxEntrySize > 0, "cache.max_entry_size 必须大于 0,当前为 %d", c.Cache.MaxEntrySize)
+		check(c.Cache.HardMaxCacheSize >= 0, "cache.hard_max_cache_size 不能为负数(0 表示不限制),当前为 %d", c.Cache.HardMaxCacheSize)
+	}
+	check(c.Cache.StaleExpire >= 0, "cache.stale_expire 不能为负数,当前为 %s", c.Cache.StaleExpire)
+	check(c.Cache.StaleMaxEntries > 0, "cache.stale_max_entries 必须大于 0,当前为 %d", c.Cache.StaleMaxEntries)
+
+	// Redis
+	if c.Redis.Enabled {
+		check(c.Redis.Host != "", "redis.host 不能为空")
+		check(c.Redis.Port > 0 && c.Redis.Port <= 65535, "redis.port 必须在 1-65535 之间,当前为 %d", c.Redis.Port)
+		check(c.Redis.DB >= 0 && c.Redis.DB <= 15, "redis.db 必须在 0-15 之间,当前为 %d", c.Redis.DB)
+		check(c.Redis.PoolSize > 0, "redis.pool_size 必须大于 0,当前为 %d", c.Redis.PoolSize)
+		check(c.Redis.Timeout > 0, "redis.timeout 必须大于 0,当前为 %s", c.Redis.Timeout)
+	}
+
+	// 日志
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level 必须是 debug/info/warn/error 之一,当前为 %q", c.Log.Level))
+	}
+	switch c.Log.Format {
+	case "json", "console":
+	default:
+		errs = append(errs, fmt.Errorf("log.format 必须是 json/console 之一,当前为 %q", c.Log.Format))
+	}
+	check(c.Log.Sampling >= 0 && c.Log.Sampling <= 1, "log.sampling 必须在 0~1 之间,当前为 %v", c.Log.Sampling)
+	switch c.Log.AccessLog.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.access_log.level 必须是 debug/info/warn/error 之一,当前为 %q", c.Log.AccessLog.Level))
+	}
+	switch c.Log.AccessLog.Format {
+	case "", "json", "console":
+	default:
+		errs = append(errs, fmt.Errorf("log.access_log.format 必须是 json/console 之一,当前为 %q", c.Log.AccessLog.Format))
+	}
+
+	// HTTP 连接池
+	check(c.HTTP.Transport.MaxIdleConns >= 0, "http.transport.max_idle_conns 不能为负数,当前为 %d", c.HTTP.Transport.MaxIdleConns)
+	check(c.HTTP.Transport.MaxIdleConnsPerHost >= 0, "http.transport.max_idle_conns_per_host 不能为负数,当前为 %d", c.HTTP.Transport.MaxIdleConnsPerHost)
+	check(c.HTTP.Transport.MaxConnsPerHost >= 0, "http.transport.max_conns_per_host 不能为负数(0 表示不限制),当前为 %d", c.HTTP.Transport.MaxConnsPerHost)
+	check(c.HTTP.Transport.IdleConnTimeout >= 0, "http.transport.idle_conn_timeout 不能为负数,当前为 %s", c.HTTP.Transport.IdleConnTimeout)
+	check(c.HTTP.Transport.TLSHandshakeTimeout >= 0, "http.transport.tls_handshake_timeout 不能为负数,当前为 %s", c.HTTP.Transport.TLSHandshakeTimeout)
+
+	// 热榜变化推送
+	if c.Webhook.Enabled {
+		check(c.Webhook.Interval > 0, "webhook.interval 必须大于 0,当前为 %s", c.Webhook.Interval)
+		check(c.Webhook.TopN >= 0, "webhook.top_n 不能为负数,当前为 %d", c.Webhook.TopN)
+		check(c.Webhook.Retries >= 0, "webhook.retries 不能为负数,当前为 %d", c.Webhook.Retries)
+		check(c.Webhook.Timeout > 0, "webhook.timeout 必须大于 0,当前为 %s", c.Webhook.Timeout)
+		check(c.Webhook.Debounce >= 0, "webhook.debounce 不能为负数,当前为 %s", c.Webhook.Debounce)
+		check(c.Webhook.SeenTTL >= 0, "webhook.seen_ttl 不能为负数,当前为 %s", c.Webhook.SeenTTL)
+		check(c.Webhook.SeenCapacity > 0, "webhook.seen_capacity 必须大于 0,当前为 %d", c.Webhook.SeenCapacity)
+		for platform, urls := range c.Webhook.Subscriptions {
+			for _, rawURL := range urls {
+				u, err := url.Parse(rawURL)
+				check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+					"webhook.subscriptions.%s 中的地址不合法: %q", platform, rawURL)
+			}
+		}
+	}
+
+	// 抓取快照
+	if c.Snapshot.Enabled {
+		check(c.Snapshot.Dir != "", "snapshot.dir 不能为空")
+		check(c.Snapshot.RetentionDays > 0, "snapshot.retention_days 必须大于 0,当前为 %d", c.Snapshot.RetentionDays)
+		check(c.Snapshot.FlushInterval > 0, "snapshot.flush_interval 必须大于 0,当前为 %s", c.Snapshot.FlushInterval)
+	}
+
+	// SSE 推送
+	if c.SSE.Enabled {
+		check(c.SSE.Interval > 0, "sse.interval 必须大于 0,当前为 %s", c.SSE.Interval)
+		check(c.SSE.Heartbeat > 0, "sse.heartbeat 必须大于 0,当前为 %s", c.SSE.Heartbeat)
+		check(c.SSE.MaxConnections > 0, "sse.max_connections 必须大于 0,当前为 %d", c.SSE.MaxConnections)
+	}
+
+	// 启动依赖预检
+	if c.Precheck.Enabled {
+		check(c.Precheck.Timeout > 0, "precheck.timeout 必须大于 0,当前为 %s", c.Precheck.Timeout)
+		for _, host := range c.Precheck.Hosts {
+			check(strings.TrimSpace(host) != "" && !strings.Contains(host, "/"),
+				"precheck.hosts 中的域名不合法(只填域名,不带协议与路径): %q", host)
+		}
+	}
+
+	// 管理接口
+	if c.Admin.Enabled {
+		check(strings.TrimSpace(c.Admin.Token) != "", "admin.enabled 开启时必须配置 admin.token")
+	}
+
+	// 平台级配置
+	for name, platform := range c.Platforms {
+		check(platform.Timeout >= 0, "platforms.%s.timeout 不能为负数,当前为 %s", name, platform.Timeout)
+		check(platform.MinInterval >= 0, "platforms.%s.min_interval 不能为负数,当前为 %s", name, platform.MinInterval)
+		// 陈旧数据只保留 stale_expire,间隔更长时兜底数据会先过期
+		check(platform.MinInterval <= c.Cache.StaleExpire,
+			"platforms.%s.min_interval(%s) 不能超过 cache.stale_expire(%s)", name, platform.MinInterval, c.Cache.StaleExpire)
+		for _, pattern := range platform.Filter.TitlePatterns {
+			_, err := regexp.Compile(pattern)
+			check(err == nil, "platforms.%s.filter.title_patterns 中的正则不合法: %q", name, pattern)
+		}
+		for _, rawURL := range platform.FallbackURLs {
+			u, err := url.Parse(rawURL)
+			check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+				"platforms.%s.fallback_urls 中的地址不合法: %q", name, rawURL)
+		}
+		for field, aliases := range platform.Fields.Aliases {
+			for _, alias := range aliases {
+				check(strings.TrimSpace(alias) != "", "platforms.%s.fields.aliases.%s 中的别名不能为空", name, field)
+			}
+		}
+		for field, newName := range platform.Fields.Rename {
+			check(strings.TrimSpace(newName) != "", "platforms.%s.fields.rename.%s 的新名称不能为空", name, field)
+		}
+	}
+
+	// 平台路由别名
+	for alias, target := range c.Alias.Routes {
+		check(strings.Trim(target, "/ ") != "", "alias.routes.%s 的目标平台不能为空", alias)
+	}
+
+	return errors.Join(errs...)
+}
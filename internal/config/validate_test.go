@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loadTestConfig 从 yaml 内容加载配置(未写的项取默认值)
+func loadTestConfig(t *testing.T, content string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Load(path)
+}
+
+func TestLoadDefaultsAreValid(t *testing.T) {
+	cfg, err := loadTestConfig(t, "server:\n  port: 8080\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 8080 || cfg.Cache.DefaultExpire != 5*time.Minute {
+		t.Errorf("port = %d, default_expire = %s", cfg.Server.Port, cfg.Cache.DefaultExpire)
+	}
+}
+
+func TestLoadRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		message string
+	}{
+		{"负数端口", "server:\n  port: -1\n", "server.port"},
+		{"端口超出范围", "server:\n  port: 70000\n", "server.port"},
+		{"读取超时为 0", "server:\n  read_timeout: 0s\n", "server.read_timeout"},
+		{"非法的缓存时长", "cache:\n  default_expire: -5m\n", "cache.default_expire"},
+		{"缓存条目数为 0", "cache:\n  max_entries: 0\n", "cache.max_entries"},
+		{"非法的日志级别", "log:\n  level: verbose\n", "log.level"},
+		{"非法的日志格式", "log:\n  format: xml\n", "log.format"},
+		{"采样率超过 1", "log:\n  sampling: 1.5\n", "log.sampling"},
+		{"Redis 端口非法", "redis:\n  enabled: true\n  port: 0\n", "redis.port"},
+		{"Redis DB 超出范围", "redis:\n  enabled: true\n  db: 16\n", "redis.db"},
+		{"webhook 地址非法", "webhook:\n  enabled: true\n  subscriptions:\n    weibo: [\"ftp://example.com\"]\n", "webhook.subscriptions.weibo"},
+		{"管理接口缺少 token", "admin:\n  enabled: true\n", "admin.token"},
+		{"平台正则非法", "platforms:\n  weibo:\n    filter:\n      title_patterns: [\"(\"]\n", "platforms.weibo.filter.title_patterns"},
+		{"回源间隔超过陈旧数据保留时间", "platforms:\n  weibo:\n    min_interval: 2h\n", "platforms.weibo.min_interval"},
+		{"别名目标为空", "alias:\n  routes:\n    wb: \"/\"\n", "alias.routes.wb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadTestConfig(t, tt.content)
+			if err == nil {
+				t.Fatal("err = nil, want validation error")
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("err = %v, want message containing %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	_, err := loadTestConfig(t, "server:\n  port: 0\nlog:\n  level: verbose\n")
+	if err == nil {
+		t.Fatal("err = nil, want validation error")
+	}
+	for _, message := range []string{"server.port", "log.level"} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("err = %v, want message containing %q", err, message)
+		}
+	}
+}
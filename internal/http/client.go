@@ -1,6 +1,8 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,17 +13,31 @@ import (
 	"go.uber.org/zap"
 )
 
+// StatusError 上游返回非 200 状态码
+// 可用 errors.As 取出状态码
+type StatusError struct {
+	StatusCode int
+}
+
+// Error 实现 error 接口
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP 状态码异常: %d", e.StatusCode)
+}
+
 // Client HTTP 客户端封装
 // 基于 Resty 库,提供统一的 HTTP 请求能力
 type Client struct {
 	client     *resty.Client    // Resty HTTP 客户端
 	objectPool *pool.ObjectPool // 对象池管理器(可选,用于性能优化)
+	throttle   *hostThrottle    // 按 host 的风控退避(默认 429/403)
 }
 
 // NewClient 创建 HTTP 客户端
 // 配置了合理的超时、重试等参数
 func NewClient() *Client {
 	client := resty.New()
+	client.SetTransport(newTransport(DefaultTransportOptions)) // 显式配置连接池,提升连接复用
+	throttle := newHostThrottle()
 
 	// 基础配置
 	client.
@@ -42,9 +58,13 @@ func NewClient() *Client {
 			zap.String("method", req.Method),
 			zap.String("url", req.URL),
 		)
-		return nil
+		// 处于风控冷却期的 host 直接拒绝,降频期内排队等待
+		return throttle.acquire(requestHost(req.URL))
 	})
 
+	// 添加响应拦截器(解码 br/deflate 响应体)
+	client.OnAfterResponse(decodeResponseBody)
+
 	// 添加响应拦截器(记录日志和错误)
 	client.OnAfterResponse(func(c *resty.Client, resp *resty.Response) error {
 		logger.Debug("HTTP 响应",
@@ -52,6 +72,7 @@ func NewClient() *Client {
 			zap.Int("status", resp.StatusCode()),
 			zap.Duration("time", resp.Time()),
 		)
+		throttle.observe(resp.Request.Context(), requestHost(resp.Request.URL), resp.StatusCode(), resp.Header())
 		return nil
 	})
 
@@ -66,6 +87,7 @@ func NewClient() *Client {
 	return &Client{
 		client:     client,
 		objectPool: pool.NewObjectPool(), // 初始化对象池以支持缓冲区复用
+		throttle:   throttle,
 	}
 }
 
@@ -74,7 +96,14 @@ func NewClient() *Client {
 // headers: 自定义请求头(可选)
 // 返回: 响应体字节数组
 func (c *Client) Get(url string, headers map[string]string) ([]byte, error) {
-	req := c.client.R()
+	return c.GetContext(context.Background(), url, headers)
+}
+
+// GetContext 发起可取消的 GET 请求
+// ctx 超时或取消时请求立即中止,返回的错误链保留 context.DeadlineExceeded / context.Canceled,
+// 上层可用 errors.Is 区分超时与取消
+func (c *Client) GetContext(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	req := c.client.R().SetContext(ctx)
 
 	// 设置自定义请求头
 	if headers != nil {
@@ -89,18 +118,71 @@ func (c *Client) Get(url string, headers map[string]string) ([]byte, error) {
 
 	// 检查 HTTP 状态码
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("HTTP 状态码异常: %d", resp.StatusCode())
+		return nil, &StatusError{StatusCode: resp.StatusCode()}
 	}
 
 	return resp.Body(), nil
 }
 
+// RequestMetrics 单次请求的埋点数据
+type RequestMetrics struct {
+	URL        string        // 请求地址
+	Duration   time.Duration // 总耗时(包含重试等待)
+	Retries    int           // 重试次数(不含首次请求)
+	StatusCode int           // 最终状态码,请求未完成时为 0
+	Size       int           // 响应体大小(字节)
+	Throttled  bool          // 是否触发风控(默认 429/403)或因冷却期被拒绝
+}
+
+// GetWithMetrics 发起可取消的 GET 请求并返回埋点数据
+// 行为与 GetContext 一致,额外返回耗时、重试次数、状态码和响应大小
+// 请求失败时 metrics 仍然有效,便于统计失败请求
+func (c *Client) GetWithMetrics(ctx context.Context, url string, headers map[string]string) ([]byte, RequestMetrics, error) {
+	metrics := RequestMetrics{URL: url}
+	req := c.client.R().SetContext(ctx)
+
+	// 设置自定义请求头
+	if headers != nil {
+		req.SetHeaders(headers)
+	}
+
+	// 发起请求
+	start := time.Now()
+	resp, err := req.Get(url)
+	metrics.Duration = time.Since(start)
+	if req.Attempt > 1 {
+		metrics.Retries = req.Attempt - 1
+	}
+	if resp != nil {
+		metrics.StatusCode = resp.StatusCode()
+		metrics.Size = len(resp.Body())
+	}
+	metrics.Throttled = errors.Is(err, ErrHostThrottled) ||
+		(metrics.StatusCode != 0 && c.throttle.triggered(ctx, metrics.StatusCode))
+	if err != nil {
+		return nil, metrics, fmt.Errorf("GET 请求失败: %w", err)
+	}
+
+	// 检查 HTTP 状态码
+	if resp.StatusCode() != 200 {
+		return nil, metrics, &StatusError{StatusCode: resp.StatusCode()}
+	}
+
+	return resp.Body(), metrics, nil
+}
+
 // Post 发起 POST 请求
 // url: 请求地址
 // body: 请求体(JSON 对象或字符串)
 // headers: 自定义请求头(可选)
 func (c *Client) Post(url string, body interface{}, headers map[string]string) ([]byte, error) {
-	req := c.client.R()
+	return c.PostContext(context.Background(), url, body, headers)
+}
+
+// PostContext 发起可取消的 POST 请求
+// ctx 超时或取消时请求立即中止,错误链保留 context 错误
+func (c *Client) PostContext(ctx context.Context, url string, body interface{}, headers map[string]string) ([]byte, error) {
+	req := c.client.R().SetContext(ctx)
 
 	// 设置请求体
 	req.SetBody(body)
@@ -118,7 +200,7 @@ func (c *Client) Post(url string, body interface{}, headers map[string]string) (
 
 	// 检查 HTTP 状态码
 	if resp.StatusCode() != 200 && resp.StatusCode() != 201 {
-		return nil, fmt.Errorf("HTTP 状态码异常: %d", resp.StatusCode())
+		return nil, &StatusError{StatusCode: resp.StatusCode()}
 	}
 
 	return resp.Body(), nil
@@ -147,7 +229,7 @@ func (c *Client) GetWithCookies(url string, cookies map[string]string, headers m
 
 	// 检查 HTTP 状态码
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("HTTP 状态码异常: %d", resp.StatusCode())
+		return nil, &StatusError{StatusCode: resp.StatusCode()}
 	}
 
 	return resp.Body(), nil
@@ -174,7 +256,7 @@ func (c *Client) GetJSON(url string, result interface{}, headers map[string]stri
 
 	// 检查 HTTP 状态码
 	if resp.StatusCode() != 200 {
-		return fmt.Errorf("HTTP 状态码异常: %d", resp.StatusCode())
+		return &StatusError{StatusCode: resp.StatusCode()}
 	}
 
 	return nil
@@ -183,7 +265,13 @@ func (c *Client) GetJSON(url string, result interface{}, headers map[string]stri
 // GetWithResponse 发起 GET 请求并返回完整的响应对象（包括响应头）
 // 用于需要访问响应头的场景（如获取 Cookie）
 func (c *Client) GetWithResponse(url string, headers map[string]string) (*resty.Response, error) {
-	req := c.client.R()
+	return c.GetWithResponseContext(context.Background(), url, headers)
+}
+
+// GetWithResponseContext 发起可取消的 GET 请求并返回完整的响应对象
+// ctx 超时或取消时请求立即中止,错误链保留 context 错误
+func (c *Client) GetWithResponseContext(ctx context.Context, url string, headers map[string]string) (*resty.Response, error) {
+	req := c.client.R().SetContext(ctx)
 
 	// 设置自定义请求头
 	if headers != nil {
@@ -207,6 +295,11 @@ func (c *Client) SetTimeout(timeout time.Duration) *Client {
 	return c
 }
 
+// Timeout 获取当前的超时时间
+func (c *Client) Timeout() time.Duration {
+	return c.client.GetClient().Timeout
+}
+
 // SetRetry 设置重试次数
 func (c *Client) SetRetry(count int, waitTime time.Duration) *Client {
 	c.client.SetRetryCount(count).SetRetryWaitTime(waitTime)
@@ -226,6 +319,29 @@ func (c *Client) SetProxy(proxyURL string) *Client {
 	return c
 }
 
+// SetTransportOptions 调整底层连接池参数
+// 已通过 SetProxy 设置的代理会保留
+func (c *Client) SetTransportOptions(opts TransportOptions) *Client {
+	transport := newTransport(opts)
+	if current, ok := c.client.GetClient().Transport.(*http.Transport); ok {
+		transport.Proxy = current.Proxy
+	}
+	c.client.SetTransport(transport)
+	return c
+}
+
+// SetThrottleStatuses 设置视为触发风控的状态码,默认为 DefaultThrottleStatuses
+// 单个请求可通过 WithThrottleStatuses 覆盖
+func (c *Client) SetThrottleStatuses(codes []int) *Client {
+	c.throttle.setStatuses(codes)
+	return c
+}
+
+// ThrottleStats 获取各 host 的风控退避状态
+func (c *Client) ThrottleStats() []ThrottleStat {
+	return c.throttle.snapshot()
+}
+
 // GetRawClient 获取原始 Resty 客户端
 // 用于一些高级定制场景
 func (c *Client) GetRawClient() *resty.Client {
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/go-resty/resty/v2"
+)
+
+// maxDecodedBodySize 解码后响应体的最大字节数,防止压缩炸弹耗尽内存
+const maxDecodedBodySize = 32 << 20
+
+// decodeResponseBody 按 Content-Encoding 解码响应体
+// 标准库只在自己添加 Accept-Encoding 时透明解压 gzip,resty 也只处理 gzip;
+// handler 显式声明 "gzip, deflate, br" 时上游可能返回 br/deflate,这里统一解码
+// 解码后删除 Content-Encoding 头,避免重复处理
+func decodeResponseBody(_ *resty.Client, resp *resty.Response) error {
+	encoding := strings.TrimSpace(resp.Header().Get("Content-Encoding"))
+	// 单独的 gzip 已由 resty 解压
+	if encoding == "" || strings.EqualFold(encoding, "gzip") || len(resp.Body()) == 0 {
+		return nil
+	}
+
+	body, err := decodeBody(resp.Body(), encoding, maxDecodedBodySize)
+	if err != nil {
+		return fmt.Errorf("解码响应体失败(%s): %w", encoding, err)
+	}
+
+	resp.SetBody(body)
+	resp.Header().Del("Content-Encoding")
+	return nil
+}
+
+// decodeBody 按编码列表解码,多重编码按声明的逆序依次解码
+// 每一层解码结果超过 maxSize 字节时返回错误
+func decodeBody(body []byte, encoding string, maxSize int64) ([]byte, error) {
+	codings := strings.Split(encoding, ",")
+	for i := len(codings) - 1; i >= 0; i-- {
+		var reader io.Reader
+		switch coding := strings.ToLower(strings.TrimSpace(codings[i])); coding {
+		case "", "identity":
+			continue
+		case "br":
+			reader = brotli.NewReader(bytes.NewReader(body))
+		case "gzip", "x-gzip":
+			gz, err := gzip.NewReader(bytes.NewReader(body))
+			if err != nil {
+				return nil, err
+			}
+			reader = gz
+		case "deflate":
+			reader = newDeflateReader(body)
+		default:
+			return nil, fmt.Errorf("不支持的编码: %s", coding)
+		}
+
+		// 多读一个字节用于判断是否超限
+		decoded, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(decoded)) > maxSize {
+			return nil, fmt.Errorf("解码后的响应体超过 %d 字节", maxSize)
+		}
+		body = decoded
+	}
+	return body, nil
+}
+
+// newDeflateReader 创建 deflate 解码器
+// 规范要求 deflate 带 zlib 头,但不少服务器直接返回裸 deflate 数据,两种都兼容
+func newDeflateReader(body []byte) io.Reader {
+	if zr, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+		return zr
+	}
+	return flate.NewReader(bytes.NewReader(body))
+}
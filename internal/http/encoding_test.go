@@ -0,0 +1,101 @@
+package http
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func compress(t *testing.T, coding string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch coding {
+	case "br":
+		w = brotli.NewWriter(&buf)
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w = fw
+	default:
+		t.Fatalf("unknown coding %s", coding)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeBody(t *testing.T) {
+	plain := []byte(`{"data":"热榜"}`)
+
+	tests := []struct {
+		name     string
+		body     []byte
+		encoding string
+	}{
+		{"br", compress(t, "br", plain), "br"},
+		{"大写 br", compress(t, "br", plain), " BR "},
+		{"zlib deflate", compress(t, "deflate", plain), "deflate"},
+		{"裸 deflate", compress(t, "raw-deflate", plain), "deflate"},
+		{"gzip 后 br", compress(t, "br", compress(t, "gzip", plain)), "gzip, br"},
+		{"identity", plain, "identity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeBody(tt.body, tt.encoding, maxDecodedBodySize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, plain) {
+				t.Errorf("got %q, want %q", got, plain)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyUnsupported(t *testing.T) {
+	if _, err := decodeBody([]byte("x"), "zstd", maxDecodedBodySize); err == nil {
+		t.Error("want error for unsupported encoding")
+	}
+}
+
+func TestDecodeBodyLimit(t *testing.T) {
+	const limit = 1 << 10
+	// 高度可压缩的数据,压缩后远小于 limit
+	bomb := bytes.Repeat([]byte{'a'}, 64*limit)
+
+	for _, coding := range []string{"br", "deflate"} {
+		t.Run(coding, func(t *testing.T) {
+			body := compress(t, coding, bomb)
+			if len(body) >= limit {
+				t.Fatalf("compressed size %d should be below limit", len(body))
+			}
+			_, err := decodeBody(body, coding, limit)
+			if err == nil || !strings.Contains(err.Error(), "超过") {
+				t.Errorf("err = %v, want size limit error", err)
+			}
+
+			// 恰好等于上限时正常解码
+			exact := compress(t, coding, bomb[:limit])
+			if got, err := decodeBody(exact, coding, limit); err != nil || len(got) != limit {
+				t.Errorf("exact limit: len = %d, err = %v", len(got), err)
+			}
+		})
+	}
+}
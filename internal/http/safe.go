@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrUnsafeTarget 目标地址不是公网 HTTP(S) 地址
+// 抓取用户提供的 URL 时用于防止 SSRF(访问内网服务、云元数据接口等)
+var ErrUnsafeTarget = errors.New("不允许访问的目标地址")
+
+// ValidatePublicURL 校验用户提供的 URL
+// 只允许 http/https,主机名解析出的所有 IP 都必须是公网地址
+func ValidatePublicURL(ctx context.Context, raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsafeTarget, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: 只支持 http/https", ErrUnsafeTarget)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("%w: 不允许携带用户信息", ErrUnsafeTarget)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("%w: 缺少主机名", ErrUnsafeTarget)
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("解析主机名失败: %w", err)
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return nil, fmt.Errorf("%w: %s 解析到非公网地址 %s", ErrUnsafeTarget, host, addr.IP)
+		}
+	}
+	return u, nil
+}
+
+// NewPublicOnlyClient 创建只能访问公网地址的 HTTP 客户端
+// 在建立连接时再次校验实际连接的 IP,防止 DNS 重绑定绕过 ValidatePublicURL;
+// 不使用环境变量代理,重定向最多 maxRedirects 次且每一跳都重新校验
+func NewPublicOnlyClient(timeout time.Duration, maxRedirects int) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrUnsafeTarget, host)
+			}
+			return nil
+		},
+	}
+
+	transport := newTransport(DefaultTransportOptions)
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("重定向次数超过 %d 次", maxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("%w: 重定向到 %s", ErrUnsafeTarget, req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// isPublicIP 是否为公网地址
+// 排除回环、内网、链路本地(含云元数据 169.254.169.254)、组播、未指定地址以及 CGNAT 地址段
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		// 100.64.0.0/10 运营商级 NAT,0.0.0.0/8 本网络
+		if (ip4[0] == 100 && ip4[1]&0xc0 == 64) || ip4[0] == 0 {
+			return false
+		}
+	}
+	return true
+}
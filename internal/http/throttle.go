@@ -0,0 +1,233 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// throttleBaseCooldown 首次触发风控后的冷却时间,连续触发时翻倍
+	throttleBaseCooldown = 30 * time.Second
+	// throttleMaxCooldown 冷却时间上限
+	throttleMaxCooldown = 10 * time.Minute
+	// throttleSlowWindow 冷却结束后的降频时长
+	throttleSlowWindow = 5 * time.Minute
+	// throttleSlowInterval 降频期间同一 host 两次请求的最小间隔
+	throttleSlowInterval = 2 * time.Second
+)
+
+// ErrHostThrottled 上游处于风控冷却期,请求未发出
+// 可用 errors.Is 判断
+var ErrHostThrottled = errors.New("上游风控冷却中")
+
+// DefaultThrottleStatuses 默认视为触发风控的状态码
+var DefaultThrottleStatuses = []int{http.StatusTooManyRequests, http.StatusForbidden}
+
+// throttleStatusesKey 请求上下文中覆盖风控状态码的键
+type throttleStatusesKey struct{}
+
+// WithThrottleStatuses 返回只把 codes 视为风控的上下文,覆盖客户端的设置
+// 用于状态码含义特殊的上游: 如酷安用 403 表示签名失效,处理器需要立即重新签名重试,进入冷却反而会让重试直接失败
+func WithThrottleStatuses(ctx context.Context, codes ...int) context.Context {
+	return context.WithValue(ctx, throttleStatusesKey{}, statusSet(codes))
+}
+
+// statusSet 将状态码列表转换为集合
+func statusSet(codes []int) map[int]bool {
+	set := make(map[int]bool, len(codes))
+	for _, code := range codes {
+		set[code] = true
+	}
+	return set
+}
+
+// ThrottleStat 单个 host 的风控状态
+type ThrottleStat struct {
+	Host          string `json:"host"`           // 上游域名
+	Events        int64  `json:"events"`         // 累计触发风控(默认 429/403)次数
+	Strikes       int    `json:"strikes"`        // 连续触发次数,决定冷却时长
+	CooldownUntil string `json:"cooldown_until"` // 冷却截止时间(RFC3339)
+	SlowUntil     string `json:"slow_until"`     // 降频截止时间(RFC3339)
+}
+
+// hostState 单个 host 的风控记录
+type hostState struct {
+	events        int64
+	strikes       int
+	cooldownUntil time.Time
+	slowUntil     time.Time
+	nextRequest   time.Time // 降频期间下一次允许发出请求的时间
+}
+
+// hostThrottle 按 host 的风控退避
+//
+// 上游返回 statuses 中的状态码(默认 429 与 403)时认为触发了风控;
+// 用 403 表示鉴权或签名失效的上游可通过 WithThrottleStatuses 按请求覆盖
+//
+//  1. 冷却期内直接拒绝对该 host 的请求(返回 ErrHostThrottled),避免立即重试再次被封
+//  2. 冷却期结束后进入降频期,请求之间至少间隔 throttleSlowInterval
+//  3. 降频期结束后的成功请求会清零连续触发次数
+type hostThrottle struct {
+	mu       sync.Mutex
+	hosts    map[string]*hostState
+	statuses map[int]bool // 视为风控的状态码
+}
+
+// newHostThrottle 创建风控退避表
+func newHostThrottle() *hostThrottle {
+	return &hostThrottle{
+		hosts:    make(map[string]*hostState),
+		statuses: statusSet(DefaultThrottleStatuses),
+	}
+}
+
+// setStatuses 设置视为风控的状态码
+func (t *hostThrottle) setStatuses(codes []int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.statuses = statusSet(codes)
+}
+
+// triggered 状态码是否视为触发风控,ctx 中通过 WithThrottleStatuses 指定时以其为准
+func (t *hostThrottle) triggered(ctx context.Context, statusCode int) bool {
+	if ctx != nil {
+		if statuses, ok := ctx.Value(throttleStatusesKey{}).(map[int]bool); ok {
+			return statuses[statusCode]
+		}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.statuses[statusCode]
+}
+
+// acquire 请求发出前调用
+// 冷却期内返回 ErrHostThrottled;降频期内按最小间隔排队等待
+func (t *hostThrottle) acquire(host string) error {
+	t.mu.Lock()
+	state, ok := t.hosts[host]
+	if !ok {
+		t.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	if now.Before(state.cooldownUntil) {
+		remaining := state.cooldownUntil.Sub(now).Round(time.Second)
+		t.mu.Unlock()
+		return fmt.Errorf("%w: %s 剩余 %s", ErrHostThrottled, host, remaining)
+	}
+
+	var wait time.Duration
+	if now.Before(state.slowUntil) {
+		// 预占下一个请求时段,并发请求依次顺延
+		slot := state.nextRequest
+		if slot.Before(now) {
+			slot = now
+		}
+		state.nextRequest = slot.Add(throttleSlowInterval)
+		wait = slot.Sub(now)
+	}
+	t.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return nil
+}
+
+// observe 请求完成后调用,根据状态码更新风控状态
+func (t *hostThrottle) observe(ctx context.Context, host string, statusCode int, header http.Header) {
+	if !t.triggered(ctx, statusCode) {
+		t.mu.Lock()
+		if state, ok := t.hosts[host]; ok && time.Now().After(state.slowUntil) {
+			state.strikes = 0
+		}
+		t.mu.Unlock()
+		return
+	}
+
+	t.mu.Lock()
+	state, ok := t.hosts[host]
+	if !ok {
+		state = &hostState{}
+		t.hosts[host] = state
+	}
+
+	now := time.Now()
+	state.events++
+	state.strikes++
+	cooldown := throttleBaseCooldown << (state.strikes - 1)
+	if cooldown > throttleMaxCooldown || cooldown <= 0 {
+		cooldown = throttleMaxCooldown
+	}
+	// 上游明确给出 Retry-After 时,以较长者为准
+	if retryAfter := parseRetryAfter(header.Get("Retry-After"), now); retryAfter > cooldown {
+		cooldown = retryAfter
+	}
+	state.cooldownUntil = now.Add(cooldown)
+	state.slowUntil = state.cooldownUntil.Add(throttleSlowWindow)
+	strikes, events := state.strikes, state.events
+	t.mu.Unlock()
+
+	logger.Warn("上游触发风控,进入冷却",
+		zap.String("host", host),
+		zap.Int("status", statusCode),
+		zap.Int("strikes", strikes),
+		zap.Int64("events", events),
+		zap.Duration("cooldown", cooldown),
+	)
+}
+
+// snapshot 返回各 host 风控状态的副本(按域名排序)
+func (t *hostThrottle) snapshot() []ThrottleStat {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]ThrottleStat, 0, len(t.hosts))
+	for host, state := range t.hosts {
+		result = append(result, ThrottleStat{
+			Host:          host,
+			Events:        state.events,
+			Strikes:       state.strikes,
+			CooldownUntil: state.cooldownUntil.Format(time.RFC3339),
+			SlowUntil:     state.slowUntil.Format(time.RFC3339),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Host < result[j].Host
+	})
+	return result
+}
+
+// parseRetryAfter 解析 Retry-After 头,支持秒数和 HTTP 日期两种格式
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// requestHost 从请求地址中取出 host
+func requestHost(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return rawURL
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestHostThrottleStatuses(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int // nil 表示默认设置
+		ctx       context.Context
+		status    int
+		throttled bool
+	}{
+		{"默认 429", nil, context.Background(), http.StatusTooManyRequests, true},
+		{"默认 403", nil, context.Background(), http.StatusForbidden, true},
+		{"默认 500", nil, context.Background(), http.StatusInternalServerError, false},
+		{"配置只含 429", []int{http.StatusTooManyRequests}, context.Background(), http.StatusForbidden, false},
+		{"请求覆盖", nil, WithThrottleStatuses(context.Background(), http.StatusTooManyRequests), http.StatusForbidden, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttle := newHostThrottle()
+			if tt.statuses != nil {
+				throttle.setStatuses(tt.statuses)
+			}
+			throttle.observe(tt.ctx, "example.com", tt.status, http.Header{})
+
+			err := throttle.acquire("example.com")
+			if got := errors.Is(err, ErrHostThrottled); got != tt.throttled {
+				t.Errorf("throttled = %v (err = %v), want %v", got, err, tt.throttled)
+			}
+		})
+	}
+}
+
+func TestHostThrottleCooldownOnTooManyRequests(t *testing.T) {
+	throttle := newHostThrottle()
+	throttle.observe(context.Background(), "example.com", http.StatusTooManyRequests, http.Header{})
+
+	err := throttle.acquire("example.com")
+	if !errors.Is(err, ErrHostThrottled) {
+		t.Fatalf("429 后应进入冷却, got %v", err)
+	}
+	if err := throttle.acquire("other.com"); err != nil {
+		t.Fatalf("其他 host 不受影响: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+// TransportOptions 底层连接池参数
+// 热榜接口集中请求少数上游域名,调大每个 host 的空闲连接数可以复用连接,减少 TLS 握手
+type TransportOptions struct {
+	MaxIdleConns        int           // 所有 host 的最大空闲连接数
+	MaxIdleConnsPerHost int           // 单个 host 的最大空闲连接数
+	MaxConnsPerHost     int           // 单个 host 的最大连接数(含活跃连接),0 表示不限制
+	IdleConnTimeout     time.Duration // 空闲连接保留时间
+	TLSHandshakeTimeout time.Duration // TLS 握手超时时间
+	ForceAttemptHTTP2   bool          // 是否优先尝试 HTTP/2
+}
+
+// DefaultTransportOptions 默认连接池参数
+// 标准库默认每个 host 只保留 2 个空闲连接,并发抓取时大量连接用完即关,这里适当放大
+var DefaultTransportOptions = TransportOptions{
+	MaxIdleConns:        200,
+	MaxIdleConnsPerHost: 20,
+	MaxConnsPerHost:     0,
+	IdleConnTimeout:     90 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+	ForceAttemptHTTP2:   true,
+}
+
+// newTransport 按参数创建 http.Transport
+// 以标准库默认 Transport 为基础(保留拨号超时、环境变量代理等设置),只覆盖连接池相关参数
+func newTransport(opts TransportOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = opts.MaxIdleConns
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	transport.IdleConnTimeout = opts.IdleConnTimeout
+	transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	transport.ForceAttemptHTTP2 = opts.ForceAttemptHTTP2
+	return transport
+}
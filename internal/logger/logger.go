@@ -9,7 +9,10 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var globalLogger *zap.Logger
+var (
+	globalLogger *zap.Logger
+	accessLogger *zap.Logger // 访问日志,未单独配置时与 globalLogger 写入同一位置
+)
 
 // Init 初始化日志系统
 // cfg: 配置对象,包含日志级别、输出路径等参数
@@ -18,45 +21,12 @@ func Init(cfg *config.Config) (*zap.Logger, error) {
 	// 1. 确定日志级别
 	// 就像调节音量,不同级别会输出不同详细程度的日志
 	// debug(最详细) > info(常规) > warn(警告) > error(错误)
-	var level zapcore.Level
-	switch cfg.Log.Level {
-	case "debug":
-		level = zapcore.DebugLevel
-	case "info":
-		level = zapcore.InfoLevel
-	case "warn":
-		level = zapcore.WarnLevel
-	case "error":
-		level = zapcore.ErrorLevel
-	default:
-		level = zapcore.InfoLevel
-	}
-
-	// 2. 配置编码器(决定日志的输出格式)
-	var encoderConfig zapcore.EncoderConfig
-	if cfg.Log.Format == "json" {
-		// JSON 格式:机器友好,便于日志分析工具处理
-		// 输出像: {"level":"info","ts":1234567890,"msg":"服务启动"}
-		encoderConfig = zap.NewProductionEncoderConfig()
-	} else {
-		// Console 格式:人类友好,便于直接阅读
-		// 输出像: 2024-01-01 12:00:00 INFO 服务启动
-		encoderConfig = zap.NewDevelopmentEncoderConfig()
-		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // 彩色输出
-	}
-
-	// 时间格式设置为易读的格式
-	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	level := parseLevel(cfg.Log.Level)
 
-	// 3. 创建编码器
-	var encoder zapcore.Encoder
-	if cfg.Log.Format == "json" {
-		encoder = zapcore.NewJSONEncoder(encoderConfig)
-	} else {
-		encoder = zapcore.NewConsoleEncoder(encoderConfig)
-	}
+	// 2. 创建编码器(决定日志的输出格式)
+	encoder := newEncoder(cfg.Log.Format)
 
-	// 4. 配置输出目标
+	// 3. 配置输出目标
 	// 同时输出到控制台和文件,方便开发和生产环境
 	writers := []zapcore.WriteSyncer{
 		zapcore.AddSync(os.Stdout), // 控制台输出
@@ -66,24 +36,18 @@ func Init(cfg *config.Config) (*zap.Logger, error) {
 	if cfg.Log.OutputPath != "" {
 		// 使用 lumberjack 实现日志文件自动轮转
 		// 就像一个"自动整理的笔记本",写满一页自动翻页
-		fileWriter := &lumberjack.Logger{
-			Filename:   cfg.Log.OutputPath, // 日志文件路径
-			MaxSize:    cfg.Log.MaxSize,    // 单个文件最大大小(MB)
-			MaxBackups: cfg.Log.MaxBackups, // 保留的旧文件数量
-			MaxAge:     cfg.Log.MaxAge,     // 保留天数
-			Compress:   cfg.Log.Compress,   // 是否压缩旧文件
-		}
+		fileWriter := newRotateWriter(cfg.Log.OutputPath, cfg.Log.MaxSize, cfg.Log.MaxBackups, cfg.Log.MaxAge, cfg.Log.Compress)
 		writers = append(writers, zapcore.AddSync(fileWriter))
 	}
 
-	// 5. 创建 Core(日志系统的核心)
+	// 4. 创建 Core(日志系统的核心)
 	core := zapcore.NewCore(
 		encoder,                                 // 编码器:决定格式
 		zapcore.NewMultiWriteSyncer(writers...), // 输出目标:控制台+文件
 		level,                                   // 日志级别:过滤器
 	)
 
-	// 6. 创建 Logger 实例
+	// 5. 创建 Logger 实例
 	logger := zap.New(
 		core,
 		zap.AddCaller(),                       // 添加调用者信息(文件名和行号)
@@ -92,9 +56,84 @@ func Init(cfg *config.Config) (*zap.Logger, error) {
 	)
 
 	globalLogger = logger
+	accessLogger = newAccessLogger(cfg, logger)
 	return logger, nil
 }
 
+// newAccessLogger 创建访问日志 logger
+// 配置了 log.access_log.output_path 时写入独立文件,级别与轮转参数单独生效;
+// 否则访问日志与应用日志写在一起
+func newAccessLogger(cfg *config.Config, appLogger *zap.Logger) *zap.Logger {
+	access := cfg.Log.AccessLog
+	if access.OutputPath == "" {
+		// 由中间件直接调用,调用位置没有意义
+		return appLogger.WithOptions(zap.WithCaller(false))
+	}
+
+	format := access.Format
+	if format == "" {
+		format = cfg.Log.Format
+	}
+
+	// 访问日志字段固定,不需要调用位置与堆栈
+	core := zapcore.NewCore(
+		newEncoder(format),
+		zapcore.AddSync(newRotateWriter(access.OutputPath, access.MaxSize, access.MaxBackups, access.MaxAge, access.Compress)),
+		parseLevel(access.Level),
+	)
+	return zap.New(core)
+}
+
+// parseLevel 解析日志级别,无法识别时使用 info
+func parseLevel(level string) zapcore.Level {
+	switch level {
+	case "debug":
+		return zapcore.DebugLevel
+	case "info":
+		return zapcore.InfoLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// newEncoder 按输出格式创建编码器
+func newEncoder(format string) zapcore.Encoder {
+	var encoderConfig zapcore.EncoderConfig
+	if format == "json" {
+		// JSON 格式:机器友好,便于日志分析工具处理
+		// 输出像: {"level":"info","ts":1234567890,"msg":"服务启动"}
+		encoderConfig = zap.NewProductionEncoderConfig()
+	} else {
+		// Console 格式:人类友好,便于直接阅读
+		// 输出像: 2024-01-01 12:00:00 INFO 服务启动
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // 彩色输出
+	}
+
+	// 时间格式设置为易读的格式
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	if format == "json" {
+		return zapcore.NewJSONEncoder(encoderConfig)
+	}
+	return zapcore.NewConsoleEncoder(encoderConfig)
+}
+
+// newRotateWriter 创建按大小自动轮转的日志文件
+func newRotateWriter(path string, maxSize, maxBackups, maxAge int, compress bool) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   path,       // 日志文件路径
+		MaxSize:    maxSize,    // 单个文件最大大小(MB)
+		MaxBackups: maxBackups, // 保留的旧文件数量
+		MaxAge:     maxAge,     // 保留天数
+		Compress:   compress,   // 是否压缩旧文件
+	}
+}
+
 // Get 获取全局 logger 实例
 // 在其他模块中可以通过这个函数获取 logger
 func Get() *zap.Logger {
@@ -105,9 +144,21 @@ func Get() *zap.Logger {
 	return globalLogger
 }
 
+// Access 获取访问日志 logger
+// 请求日志中间件使用,未初始化时返回全局 logger
+func Access() *zap.Logger {
+	if accessLogger == nil {
+		return Get()
+	}
+	return accessLogger
+}
+
 // Sync 刷新日志缓冲区
 // 在程序退出前调用,确保所有日志都写入磁盘
 func Sync() error {
+	if accessLogger != nil {
+		_ = accessLogger.Sync()
+	}
 	if globalLogger != nil {
 		return globalLogger.Sync()
 	}
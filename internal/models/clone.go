@@ -0,0 +1,24 @@
+package models
+
+// CloneHotData 复制热榜数据列表,Extra 按条目复制一层
+// 缓存在内存中的数据会被多个请求共用,而后处理(如热度归一化)会原地修改条目与 Extra,
+// 保存或取出共用数据时需要复制
+func CloneHotData(data []HotData) []HotData {
+	if data == nil {
+		return nil
+	}
+
+	result := make([]HotData, len(data))
+	copy(result, data)
+	for i := range result {
+		if result[i].Extra == nil {
+			continue
+		}
+		extra := make(map[string]interface{}, len(result[i].Extra))
+		for key, value := range result[i].Extra {
+			extra[key] = value
+		}
+		result[i].Extra = extra
+	}
+	return result
+}
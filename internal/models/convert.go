@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ToInt64 将上游 JSON 中可能是数字或字符串的字段转换为整数
+// 支持各类整数、浮点数(截断小数)、json.Number(UseNumber 解码)以及数字字符串(允许千分位逗号);
+// 无法转换时返回 0。带 "万"、"亿" 等单位的热度文案请使用 HotValue
+func ToInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float32:
+		return floatToInt64(float64(v))
+	case float64:
+		return floatToInt64(v)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return floatToInt64(f)
+	case string:
+		s := strings.ReplaceAll(strings.TrimSpace(v), ",", "")
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+		f, _ := strconv.ParseFloat(s, 64)
+		return floatToInt64(f)
+	}
+	return 0
+}
+
+// floatToInt64 浮点数截断为整数,NaN 与溢出时返回 0
+func floatToInt64(f float64) int64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f > math.MaxInt64 || f < math.MinInt64 {
+		return 0
+	}
+	return int64(f)
+}
+
+// ToString 将上游 JSON 中可能是数字或字符串的字段转换为字符串
+// 数字按原值输出(不使用科学计数法),nil 与 false 返回空串,true 返回 "true"
+func ToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		if v {
+			return "true"
+		}
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
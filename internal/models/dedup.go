@@ -0,0 +1,35 @@
+package models
+
+import "strings"
+
+// Dedup 去除重复的热榜条目,保留首次出现的条目
+// 有 URL 的条目按 URL 判重(忽略首尾空白和末尾的 "/"),没有 URL 的按标题判重
+// 返回新的切片,不修改原数据
+func Dedup(data []HotData) []HotData {
+	result := make([]HotData, 0, len(data))
+	seen := make(map[string]bool, len(data))
+
+	for _, item := range data {
+		key := dedupKey(item)
+		if key != "" {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// dedupKey 计算条目的判重键,URL 与标题都为空时返回空串(不参与去重)
+func dedupKey(item HotData) string {
+	if url := strings.TrimRight(strings.TrimSpace(item.URL), "/"); url != "" {
+		return "url:" + url
+	}
+	if title := strings.TrimSpace(item.Title); title != "" {
+		return "title:" + title
+	}
+	return ""
+}
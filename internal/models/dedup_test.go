@@ -0,0 +1,53 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedup(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []HotData
+		want []string // 保留条目的 ID
+	}{
+		{"空列表", nil, []string{}},
+		{"无重复", []HotData{
+			{ID: "1", Title: "a", URL: "https://example.com/1"},
+			{ID: "2", Title: "b", URL: "https://example.com/2"},
+		}, []string{"1", "2"}},
+		{"同 URL 保留首个", []HotData{
+			{ID: "1", Title: "标题一", URL: "https://example.com/1"},
+			{ID: "2", Title: "标题二", URL: "https://example.com/1"},
+		}, []string{"1"}},
+		{"URL 忽略末尾斜杠与空白", []HotData{
+			{ID: "1", URL: "https://example.com/a/"},
+			{ID: "2", URL: " https://example.com/a "},
+		}, []string{"1"}},
+		{"URL 不同但标题相同不去重", []HotData{
+			{ID: "1", Title: "同一标题", URL: "https://a.com/1"},
+			{ID: "2", Title: "同一标题", URL: "https://b.com/1"},
+		}, []string{"1", "2"}},
+		{"没有 URL 时按标题", []HotData{
+			{ID: "1", Title: "同一标题"},
+			{ID: "2", Title: " 同一标题 "},
+			{ID: "3", Title: "另一标题"},
+		}, []string{"1", "3"}},
+		{"URL 与标题都为空时保留", []HotData{
+			{ID: "1"},
+			{ID: "2"},
+		}, []string{"1", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, item := range Dedup(tt.in) {
+				got = append(got, item.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dedup = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
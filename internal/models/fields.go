@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FieldMapping 条目字段映射表,兼容依赖旧字段名的前端(如原 Node 项目的 pic、img)
+// 字段名为条目的 JSON 字段名,不区分大小写;映射在序列化时展开,不影响缓存中的数据
+type FieldMapping struct {
+	// Aliases 规范字段名 -> 额外输出的别名,如 "cover" -> ["pic", "img"],规范字段保留
+	Aliases map[string][]string
+	// Rename 规范字段名 -> 新名称,如 "mobileUrl" -> "mobile_url",规范字段不再输出
+	Rename map[string]string
+}
+
+// IsZero 是否没有任何映射
+func (m FieldMapping) IsZero() bool {
+	return len(m.Aliases) == 0 && len(m.Rename) == 0
+}
+
+// Expand 将条目序列化为 JSON 对象并按映射表改名、追加别名
+// 规范字段不存在(如 omitempty 省略的空值)时不输出对应别名;别名与条目已有字段重名时不覆盖
+func (m FieldMapping) Expand(item HotData) (map[string]json.RawMessage, error) {
+	body, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	// 先追加别名再改名,别名取的是改名前的规范字段
+	for name, aliases := range m.Aliases {
+		key, ok := fieldKey(fields, name)
+		if !ok {
+			continue
+		}
+		for _, alias := range aliases {
+			if _, exists := fields[alias]; !exists && alias != "" {
+				fields[alias] = fields[key]
+			}
+		}
+	}
+	for name, newName := range m.Rename {
+		key, ok := fieldKey(fields, name)
+		if !ok || newName == "" || newName == key {
+			continue
+		}
+		fields[newName] = fields[key]
+		delete(fields, key)
+	}
+
+	return fields, nil
+}
+
+// fieldKey 按不区分大小写的方式查找字段(配置加载时键名会被转为小写,如 mobileurl)
+func fieldKey(fields map[string]json.RawMessage, name string) (string, bool) {
+	if _, ok := fields[name]; ok {
+		return name, true
+	}
+	for key := range fields {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// WithFieldMapping 设置序列化时展开的条目字段映射
+func (r *Response) WithFieldMapping(mapping FieldMapping) *Response {
+	r.fields = mapping
+	return r
+}
+
+// MarshalJSON 序列化统一响应
+// 设置了字段映射时按映射表展开 data 中的每个条目,否则与默认序列化一致
+func (r Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	if r.fields.IsZero() {
+		return json.Marshal(plain(r))
+	}
+
+	items := make([]map[string]json.RawMessage, 0, len(r.Data))
+	for _, item := range r.Data {
+		fields, err := r.fields.Expand(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, fields)
+	}
+
+	// 外层的 Data 字段覆盖内嵌结构中的同名字段
+	return json.Marshal(struct {
+		plain
+		Data []map[string]json.RawMessage `json:"data"`
+	}{plain(r), items})
+}
@@ -0,0 +1,74 @@
+package models
+
+import "regexp"
+
+// ItemFilter 条目过滤规则,用于剔除广告、置顶等非榜单内容
+// 满足任意一条规则的条目会被过滤
+type ItemFilter struct {
+	IDs           []string         // ID 黑名单
+	TitlePatterns []*regexp.Regexp // 标题正则,匹配即过滤
+	Flags         []string         // Extra 中的标记字段,值为真(true、非零数字、非空字符串)时过滤
+}
+
+// IsZero 是否没有任何规则
+func (f ItemFilter) IsZero() bool {
+	return len(f.IDs) == 0 && len(f.TitlePatterns) == 0 && len(f.Flags) == 0
+}
+
+// Merge 合并两组规则,返回新的过滤器
+func (f ItemFilter) Merge(other ItemFilter) ItemFilter {
+	return ItemFilter{
+		IDs:           append(append([]string{}, f.IDs...), other.IDs...),
+		TitlePatterns: append(append([]*regexp.Regexp{}, f.TitlePatterns...), other.TitlePatterns...),
+		Flags:         append(append([]string{}, f.Flags...), other.Flags...),
+	}
+}
+
+// Match 判断条目是否命中过滤规则
+func (f ItemFilter) Match(item HotData) bool {
+	for _, id := range f.IDs {
+		if item.ID == id {
+			return true
+		}
+	}
+	for _, pattern := range f.TitlePatterns {
+		if pattern.MatchString(item.Title) {
+			return true
+		}
+	}
+	for _, flag := range f.Flags {
+		if truthy(item.Extra[flag]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply 过滤命中规则的条目,返回新的切片,不修改原数据
+func (f ItemFilter) Apply(data []HotData) []HotData {
+	if f.IsZero() {
+		return data
+	}
+
+	result := make([]HotData, 0, len(data))
+	for _, item := range data {
+		if !f.Match(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// truthy 判断标记字段的值是否为真
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "0" && v != "false"
+	}
+	number, ok := HotValue(value)
+	return ok && number != 0
+}
@@ -0,0 +1,134 @@
+package models
+
+import "strings"
+
+// 支持的响应语言
+const (
+	LangZH = "zh" // 中文(默认)
+	LangEN = "en" // 英文
+)
+
+// LocalizedText 平台展示文案
+type LocalizedText struct {
+	Title       string // 平台显示名称
+	Description string // 平台描述
+}
+
+// i18nPlatformTexts 多语言平台文案表: 语言 -> 平台路由名 -> 文案
+// 中文文案由各处理器直接给出,这里只维护其他语言
+var i18nPlatformTexts = map[string]map[string]LocalizedText{
+	LangEN: {
+		"hackernews":   {Title: "Hacker News", Description: "The latest in programming and technology"},
+		"github":       {Title: "GitHub", Description: "Trending open source repositories on GitHub"},
+		"gitee":        {Title: "Gitee", Description: "Popular open source repositories on Gitee"},
+		"oschina":      {Title: "OSChina", Description: "Chinese open source technology community"},
+		"segmentfault": {Title: "SegmentFault", Description: "Developer Q&A and article community"},
+		"producthunt":  {Title: "Product Hunt", Description: "The best new products and apps, every day"},
+		"techcrunch":   {Title: "TechCrunch", Description: "Startup and technology news from around the world"},
+		"theverge":     {Title: "The Verge", Description: "The latest technology and culture coverage from The Verge"},
+		"engadget":     {Title: "Engadget", Description: "Daily technology and gadget news from Engadget"},
+		"theguardian":  {Title: "The Guardian", Description: "World news picks from The Guardian"},
+		"economist":    {Title: "The Economist", Description: "The latest in-depth reporting from The Economist"},
+		"nytimes":      {Title: "The New York Times", Description: "News from The New York Times"},
+		"linuxdo":      {Title: "Linux.do", Description: "Popular topics on Linux.do"},
+		"v2ex":         {Title: "V2EX", Description: "A community of creative workers"},
+		"bilibili":     {Title: "Bilibili", Description: "Trending videos on Bilibili"},
+		"weibo":        {Title: "Weibo", Description: "Real-time trending topics on Weibo"},
+		"zhihu":        {Title: "Zhihu", Description: "Trending questions on Zhihu"},
+		"baidu":        {Title: "Baidu", Description: "Trending searches on Baidu"},
+		"douyin":       {Title: "Douyin", Description: "Trending topics on Douyin"},
+		"xiaohongshu":  {Title: "Xiaohongshu", Description: "Trending searches and notes on Xiaohongshu (RED)"},
+		"toutiao":      {Title: "Toutiao", Description: "Trending news on Toutiao"},
+		"juejin":       {Title: "Juejin", Description: "Popular articles on Juejin"},
+		"36kr":         {Title: "36Kr", Description: "Startup and business news from 36Kr"},
+		"ithome":       {Title: "IT Home", Description: "Trending technology news on IT Home"},
+		"cnbeta":       {Title: "cnBeta", Description: "Chinese technology industry news"},
+	},
+}
+
+// i18nTypeTexts 榜单类型文案表: 语言 -> 中文类型 -> 译文
+var i18nTypeTexts = map[string]map[string]string{
+	LangEN: {
+		"热榜":   "Hot",
+		"热搜":   "Trending",
+		"热搜榜":  "Trending",
+		"文娱榜":  "Entertainment",
+		"要闻榜":  "News",
+		"热门":   "Popular",
+		"热门仓库": "Popular Repositories",
+		"热门文章": "Popular Articles",
+		"热门问答": "Popular Questions",
+		"热门笔记": "Popular Notes",
+		"最热":   "Hottest",
+		"最新":   "Latest",
+		"最新资讯": "Latest News",
+		"热门资讯": "Popular News",
+		"全站":   "All",
+		"科技":   "Technology",
+		"游戏":   "Games",
+		"音乐":   "Music",
+		"动画":   "Animation",
+		"娱乐":   "Entertainment",
+		"电影":   "Movies",
+		"电视剧":  "TV Series",
+		"小说":   "Novels",
+		"汽车":   "Cars",
+		"中文网":  "Chinese Edition",
+		"全球版":  "Global Edition",
+	},
+}
+
+// ParseLang 解析请求语言
+// 优先使用显式指定的 lang 参数,其次使用 Accept-Language 请求头的首选语言
+// 无法识别时返回默认中文
+func ParseLang(queryLang string, acceptLanguage string) string {
+	if lang := normalizeLang(queryLang); lang != "" {
+		return lang
+	}
+
+	// Accept-Language 形如 "en-US,en;q=0.9,zh-CN;q=0.8",取第一个语言标签
+	first := strings.SplitN(acceptLanguage, ",", 2)[0]
+	first = strings.SplitN(first, ";", 2)[0]
+	if lang := normalizeLang(first); lang != "" {
+		return lang
+	}
+
+	return LangZH
+}
+
+// normalizeLang 将 "en-US"、"zh_CN" 等语言标签归一为 "en"/"zh"
+func normalizeLang(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	switch {
+	case strings.HasPrefix(tag, LangEN):
+		return LangEN
+	case strings.HasPrefix(tag, LangZH):
+		return LangZH
+	default:
+		return ""
+	}
+}
+
+// Localize 按语言替换响应中的展示文案
+// platform 为路由名(如 "hackernews"),中文或文案表中缺失的项保持原样
+func Localize(resp *Response, platform string, lang string) {
+	if resp == nil || lang == "" || lang == LangZH {
+		return
+	}
+
+	if texts, ok := i18nPlatformTexts[lang][platform]; ok {
+		resp.Title = texts.Title
+		resp.Description = texts.Description
+	}
+
+	// 类型可能是 "热榜 · 科技" 这种组合形式,逐段翻译
+	if typeTexts, ok := i18nTypeTexts[lang]; ok {
+		parts := strings.Split(resp.Type, " · ")
+		for i, part := range parts {
+			if translated, ok := typeTexts[part]; ok {
+				parts[i] = translated
+			}
+		}
+		resp.Type = strings.Join(parts, " · ")
+	}
+}
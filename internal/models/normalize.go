@@ -0,0 +1,37 @@
+package models
+
+import "math"
+
+// HotScoreField 归一化热度分数在 Extra 中的字段名
+const HotScoreField = "hot_score"
+
+// NormalizeHot 将平台内的热度按最大值归一化为 0~100 的相对分数,写入 Extra["hot_score"]
+// 不同平台的 Hot 单位不同(播放量、评论数、star 等),归一化后可横向比较;原始 Hot 保持不变
+// 无法解析热度的条目不设置分数,所有热度都不大于 0 时不做处理
+func NormalizeHot(data []HotData) {
+	values := make([]float64, len(data))
+	valid := make([]bool, len(data))
+	maxValue := 0.0
+	for i, item := range data {
+		value, ok := HotValue(item.Hot)
+		if !ok {
+			continue
+		}
+		values[i], valid[i] = math.Max(value, 0), true
+		maxValue = math.Max(maxValue, values[i])
+	}
+	if maxValue <= 0 {
+		return
+	}
+
+	for i := range data {
+		if !valid[i] {
+			continue
+		}
+		if data[i].Extra == nil {
+			data[i].Extra = make(map[string]interface{}, 1)
+		}
+		// 保留一位小数
+		data[i].Extra[HotScoreField] = math.Round(values[i]/maxValue*1000) / 10
+	}
+}
@@ -15,29 +15,51 @@ type HotData struct {
 	Timestamp interface{} `json:"timestamp,omitempty"` // 发布时间 (支持 number 或 string)
 	URL       string      `json:"url"`                 // 详情页链接 (必需)
 	MobileURL string      `json:"mobileUrl,omitempty"` // 移动端链接 (可选)
+	TimeText  string      `json:"time_text,omitempty"` // 相对时间文案,如 "3小时前" (仅 ?humanize=true 时返回)
+
+	// Extra 平台特有的扩展字段 (可选)
+	// 用于承载统一结构之外的信息,如预警级别、统计数据等
+	Extra map[string]interface{} `json:"extra,omitempty"`
 }
 
 // Response 统一响应结构
 // 所有 API 都返回这个格式,保证与原项目完全兼容
 type Response struct {
-	Code        int                    `json:"code"`                  // 状态码: 200 成功, 其他失败
-	Message     string                 `json:"message"`               // 提示信息
-	Name        string                 `json:"name"`                  // 平台调用名称, 如 "bilibili"、"weibo"
-	Title       string                 `json:"title"`                 // 平台名称,如 "哔哩哔哩"、"微博" (新增)
-	Type        string                 `json:"type"`                  // 榜单类型,如 "热榜" (替代 subtitle)
-	Description string                 `json:"description,omitempty"` // 平台描述 (新增)
-	Params      map[string]interface{} `json:"params,omitempty"`      // 参数说明 (新增)
-	Link        string                 `json:"link,omitempty"`        // 官方链接 (新增)
-	UpdateTime  string                 `json:"updateTime"`            // 更新时间 (改为驼峰式)
-	Total       int                    `json:"total"`                 // 数据总数
-	FromCache   bool                   `json:"fromCache"`             // 是否来自缓存
-	Data        []HotData              `json:"data"`                  // 热榜数据列表
+	Code           int                    `json:"code"`                     // 状态码: 200 成功, 其他失败
+	Message        string                 `json:"message"`                  // 提示信息
+	Name           string                 `json:"name"`                     // 平台调用名称, 如 "bilibili"、"weibo"
+	Title          string                 `json:"title"`                    // 平台名称,如 "哔哩哔哩"、"微博" (新增)
+	Type           string                 `json:"type"`                     // 榜单类型,如 "热榜" (替代 subtitle)
+	Description    string                 `json:"description,omitempty"`    // 平台描述 (新增)
+	Params         map[string]interface{} `json:"params,omitempty"`         // 参数说明 (新增)
+	Link           string                 `json:"link,omitempty"`           // 官方链接 (新增)
+	UpdateTime     string                 `json:"updateTime"`               // 更新时间 (改为驼峰式)
+	Total          int                    `json:"total"`                    // 数据总数
+	FromCache      bool                   `json:"fromCache"`                // 是否来自缓存
+	CacheRemaining *int64                 `json:"cacheRemaining,omitempty"` // 缓存剩余秒数 (仅 ?ttl=true 时返回)
+	Data           []HotData              `json:"data"`                     // 热榜数据列表
+
+	fields FieldMapping // 序列化时展开的条目字段映射,见 WithFieldMapping
 }
 
+// 错误分类,便于前端按类型处理
+const (
+	ErrorTypeUpstream     = "upstream_error" // 上游请求失败(网络错误、非 200 状态码等)
+	ErrorTypeTimeout      = "timeout"        // 抓取超时
+	ErrorTypeParse        = "parse_error"    // 上游响应解析失败
+	ErrorTypeEmptyData    = "empty_data"     // 上游返回的数据为空
+	ErrorTypeRateLimited  = "rate_limited"   // 触发上游风控(429 或冷却期内)
+	ErrorTypeCanceled     = "canceled"       // 请求被取消(如服务关闭)
+	ErrorTypeInvalidParam = "invalid_param"  // 请求参数不合法
+)
+
 // ErrorResponse 错误响应
 type ErrorResponse struct {
-	Code    int    `json:"code"`    // 错误码
-	Message string `json:"message"` // 错误信息
+	Code     int    `json:"code"`               // 错误码
+	Message  string `json:"message"`            // 错误信息
+	Type     string `json:"type,omitempty"`     // 错误分类,见 ErrorType* 常量
+	Platform string `json:"platform,omitempty"` // 出错的平台路由名
+	TraceID  string `json:"trace_id,omitempty"` // 追踪 ID,与日志中的 trace_id 对应
 }
 
 // SuccessResponse 创建成功响应 (新签名,向后兼容原项目)
@@ -89,6 +111,24 @@ func ErrorResponseObj(code int, message string) *ErrorResponse {
 	}
 }
 
+// WithType 设置错误分类
+func (e *ErrorResponse) WithType(errType string) *ErrorResponse {
+	e.Type = errType
+	return e
+}
+
+// WithPlatform 设置出错的平台
+func (e *ErrorResponse) WithPlatform(platform string) *ErrorResponse {
+	e.Platform = platform
+	return e
+}
+
+// WithTraceID 设置追踪 ID
+func (e *ErrorResponse) WithTraceID(traceID string) *ErrorResponse {
+	e.TraceID = traceID
+	return e
+}
+
 // getCurrentTime 获取当前时间字符串 (RFC3339 格式,与原项目兼容)
 func getCurrentTime() string {
 	return time.Now().Format(time.RFC3339)
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// htmlUnsafeBlockPattern 脚本、样式等标签连同内容一起去掉
+	htmlUnsafeBlockPattern = regexp.MustCompile(`(?is)<(script|style|iframe|noscript|template)\b[^>]*>.*?</(script|style|iframe|noscript|template)\s*>`)
+	// htmlCommentPattern HTML 注释
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// htmlBreakPattern 换行与块级元素结束标签,转为换行保留段落结构
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6]|tr|blockquote|pre)\s*>`)
+	// htmlTagPattern 其余标签
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+	// blankLinesPattern 连续两个以上的空行
+	blankLinesPattern = regexp.MustCompile(`\n[ \t\r\f\v]*(\n[ \t\r\f\v]*){2,}`)
+	// htmlAngleEscaper 转义残留的尖括号,如未闭合的 "<img src=x onerror=..."
+	htmlAngleEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+)
+
+// StripHTML 将含 HTML 的文本清洗为纯文本
+// 去掉脚本、样式等标签及其内容与其余所有标签,<br>、</p> 等转为换行,解码 HTML 实体
+// 实体解码后再去一次标签,避免 "&lt;script&gt;" 解码后重新变成标签
+// 正则只能去掉闭合的标签,最后把残留的 < 与 > 转义,保证输出中不会出现可被解析的标签
+func StripHTML(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return s
+	}
+
+	s = htmlUnsafeBlockPattern.ReplaceAllString(s, "")
+	s = htmlCommentPattern.ReplaceAllString(s, "")
+	s = htmlBreakPattern.ReplaceAllString(s, "\n")
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = htmlAngleEscaper.Replace(s)
+	s = strings.ReplaceAll(s, "\u00a0", " ") // &nbsp;
+	s = blankLinesPattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+// SanitizeHotData 清洗热榜条目标题与描述中的 HTML,原地修改
+// 标题只保留单行,描述保留换行
+func SanitizeHotData(data []HotData) {
+	for i := range data {
+		data[i].Title = strings.Join(strings.Fields(StripHTML(data[i].Title)), " ")
+		data[i].Desc = StripHTML(data[i].Desc)
+	}
+}
+
+// TruncateRunes 按字符(rune)截断文本,超出 n 个字符时截断并追加省略号
+// 按 rune 计数,不会截断多字节字符;n <= 0 时不截断
+func TruncateRunes(s string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return strings.TrimRightFunc(string([]rune(s)[:n]), unicode.IsSpace) + "…"
+}
+
+// TruncateDesc 将热榜条目的描述截断到 n 个字符,原地修改
+func TruncateDesc(data []HotData, n int) {
+	for i := range data {
+		data[i].Desc = TruncateRunes(data[i].Desc, n)
+	}
+}
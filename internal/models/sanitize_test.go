@@ -0,0 +1,46 @@
+package models
+
+import "testing"
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"纯文本", "hello world", "hello world"},
+		{"普通标签", "<b>加粗</b>标题", "加粗标题"},
+		{"脚本连同内容去掉", "前<script>alert(1)</script>后", "前后"},
+		{"注释", "a<!-- x -->b", "ab"},
+		{"换行与段落", "第一行<br>第二行<p>段落</p>", "第一行\n第二行段落"},
+		{"实体解码", "Tom &amp; Jerry&nbsp;!", "Tom & Jerry !"},
+		{"编码的闭合标签", "&lt;script&gt;alert(1)&lt;/script&gt;", "alert(1)"},
+		{"未闭合的标签", "标题<img src=x onerror=alert(1)", "标题&lt;img src=x onerror=alert(1)"},
+		{"编码的未闭合标签", "&lt;img src=x onerror=alert(1)", "&lt;img src=x onerror=alert(1)"},
+		{"残留的尖括号", "1 < 2", "1 &lt; 2"},
+		{"多余空行", "a<br><br><br><br>b", "a\n\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripHTML(tt.in); got != tt.want {
+				t.Errorf("StripHTML(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeHotData(t *testing.T) {
+	data := []HotData{{
+		Title: "<em>标题</em>\n  第二行",
+		Desc:  "描述<br>换行<svg onload=alert(1)",
+	}}
+	SanitizeHotData(data)
+
+	if data[0].Title != "标题 第二行" {
+		t.Errorf("Title = %q", data[0].Title)
+	}
+	if data[0].Desc != "描述\n换行&lt;svg onload=alert(1)" {
+		t.Errorf("Desc = %q", data[0].Desc)
+	}
+}
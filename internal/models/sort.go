@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+)
+
+// 排序字段
+const (
+	SortDefault = "default" // 保持上游原始顺序
+	SortHot     = "hot"     // 按热度
+	SortTime    = "time"    // 按发布时间
+)
+
+// hotNumberPattern 从热度文案中提取数字与单位,如 "1.2万热度"、"933.2w"、"3,456"
+var hotNumberPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(万|亿|[wWkK])?`)
+
+// SortHotData 按指定字段对热榜条目排序
+// by 为 hot 或 time,其他值保持原始顺序;desc 为 true 时降序
+// 无法解析出热度/时间的条目无论升降序都排在最后,值相同的条目保持原始相对顺序
+// 返回新的切片,不修改原数据
+func SortHotData(data []HotData, by string, desc bool) []HotData {
+	var key func(item HotData) (float64, bool)
+	switch by {
+	case SortHot:
+		key = func(item HotData) (float64, bool) {
+			return HotValue(item.Hot)
+		}
+	case SortTime:
+		key = func(item HotData) (float64, bool) {
+			ms := timeutil.ParseTime(item.Timestamp)
+			return float64(ms), ms > 0
+		}
+	default:
+		return data
+	}
+
+	type sortItem struct {
+		value float64
+		ok    bool
+		item  HotData
+	}
+	items := make([]sortItem, len(data))
+	for i, item := range data {
+		value, ok := key(item)
+		items[i] = sortItem{value: value, ok: ok, item: item}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.ok != b.ok {
+			return a.ok
+		}
+		if desc {
+			return a.value > b.value
+		}
+		return a.value < b.value
+	})
+
+	result := make([]HotData, len(items))
+	for i, item := range items {
+		result[i] = item.item
+	}
+	return result
+}
+
+// HotValue 将热度值转换为可比较的数字
+// 支持各种数字类型、json.Number 以及 "1.2万"、"933.2w"、"3,456 热度" 这类文案,无法解析时返回 false
+func HotValue(hot interface{}) (float64, bool) {
+	switch v := hot.(type) {
+	case nil:
+		return 0, false
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		return parseHotText(v)
+	}
+	return 0, false
+}
+
+// parseHotText 解析热度文案
+func parseHotText(text string) (float64, bool) {
+	matches := hotNumberPattern.FindStringSubmatch(strings.ReplaceAll(text, ",", ""))
+	if len(matches) < 2 {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	switch strings.ToLower(matches[2]) {
+	case "k":
+		value *= 1e3
+	case "w", "万":
+		value *= 1e4
+	case "亿":
+		value *= 1e8
+	}
+	return value, true
+}
@@ -33,6 +33,9 @@ func (h *Kr36Handler) GetPath() string {
 func (h *Kr36Handler) Handle(c *fiber.Ctx) error {
 	rankType := c.Query("type", "hot")
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", rankType, isSlug); err != nil {
+		return paramError(c, err)
+	}
 	typeMap := map[string]string{
 		"hot":     "人气榜",
 		"video":   "视频榜",
@@ -43,14 +46,17 @@ func (h *Kr36Handler) Handle(c *fiber.Ctx) error {
 	if typeName == "" {
 		typeName = "人气榜"
 	}
-	data, err := h.fetchKr36Hot(c.Context(), rankType)
+	cacheKey := fmt.Sprintf("36kr_%s", rankType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchKr36Hot(ctx, rankType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 	resp := models.SuccessResponse(
 		"36kr", "36氪", typeName, "发现36氪热门资讯",
 		"https://36kr.com/", map[string]interface{}{"type": typeMap},
-		data, !noCache,
+		data, fromCache,
 	)
 	return c.JSON(resp)
 }
@@ -72,7 +78,7 @@ func (h *Kr36Handler) fetchKr36Hot(ctx context.Context, rankType string) ([]mode
 	bodyBytes, _ := json.Marshal(requestBody)
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Post(apiURL, bodyBytes, map[string]string{
+	body, err := httpClient.PostContext(ctx, apiURL, bodyBytes, map[string]string{
 		"Content-Type": "application/json; charset=utf-8",
 	})
 	if err != nil {
@@ -99,11 +105,12 @@ func (h *Kr36Handler) fetchKr36Hot(ctx context.Context, rankType string) ([]mode
 		list = apiResp.Data.HotRankList
 	}
 
-	return h.transformData(list), nil
+	return h.transformData(list, rankType), nil
 }
 
 // transformData 转换数据格式
-func (h *Kr36Handler) transformData(items []Kr36Item) []models.HotData {
+// 热度按榜单取对应的统计字段: 人气榜取阅读数、视频榜取播放数、热议榜取评论数、收藏榜取收藏数
+func (h *Kr36Handler) transformData(items []Kr36Item, rankType string) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
@@ -114,10 +121,17 @@ func (h *Kr36Handler) transformData(items []Kr36Item) []models.HotData {
 			Title:     material.WidgetTitle,
 			Cover:     material.WidgetImage,
 			Author:    material.AuthorName,
-			Hot:       material.StatCollect,
+			Hot:       material.hot(rankType),
 			URL:       fmt.Sprintf("https://www.36kr.com/p/%d", item.ItemID),
 			MobileURL: fmt.Sprintf("https://m.36kr.com/p/%d", item.ItemID),
 			Timestamp: item.PublishTime * 1000, // 时间戳转换为毫秒级
+			Extra: map[string]interface{}{
+				"views":     material.StatRead,
+				"plays":     material.StatPlay,
+				"likes":     material.StatPraise,
+				"comments":  material.StatComment,
+				"favorites": material.StatCollect,
+			},
 		}
 
 		result = append(result, hotData)
@@ -151,5 +165,32 @@ type Kr36TemplateMaterial struct {
 	WidgetTitle string `json:"widgetTitle"`
 	WidgetImage string `json:"widgetImage"`
 	AuthorName  string `json:"authorName"`
-	StatCollect int64  `json:"statCollect"`
+	StatRead    int64  `json:"statRead"`    // 阅读数
+	StatPlay    int64  `json:"statPlay"`    // 播放数(视频)
+	StatPraise  int64  `json:"statPraise"`  // 点赞数
+	StatComment int64  `json:"statComment"` // 评论数
+	StatCollect int64  `json:"statCollect"` // 收藏数
+}
+
+// hot 按榜单类型选取热度字段,对应字段为 0 时依次回退到阅读数、点赞数
+func (m Kr36TemplateMaterial) hot(rankType string) int64 {
+	var hot int64
+	switch rankType {
+	case "video":
+		hot = m.StatPlay
+	case "comment":
+		hot = m.StatComment
+	case "collect":
+		hot = m.StatCollect
+	default:
+		hot = m.StatRead
+	}
+
+	if hot == 0 {
+		hot = m.StatRead
+	}
+	if hot == 0 {
+		hot = m.StatPraise
+	}
+	return hot
 }
@@ -0,0 +1,76 @@
+package routes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// kr36Fixture 榜单接口响应(节选),各榜单的统计字段不同
+const kr36Fixture = `{"code":0,"data":{
+  "hotRankList":[{"itemId":2761234567890001,"itemType":10,"templateMaterial":{"itemId":2761234567890001,"widgetTitle":"AI 芯片创业公司完成新一轮融资","widgetImage":"https://img.36krcdn.com/hsossms/20240501/v2_a.jpg","authorName":"36氪","statRead":58231,"statPraise":120,"statComment":35,"statCollect":210},"publishTime":1714550400}],
+  "videoList":[{"itemId":2761234567890002,"templateMaterial":{"widgetTitle":"三分钟看懂新能源车价格战","authorName":"36氪视频","statPlay":9980,"statPraise":88},"publishTime":1714550400}],
+  "remarkList":[{"itemId":2761234567890003,"templateMaterial":{"widgetTitle":"年轻人为什么不爱买房了","statRead":30000,"statComment":642},"publishTime":1714550400}],
+  "collectList":[{"itemId":2761234567890004,"templateMaterial":{"widgetTitle":"2024 年值得关注的十个赛道","statRead":12000,"statCollect":1890},"publishTime":1714550400}]
+}}`
+
+func TestKr36TransformData(t *testing.T) {
+	var resp Kr36APIResponse
+	if err := json.Unmarshal([]byte(kr36Fixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rankType string
+		items    []Kr36Item
+		wantHot  int64
+	}{
+		{"hot", resp.Data.HotRankList, 58231},
+		{"video", resp.Data.VideoList, 9980},
+		{"comment", resp.Data.RemarkList, 642},
+		{"collect", resp.Data.CollectList, 1890},
+	}
+
+	h := &Kr36Handler{}
+	for _, tt := range tests {
+		t.Run(tt.rankType, func(t *testing.T) {
+			data := h.transformData(tt.items, tt.rankType)
+			if len(data) != 1 {
+				t.Fatalf("len(data) = %d, want 1", len(data))
+			}
+			if data[0].Hot != tt.wantHot {
+				t.Errorf("Hot = %v, want %d", data[0].Hot, tt.wantHot)
+			}
+			if data[0].Timestamp != int64(1714550400000) {
+				t.Errorf("Timestamp = %v, want milliseconds", data[0].Timestamp)
+			}
+		})
+	}
+
+	first := h.transformData(resp.Data.HotRankList, "hot")[0]
+	if first.ID != "2761234567890001" || first.URL != "https://www.36kr.com/p/2761234567890001" || first.Author != "36氪" {
+		t.Errorf("first = %+v", first)
+	}
+	if first.Extra["views"] != int64(58231) || first.Extra["likes"] != int64(120) ||
+		first.Extra["comments"] != int64(35) || first.Extra["favorites"] != int64(210) {
+		t.Errorf("Extra = %v", first.Extra)
+	}
+}
+
+func TestKr36HotFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		material Kr36TemplateMaterial
+		rankType string
+		want     int64
+	}{
+		{"视频缺少播放数时取阅读数", Kr36TemplateMaterial{StatRead: 100}, "video", 100},
+		{"缺少阅读数时取点赞数", Kr36TemplateMaterial{StatPraise: 7}, "comment", 7},
+		{"统计全为 0", Kr36TemplateMaterial{}, "collect", 0},
+		{"未知榜单取阅读数", Kr36TemplateMaterial{StatRead: 5, StatCollect: 9}, "unknown", 5},
+	}
+	for _, tt := range tests {
+		if got := tt.material.hot(tt.rankType); got != tt.want {
+			t.Errorf("%s: hot = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	httpclient "github.com/dailyhot/api/internal/http"
@@ -20,6 +21,10 @@ import (
 // PojieHandler 吾爱破解处理器
 type PojieHandler struct {
 	fetcher *service.Fetcher
+
+	// actualTypes 请求类型 -> 最近一次抓取实际使用的类型
+	// 缓存命中时用它还原 actualType(缓存只保存数据列表)
+	actualTypes sync.Map
 }
 
 // NewPojieHandler 创建吾爱破解处理器
@@ -38,20 +43,34 @@ func (h *PojieHandler) GetPath() string {
 func (h *PojieHandler) Handle(c *fiber.Ctx) error {
 	pojieType := c.Query("type", "digest")
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", pojieType, isSlug); err != nil {
+		return paramError(c, err)
+	}
 	typeMap := map[string]string{
 		"digest":    "最新精华",
 		"hot":       "最新热门",
 		"new":       "最新回复",
 		"newthread": "最新发表",
 	}
-	data, actualType, err := h.fetchPojie(c.Context(), pojieType)
+	cacheKey := fmt.Sprintf("52pojie_%s", pojieType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		data, actualType, err := h.fetchPojie(ctx, pojieType)
+		if err == nil {
+			h.actualTypes.Store(pojieType, actualType)
+		}
+		return data, err
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
+	}
+	actualType := pojieType
+	if v, ok := h.actualTypes.Load(pojieType); ok {
+		actualType = v.(string)
 	}
 	resp := models.SuccessResponse(
 		"52pojie", "吾爱破解", h.getTypeName(actualType), "发现吾爱破解热门讨论",
 		"https://www.52pojie.cn/", map[string]interface{}{"type": typeMap, "actualType": actualType},
-		data, !noCache,
+		data, fromCache,
 	)
 	return c.JSON(resp)
 }
@@ -80,7 +99,7 @@ func (h *PojieHandler) fetchPojie(ctx context.Context, pojieType string) ([]mode
 		"User-Agent": "Mozilla/5.0 (Linux; Android 6.0; Nexus 5 Build/MRA58N) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/125.0.0.0 Mobile Safari/537.36",
 	}
 
-	data, err := h.fetchPojieWithType(httpClient, apiURL, headers)
+	data, err := h.fetchPojieWithType(ctx, httpClient, apiURL, headers)
 	if err != nil {
 		return nil, pojieType, err
 	}
@@ -89,7 +108,7 @@ func (h *PojieHandler) fetchPojie(ctx context.Context, pojieType string) ([]mode
 	if len(data) == 0 && pojieType == "digest" {
 		fallbackType := "hot"
 		fallbackURL := fmt.Sprintf("https://www.52pojie.cn/forum.php?mod=guide&view=%s&rss=1", fallbackType)
-		if fallbackData, ferr := h.fetchPojieWithType(httpClient, fallbackURL, headers); ferr == nil && len(fallbackData) > 0 {
+		if fallbackData, ferr := h.fetchPojieWithType(ctx, httpClient, fallbackURL, headers); ferr == nil && len(fallbackData) > 0 {
 			return fallbackData, fallbackType, nil
 		}
 	}
@@ -97,8 +116,8 @@ func (h *PojieHandler) fetchPojie(ctx context.Context, pojieType string) ([]mode
 	return data, pojieType, nil
 }
 
-func (h *PojieHandler) fetchPojieWithType(httpClient *httpclient.Client, apiURL string, headers map[string]string) ([]models.HotData, error) {
-	body, err := httpClient.Get(apiURL, headers)
+func (h *PojieHandler) fetchPojieWithType(ctx context.Context, httpClient *httpclient.Client, apiURL string, headers map[string]string) ([]models.HotData, error) {
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求吾爱破解 RSS 失败: %w", err)
 	}
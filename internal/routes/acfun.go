@@ -11,6 +11,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// acfunKindMap 内容类型映射
+var acfunKindMap = map[string]string{
+	"video":   "视频",
+	"bangumi": "番剧",
+	"article": "文章",
+}
+
+// acfunRangeMap 时间范围映射
+var acfunRangeMap = map[string]string{
+	"DAY":   "日榜",
+	"WEEK":  "周榜",
+	"MONTH": "月榜",
+}
+
+// acfunArticleChannelID 文章区频道 ID,文章排行与视频排行共用频道排行接口
+const acfunArticleChannelID = "63"
+
 // AcfunHandler AcFun 处理器
 type AcfunHandler struct {
 	fetcher *service.Fetcher
@@ -31,36 +48,63 @@ func (h *AcfunHandler) GetPath() string {
 // Handle 处理请求
 func (h *AcfunHandler) Handle(c *fiber.Ctx) error {
 	// 获取查询参数
+	kind := c.Query("kind", "video")     // 默认视频
 	channelType := c.Query("type", "-1") // 默认综合
 	rankRange := c.Query("range", "DAY") // 默认今日
 	noCache := c.Query("cache") == "false"
+	if _, ok := acfunKindMap[kind]; !ok {
+		kind = "video"
+	}
+	if err := validateParam("type", channelType, isNumeric); err != nil {
+		return paramError(c, err)
+	}
+	if err := validateParam("range", rankRange, oneOf(acfunRangeMap)); err != nil {
+		return paramError(c, err)
+	}
 
-	// 获取数据
-	data, err := h.fetchAcfun(c.Context(), channelType, rankRange)
+	// 获取数据(视频保持原缓存键;番剧、文章不区分频道)
+	cacheKey := fmt.Sprintf("acfun_%s_%s", channelType, rankRange)
+	if kind != "video" {
+		cacheKey = fmt.Sprintf("acfun_%s_%s", kind, rankRange)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		switch kind {
+		case "bangumi":
+			return h.fetchBangumi(ctx, rankRange)
+		case "article":
+			return h.fetchArticle(ctx, rankRange)
+		default:
+			return h.fetchAcfun(ctx, channelType, rankRange)
+		}
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
+	}
+
+	typeName := fmt.Sprintf("排行榜 · %s", h.getTypeName(channelType))
+	if kind != "video" {
+		typeName = fmt.Sprintf("排行榜 · %s", acfunKindMap[kind])
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
-		"acfun", // name: 平台调用名称
-		"AcFun", // title: 平台显示名称
-		fmt.Sprintf("排行榜 · %s", h.getTypeName(channelType)), // type: 榜单类型
-		"发现 AcFun 平台热门内容",                                   // description: 平台描述
-		"https://www.acfun.cn/",                             // link: 官方链接
+		"acfun",                 // name: 平台调用名称
+		"AcFun",                 // title: 平台显示名称
+		typeName,                // type: 榜单类型
+		"发现 AcFun 平台热门内容",       // description: 平台描述
+		"https://www.acfun.cn/", // link: 官方链接
 		map[string]interface{}{ // params: 参数映射
+			"kind": acfunKindMap,
 			"type": map[string]string{
 				"-1": "综合", "155": "番剧", "1": "动画",
 				"60": "娱乐", "201": "生活", "58": "音乐",
 				"123": "舞蹈·偶像", "59": "游戏", "70": "科技",
 				"68": "影视", "69": "体育", "125": "鱼塘",
 			},
-			"range": map[string]string{
-				"DAY": "日榜", "WEEK": "周榜", "MONTH": "月榜",
-			},
+			"range": acfunRangeMap,
 		},
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -105,7 +149,7 @@ func (h *AcfunHandler) fetchAcfun(ctx context.Context, channelType, rankRange st
 		"Referer": fmt.Sprintf("https://www.acfun.cn/rank/list/?cid=-1&pcid=%s&range=%s", channelType, rankRange),
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求 AcFun API 失败: %w", err)
 	}
@@ -120,28 +164,84 @@ func (h *AcfunHandler) fetchAcfun(ctx context.Context, channelType, rankRange st
 	return h.transformData(apiResp.RankList), nil
 }
 
+// fetchBangumi 获取番剧排行
+func (h *AcfunHandler) fetchBangumi(ctx context.Context, rankRange string) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("https://www.acfun.cn/rest/pc-direct/rank/bangumi?rankLimit=30&rankPeriod=%s", rankRange)
+
+	body, err := h.fetcher.GetHTTPClient().GetContext(ctx, apiURL, map[string]string{
+		"Referer": "https://www.acfun.cn/bangumilist",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求 AcFun 番剧排行失败: %w", err)
+	}
+
+	var apiResp AcfunBangumiResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析 AcFun 番剧排行失败: %w", err)
+	}
+
+	return h.transformBangumiData(apiResp.BangumiList), nil
+}
+
+// fetchArticle 获取文章排行
+// 文章区与视频共用频道排行接口,条目 ID 为文章 ID
+func (h *AcfunHandler) fetchArticle(ctx context.Context, rankRange string) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("https://www.acfun.cn/rest/pc-direct/rank/channel?channelId=%s&rankLimit=30&rankPeriod=%s",
+		acfunArticleChannelID, rankRange)
+
+	body, err := h.fetcher.GetHTTPClient().GetContext(ctx, apiURL, map[string]string{
+		"Referer": fmt.Sprintf("https://www.acfun.cn/rank/list/?cid=-1&pcid=%s&range=%s", acfunArticleChannelID, rankRange),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求 AcFun 文章排行失败: %w", err)
+	}
+
+	var apiResp AcfunAPIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析 AcFun 文章排行失败: %w", err)
+	}
+
+	data := h.transformData(apiResp.RankList)
+	for i := range data {
+		data[i].URL = fmt.Sprintf("https://www.acfun.cn/a/ac%s", data[i].ID)
+		data[i].MobileURL = fmt.Sprintf("https://m.acfun.cn/v/?ac=%s&type=article", data[i].ID)
+	}
+	return data, nil
+}
+
+// transformBangumiData 转换番剧数据,以追番数作为热度
+func (h *AcfunHandler) transformBangumiData(items []AcfunBangumiItem) []models.HotData {
+	result := make([]models.HotData, 0, len(items))
+
+	for _, item := range items {
+		id := strconv.FormatInt(item.BangumiID, 10)
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     item.BangumiTitle,
+			Desc:      item.BangumiIntro,
+			Cover:     item.CoverImageV,
+			Hot:       item.StowCount,
+			URL:       fmt.Sprintf("https://www.acfun.cn/bangumi/aa%s", id),
+			MobileURL: fmt.Sprintf("https://m.acfun.cn/v/?ab=%s", id),
+			Extra: map[string]interface{}{
+				"latest": item.LastUpdateItemName,
+				"plays":  item.PlayCount,
+			},
+		})
+	}
+
+	return result
+}
+
 // transformData 将 AcFun 原始数据转换为统一格式
 func (h *AcfunHandler) transformData(items []AcfunItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
 		// 处理DougaID字段(可能是int64或string)
-		var dougaID int64
-		var dougaIDStr string
-		switch v := item.DougaID.(type) {
-		case float64:
-			dougaID = int64(v)
-			dougaIDStr = strconv.FormatInt(int64(v), 10)
-		case int64:
-			dougaID = v
-			dougaIDStr = strconv.FormatInt(v, 10)
-		case string:
-			dougaIDStr = v
-			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-				dougaID = parsed
-			}
-		default:
-			dougaID = 0
+		dougaID := models.ToInt64(item.DougaID)
+		dougaIDStr := models.ToString(item.DougaID)
+		if dougaIDStr == "" {
 			dougaIDStr = "0"
 		}
 
@@ -183,3 +283,19 @@ type AcfunItem struct {
 	LikeCount      int64       `json:"likeCount"`      // 点赞数
 	ContributeTime int64       `json:"contributeTime"` // 投稿时间
 }
+
+// AcfunBangumiResponse 番剧排行响应
+type AcfunBangumiResponse struct {
+	BangumiList []AcfunBangumiItem `json:"bangumiList"`
+}
+
+// AcfunBangumiItem 单个番剧项
+type AcfunBangumiItem struct {
+	BangumiID          int64  `json:"bangumiId"`          // 番剧 ID
+	BangumiTitle       string `json:"bangumiTitle"`       // 标题
+	BangumiIntro       string `json:"bangumiIntro"`       // 简介
+	CoverImageV        string `json:"coverImageV"`        // 竖版封面
+	LastUpdateItemName string `json:"lastUpdateItemName"` // 最新一集,如 "第12话"
+	StowCount          int64  `json:"stowCount"`          // 追番数
+	PlayCount          int64  `json:"playCount"`          // 播放数
+}
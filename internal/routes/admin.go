@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	selfTestConcurrency = 5                // 自检时的并发抓取数
+	selfTestTimeout     = 30 * time.Second // 单个平台的超时时间
+)
+
+// EnableAdmin 开启 /admin 管理接口,请求需携带 Authorization: Bearer <token>
+// 未开启时管理接口返回 404
+func (r *Registry) EnableAdmin(token string) {
+	r.adminToken = token
+}
+
+// requireAdmin 管理接口鉴权中间件
+// 管理接口会集中抓取所有平台,未开启时当作不存在,令牌不匹配时返回 401
+func (r *Registry) requireAdmin(c *fiber.Ctx) error {
+	if r.adminToken == "" {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, "未开启管理接口(admin.enabled)"))
+	}
+
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(r.adminToken)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponseObj(fiber.StatusUnauthorized, "管理接口令牌无效"))
+	}
+	return c.Next()
+}
+
+// SelfTestReport 单个平台的自检结果(JSON 输出)
+type SelfTestReport struct {
+	Platform   string `json:"platform"`
+	Success    bool   `json:"success"`
+	Count      int    `json:"count"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// handleSelfTest 平台自检处理器
+// 与 --selftest 命令行模式相同: 绕过缓存逐个抓取所有平台,返回每个平台的成功/失败/耗时/条数
+func (r *Registry) handleSelfTest(c *fiber.Ctx) error {
+	results := r.SelfTest(c.UserContext(), selfTestConcurrency, selfTestTimeout)
+
+	reports := make([]SelfTestReport, 0, len(results))
+	failed := 0
+	for _, result := range results {
+		if !result.Success {
+			failed++
+		}
+		reports = append(reports, SelfTestReport{
+			Platform:   result.Platform,
+			Success:    result.Success,
+			Count:      result.Count,
+			DurationMs: result.Duration.Milliseconds(),
+			Error:      result.Error,
+		})
+	}
+
+	c.Set("Content-Type", fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.JSON(fiber.Map{
+		"code":      200,
+		"count":     len(reports),
+		"failed":    failed,
+		"platforms": reports,
+	})
+}
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newAdminTestApp(token string) *fiber.App {
+	r := &Registry{adminToken: token}
+	app := fiber.New()
+	app.Get("/admin/ping", r.requireAdmin, func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	return app
+}
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{"未开启", "", "Bearer secret", fiber.StatusNotFound},
+		{"未携带令牌", "secret", "", fiber.StatusUnauthorized},
+		{"令牌错误", "secret", "Bearer wrong", fiber.StatusUnauthorized},
+		{"缺少 Bearer 前缀", "secret", "secret", fiber.StatusUnauthorized},
+		{"令牌正确", "secret", "Bearer secret", fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/admin/ping", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.header)
+			}
+			resp, err := newAdminTestApp(tt.token).Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
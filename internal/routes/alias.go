@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// routeAlias 平台路由别名
+type routeAlias struct {
+	target   string // 规范路径,如 "/douban-movie"
+	redirect bool   // true 时 301 重定向,否则内部转发
+}
+
+// Alias 注册平台路由别名,便于兼容老客户端使用的路径
+// alias 与 target 可带或不带前导 "/",如 Alias("douban", "douban-movie", false)
+// redirect 为 true 时返回 301 重定向到规范路径(保留查询参数),否则直接按规范路径处理
+// 必须在 RegisterRoutes 之前调用
+func (r *Registry) Alias(alias, target string, redirect bool) {
+	r.aliases[normalizeRoutePath(alias)] = routeAlias{
+		target:   normalizeRoutePath(target),
+		redirect: redirect,
+	}
+}
+
+// registerAliases 注册全部别名路由
+// 指向未注册平台或与已有平台同名的别名会被忽略
+func (r *Registry) registerAliases(app *fiber.App) {
+	for alias, route := range r.aliases {
+		handler, ok := r.handlers[route.target]
+		if !ok {
+			logger.Warn("忽略指向未知平台的路由别名",
+				zap.String("alias", alias),
+				zap.String("target", route.target),
+			)
+			continue
+		}
+		if _, exists := r.handlers[alias]; exists {
+			logger.Warn("忽略与已有平台同名的路由别名", zap.String("alias", alias))
+			continue
+		}
+
+		if route.redirect {
+			target := route.target
+			app.Get(alias, func(c *fiber.Ctx) error {
+				location := target
+				if query := c.Context().QueryArgs().String(); query != "" {
+					location += "?" + query
+				}
+				return c.Redirect(location, fiber.StatusMovedPermanently)
+			})
+			continue
+		}
+
+		// 内部转发: 与规范路径共用中间件链与处理器,统计也计入规范平台
+		chain := make([]fiber.Handler, 0, len(r.middlewares)+1)
+		chain = append(chain, r.middlewares...)
+		chain = append(chain, r.wrap(handler))
+		app.Get(alias, chain...)
+	}
+}
+
+// aliasTable 返回别名 -> 规范路径的映射,供 /all 展示
+func (r *Registry) aliasTable() map[string]string {
+	table := make(map[string]string, len(r.aliases))
+	for alias, route := range r.aliases {
+		if _, ok := r.handlers[route.target]; ok {
+			table[alias] = route.target
+		}
+	}
+	return table
+}
+
+// normalizeRoutePath 统一为带前导 "/" 的路径
+func normalizeRoutePath(path string) string {
+	return "/" + strings.Trim(strings.TrimSpace(path), "/")
+}
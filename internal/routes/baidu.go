@@ -13,6 +13,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// baiduTypeMap 榜单类型映射,键为 top.baidu.com 的 tab 参数
+var baiduTypeMap = map[string]string{
+	"realtime":    "热搜",
+	"phrase":      "热梗",
+	"livelihood":  "民生",
+	"finance":     "财经",
+	"novel":       "小说",
+	"movie":       "电影",
+	"teleplay":    "电视剧",
+	"documentary": "纪录片",
+	"car":         "汽车",
+	"game":        "游戏",
+}
+
+// baiduDataPattern 百度的数据嵌入在 HTML 注释中
+var baiduDataPattern = regexp.MustCompile(`<!--s-data:(.*?)-->`)
+
 // BaiduHandler 百度热搜处理器
 type BaiduHandler struct {
 	fetcher *service.Fetcher
@@ -34,76 +51,56 @@ func (h *BaiduHandler) GetPath() string {
 func (h *BaiduHandler) Handle(c *fiber.Ctx) error {
 	// 获取类型参数 (实时/小说/电影等)
 	hotType := c.Query("type", "realtime")
-	noCache := c.Query("cache") == "false"
-
-	// 类型映射表
-	typeMap := map[string]string{
-		"realtime": "热搜",
-		"novel":    "小说",
-		"movie":    "电影",
-		"teleplay": "电视剧",
-		"car":      "汽车",
-		"game":     "游戏",
+	if _, ok := baiduTypeMap[hotType]; !ok {
+		hotType = "realtime"
 	}
-
-	// 获取当前类型名称
-	typeName := h.getTypeName(hotType)
+	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchBaiduHot(c.Context(), hotType)
+	cacheKey := fmt.Sprintf("baidu_%s", hotType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchBaiduHot(ctx, hotType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
 		"baidu",                  // name: 平台调用名称
 		"百度",                     // title: 平台显示名称
-		typeName,                 // type: 当前类型(只返回类型名称,不需要前缀)
+		baiduTypeMap[hotType],    // type: 当前类型(只返回类型名称,不需要前缀)
 		"发现百度热门搜索内容",             // description: 平台描述
 		"https://top.baidu.com/", // link: 官方链接
 		map[string]interface{}{ // params: 参数说明
-			"type": typeMap,
+			"type": baiduTypeMap,
 		},
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
-// getTypeName 获取类型中文名称
-func (h *BaiduHandler) getTypeName(hotType string) string {
-	typeMap := map[string]string{
-		"realtime": "热搜",
-		"novel":    "小说",
-		"movie":    "电影",
-		"teleplay": "电视剧",
-		"car":      "汽车",
-		"game":     "游戏",
-	}
-
-	if name, ok := typeMap[hotType]; ok {
-		return name
-	}
-	return "热搜"
-}
-
 // fetchBaiduHot 从百度获取热搜数据
 func (h *BaiduHandler) fetchBaiduHot(ctx context.Context, hotType string) ([]models.HotData, error) {
 	apiURL := fmt.Sprintf("https://top.baidu.com/board?tab=%s", hotType)
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"User-Agent": "Mozilla/5.0 (iPhone; CPU iPhone OS 14_2_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) FxiOS/1.0 Mobile/12F69 Safari/605.1.15",
 	})
 	if err != nil {
 		return nil, fmt.Errorf("请求百度 API 失败: %w", err)
 	}
 
+	return h.parseBoard(body)
+}
+
+// parseBoard 解析榜单页面
+func (h *BaiduHandler) parseBoard(body []byte) ([]models.HotData, error) {
 	// 百度的数据嵌入在 HTML 注释中,需要用正则提取
-	pattern := regexp.MustCompile(`<!--s-data:(.*?)-->`)
-	matches := pattern.FindSubmatch(body)
+	matches := baiduDataPattern.FindSubmatch(body)
 	if len(matches) < 2 {
 		return nil, fmt.Errorf("未找到百度数据")
 	}
@@ -114,12 +111,39 @@ func (h *BaiduHandler) fetchBaiduHot(ctx context.Context, hotType string) ([]mod
 		return nil, fmt.Errorf("解析百度数据失败: %w", err)
 	}
 
-	// 检查数据结构
-	if len(dataWrapper.Cards) == 0 || dataWrapper.Cards[0].Content == nil {
-		return nil, fmt.Errorf("百度数据为空")
+	card := h.pickCard(dataWrapper.Cards)
+	if card == nil {
+		return nil, fmt.Errorf("百度%w", service.ErrEmptyData)
 	}
 
-	return h.transformData(dataWrapper.Cards[0].Content), nil
+	return h.transformData(card.Content), nil
+}
+
+// pickCard 选出榜单卡片
+// 部分 tab 的第 0 张卡片是筛选栏、广告位等,优先取 component 为 hotList 的卡片,
+// 其次取第一张包含热搜词的卡片
+func (h *BaiduHandler) pickCard(cards []BaiduCard) *BaiduCard {
+	for i := range cards {
+		if cards[i].Component == "hotList" && h.hasWords(cards[i].Content) {
+			return &cards[i]
+		}
+	}
+	for i := range cards {
+		if h.hasWords(cards[i].Content) {
+			return &cards[i]
+		}
+	}
+	return nil
+}
+
+// hasWords 卡片内容中是否有热搜词
+func (h *BaiduHandler) hasWords(items []BaiduItem) bool {
+	for _, item := range items {
+		if item.Word != "" {
+			return true
+		}
+	}
+	return false
 }
 
 // transformData 转换数据格式
@@ -127,6 +151,10 @@ func (h *BaiduHandler) transformData(items []BaiduItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
+		if item.Word == "" {
+			continue
+		}
+
 		// 处理Show字段(可能是string或array)
 		author := ""
 		switch v := item.Show.(type) {
@@ -141,20 +169,7 @@ func (h *BaiduHandler) transformData(items []BaiduItem) []models.HotData {
 		}
 
 		// 处理HotScore字段（可能是int64或string）
-		var hotScore int64
-		switch v := item.HotScore.(type) {
-		case float64:
-			hotScore = int64(v)
-		case int64:
-			hotScore = v
-		case string:
-			// 尝试将字符串转换为int64
-			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-				hotScore = parsed
-			}
-		default:
-			hotScore = 0
-		}
+		hotScore := models.ToInt64(item.HotScore)
 
 		hotData := models.HotData{
 			ID:        strconv.Itoa(item.Index),
@@ -165,6 +180,10 @@ func (h *BaiduHandler) transformData(items []BaiduItem) []models.HotData {
 			URL:       fmt.Sprintf("https://www.baidu.com/s?wd=%s", url.QueryEscape(item.Query)),
 			MobileURL: item.RawURL,
 			Author:    author,
+			Extra: map[string]interface{}{
+				"trend": item.HotChange, // 排名变化: up 上升 / down 下降 / same 持平
+				"tag":   item.HotTag,    // 标签: 新、热、沸等,取值为百度的标签编号
+			},
 		}
 
 		result = append(result, hotData)
@@ -180,17 +199,20 @@ type BaiduDataWrapper struct {
 
 // BaiduCard 卡片
 type BaiduCard struct {
-	Content []BaiduItem `json:"content"`
+	Component string      `json:"component"` // 卡片类型,榜单为 hotList
+	Content   []BaiduItem `json:"content"`
 }
 
 // BaiduItem 热搜项
 type BaiduItem struct {
-	Index    int         `json:"index"`    // 排名
-	Word     string      `json:"word"`     // 热搜词
-	Query    string      `json:"query"`    // 查询词
-	Desc     string      `json:"desc"`     // 描述
-	Img      string      `json:"img"`      // 图片
-	Show     interface{} `json:"show"`     // 来源 (可能是string或array)
-	RawURL   string      `json:"rawUrl"`   // 移动端 URL
-	HotScore interface{} `json:"hotScore"` // 热度分数（可能是int64或string）
+	Index     int         `json:"index"`     // 排名
+	Word      string      `json:"word"`      // 热搜词
+	Query     string      `json:"query"`     // 查询词
+	Desc      string      `json:"desc"`      // 描述
+	Img       string      `json:"img"`       // 图片
+	Show      interface{} `json:"show"`      // 来源 (可能是string或array)
+	RawURL    string      `json:"rawUrl"`    // 移动端 URL
+	HotScore  interface{} `json:"hotScore"`  // 热度分数（可能是int64或string）
+	HotChange string      `json:"hotChange"` // 排名变化
+	HotTag    interface{} `json:"hotTag"`    // 标签编号(可能是string或number)
 }
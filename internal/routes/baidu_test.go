@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dailyhot/api/internal/service"
+)
+
+// baiduRealtimeFixture 热搜 tab 页面(节选),榜单卡片位于第 0 位
+// 数据在页面中为单行的 s-data 注释
+const baiduRealtimeFixture = `<!DOCTYPE html><html><head></head><body><div id="sanRoot"></div>
+<!--s-data:{"data":{},"cards":[{"component":"hotList","content":[{"index":0,"word":"神舟十八号发射成功","query":"神舟十八号发射成功","desc":"4月25日20时59分,神舟十八号载人飞船发射成功。","img":"https://fyb-2.cdn.bcebos.com/hotboard_image/a.jpg","show":[],"rawUrl":"https://m.baidu.com/s?word=%E7%A5%9E%E8%88%9F","hotScore":"4952103","hotChange":"same","hotTag":"3"},{"index":1,"word":"五一假期出行指南","query":"五一 出行","desc":"","img":"","show":"央视新闻","rawUrl":"","hotScore":4800000,"hotChange":"up","hotTag":0}]}]}-->
+</body></html>`
+
+// baiduNovelFixture 小说 tab 页面(节选),第 0 张卡片为分类筛选栏
+const baiduNovelFixture = `<html><body>
+<!--s-data:{"cards":[{"component":"tagFilter","content":[{"index":0,"name":"全部类型"}]},{"component":"hotList","content":[{"index":0,"word":"剑来","query":"剑来","desc":"大千世界,无奇不有。","img":"https://fyb-pc-static.cdn.bcebos.com/novel.jpg","show":["作者：烽火戏诸侯","类型：玄幻"],"rawUrl":"https://m.baidu.com/s?word=%E5%89%91%E6%9D%A5","hotScore":"29817","hotChange":"down"}]}]}-->
+</body></html>`
+
+func TestBaiduParseBoard(t *testing.T) {
+	h := &BaiduHandler{}
+
+	data, err := h.parseBoard([]byte(baiduRealtimeFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("realtime: len(data) = %d, want 2", len(data))
+	}
+	first := data[0]
+	if first.ID != "0" || first.Title != "神舟十八号发射成功" || first.Hot != int64(4952103) || first.Author != "" {
+		t.Errorf("realtime[0] = %+v", first)
+	}
+	if first.URL != "https://www.baidu.com/s?wd=%E7%A5%9E%E8%88%9F%E5%8D%81%E5%85%AB%E5%8F%B7%E5%8F%91%E5%B0%84%E6%88%90%E5%8A%9F" {
+		t.Errorf("URL = %q", first.URL)
+	}
+	if first.Extra["trend"] != "same" || first.Extra["tag"] != "3" {
+		t.Errorf("Extra = %v", first.Extra)
+	}
+	// hotScore 为数字、show 为字符串
+	if data[1].Hot != int64(4800000) || data[1].Author != "央视新闻" || data[1].URL != "https://www.baidu.com/s?wd=%E4%BA%94%E4%B8%80+%E5%87%BA%E8%A1%8C" {
+		t.Errorf("realtime[1] = %+v", data[1])
+	}
+
+	// 榜单卡片不在第 0 位时按 component 选取;show 为数组时取第一项
+	data, err = h.parseBoard([]byte(baiduNovelFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 1 || data[0].Title != "剑来" || data[0].Author != "作者：烽火戏诸侯" || data[0].Hot != int64(29817) {
+		t.Errorf("novel = %+v", data)
+	}
+}
+
+func TestBaiduParseBoardErrors(t *testing.T) {
+	h := &BaiduHandler{}
+	if _, err := h.parseBoard([]byte("<html></html>")); err == nil {
+		t.Error("missing s-data: err = nil, want error")
+	}
+	if _, err := h.parseBoard([]byte(`<!--s-data:{bad-->`)); err == nil {
+		t.Error("invalid JSON: err = nil, want error")
+	}
+	empty := `<!--s-data:{"cards":[{"component":"tagFilter","content":[{"index":0}]}]}-->`
+	if _, err := h.parseBoard([]byte(empty)); !errors.Is(err, service.ErrEmptyData) {
+		t.Errorf("no list card: err = %v, want ErrEmptyData", err)
+	}
+}
+
+func TestBaiduPickCard(t *testing.T) {
+	h := &BaiduHandler{}
+	cards := []BaiduCard{
+		{Component: "ad", Content: []BaiduItem{{Word: "广告"}}},
+		{Component: "hotList", Content: []BaiduItem{{Word: "榜单"}}},
+	}
+	if card := h.pickCard(cards); card == nil || card.Content[0].Word != "榜单" {
+		t.Errorf("pickCard = %+v, want hotList card", card)
+	}
+
+	// 没有 hotList 卡片时取第一张包含热搜词的卡片
+	cards = []BaiduCard{
+		{Component: "tagFilter"},
+		{Component: "list", Content: []BaiduItem{{Word: "词"}}},
+	}
+	if card := h.pickCard(cards); card == nil || card.Component != "list" {
+		t.Errorf("pickCard = %+v, want list card", card)
+	}
+}
@@ -0,0 +1,158 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// batchMaxItems 单次批量查询的最大条数
+	batchMaxItems = 20
+	// batchConcurrency 批量查询的最大并发数
+	batchConcurrency = 5
+	// batchMaxTimeout ?timeout 允许的最大整体超时
+	batchMaxTimeout = time.Minute
+)
+
+// BatchQuery 批量查询中的单项
+type BatchQuery struct {
+	Path  string            `json:"path"`  // 平台路径,如 "/bilibili"
+	Query map[string]string `json:"query"` // 查询参数,如 {"type": "188"}
+}
+
+// BatchResult 批量查询中单项的结果
+// 成功时 data 为该平台的统一响应,失败时 error 为错误信息
+type BatchResult struct {
+	Path    string            `json:"path"`
+	Query   map[string]string `json:"query,omitempty"`
+	Data    *models.Response  `json:"data,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Timeout bool              `json:"timeout,omitempty"` // 整体超时前未完成
+}
+
+// handleBatch 批量查询处理器
+// 请求体为查询数组,如 [{"path":"/bilibili","query":{"type":"188"}}, ...]
+// 各项并发执行(复用缓存与平台中间件),结果按请求顺序返回,单项失败不影响其他项
+// 可通过 ?timeout=3s 设置整体超时: 到时直接返回已完成项的结果,未完成项标记为 timeout,
+// 其抓取仍在后台继续并写入缓存
+func (r *Registry) handleBatch(c *fiber.Ctx) error {
+	var queries []BatchQuery
+	if err := c.BodyParser(&queries); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, "请求体格式错误: "+err.Error()))
+	}
+	if len(queries) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, "查询列表不能为空"))
+	}
+	if len(queries) > batchMaxItems {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, fmt.Sprintf("单次最多查询 %d 项", batchMaxItems)))
+	}
+
+	ctx := c.UserContext()
+	if raw := c.Query("timeout"); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout <= 0 || timeout > batchMaxTimeout {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, fmt.Sprintf("timeout 参数无效,应为 (0, %s] 之间的时长,如 3s", batchMaxTimeout)))
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	results, partial := r.runBatch(ctx, queries)
+
+	c.Set("Content-Type", fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.JSON(fiber.Map{
+		"code":    200,
+		"count":   len(results),
+		"partial": partial,
+		"results": results,
+	})
+}
+
+// runBatch 并发执行批量查询
+// ctx 结束时不再等待未完成的项,返回已收集的结果并将其余项标记为超时;partial 表示是否有项超时
+func (r *Registry) runBatch(ctx context.Context, queries []BatchQuery) ([]BatchResult, bool) {
+	var mu sync.Mutex
+	results := make([]BatchResult, len(queries))
+	finished := make([]bool, len(queries))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, batchConcurrency)
+	for i, query := range queries {
+		results[i] = BatchResult{Path: query.Path, Query: query.Query}
+
+		// 只允许查询已注册的平台路由,避免递归调用 /batch 等内置接口
+		if _, ok := r.handlers[query.Path]; !ok {
+			results[i].Error = "未知的平台路径: " + query.Path
+			finished[i] = true
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, query BatchQuery) {
+			defer wg.Done()
+
+			// 排队期间已超时的项不再发起请求
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-semaphore }()
+
+			target := query.Path
+			if len(query.Query) > 0 {
+				values := url.Values{}
+				for key, value := range query.Query {
+					values.Set(key, value)
+				}
+				target += "?" + values.Encode()
+			}
+
+			resp, err := r.invoke(ctx, target, "DailyHotApi/Batch")
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				results[i].Error = err.Error()
+			} else {
+				// 进程内调用返回规范字段,按平台配置的字段映射序列化,与直接请求平台路由的输出一致
+				results[i].Data = resp.WithFieldMapping(r.fetcher.FieldMapping(strings.TrimPrefix(query.Path, "/")))
+			}
+			finished[i] = true
+		}(i, query)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	// 复制一份结果,超时后仍在运行的 goroutine 不会再影响返回值
+	mu.Lock()
+	defer mu.Unlock()
+	snapshot := make([]BatchResult, len(results))
+	partial := false
+	for i, result := range results {
+		if !finished[i] {
+			result.Error = "抓取超时"
+			result.Timeout = true
+			partial = true
+		}
+		snapshot[i] = result
+	}
+	return snapshot, partial
+}
@@ -0,0 +1,162 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/internal/config"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeBatchHandler 平台处理器,release 不为 nil 时阻塞到 release 关闭
+type fakeBatchHandler struct {
+	path    string
+	release chan struct{}
+}
+
+func (h *fakeBatchHandler) GetPath() string { return h.path }
+
+func (h *fakeBatchHandler) Handle(c *fiber.Ctx) error {
+	if h.release != nil {
+		<-h.release
+	}
+	items := []models.HotData{{ID: "1", Title: h.path, URL: "https://example.com"}}
+	return c.JSON(models.SuccessResponse(h.path[1:], "测试", "热榜", "", "", nil, items, false))
+}
+
+func newBatchTestApp(t *testing.T, handlers ...*fakeBatchHandler) (*Registry, *fiber.App) {
+	t.Helper()
+	r := NewRegistry(newTestFetcher(t))
+	app := fiber.New()
+	r.app = app
+	for _, handler := range handlers {
+		r.handlers[handler.path] = handler
+		app.Get(handler.path, handler.Handle)
+	}
+	app.Post("/batch", r.handleBatch)
+	return r, app
+}
+
+func TestRunBatch(t *testing.T) {
+	r, _ := newBatchTestApp(t, &fakeBatchHandler{path: "/fast"})
+
+	results, partial := r.runBatch(context.Background(), []BatchQuery{
+		{Path: "/fast"},
+		{Path: "/unknown"},
+	})
+	if partial {
+		t.Error("partial = true, want false")
+	}
+	if results[0].Data == nil || results[0].Data.Title != "测试" {
+		t.Errorf("results[0] = %+v, want data", results[0])
+	}
+	if results[1].Error == "" || results[1].Timeout {
+		t.Errorf("results[1] = %+v, want unknown path error", results[1])
+	}
+}
+
+func TestRunBatchTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	r, _ := newBatchTestApp(t,
+		&fakeBatchHandler{path: "/fast"},
+		&fakeBatchHandler{path: "/slow", release: release},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results, partial := r.runBatch(ctx, []BatchQuery{{Path: "/fast"}, {Path: "/slow"}})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("runBatch took %s, want to return at the deadline", elapsed)
+	}
+
+	if !partial {
+		t.Error("partial = false, want true")
+	}
+	if results[0].Data == nil || results[0].Timeout {
+		t.Errorf("results[0] = %+v, want completed", results[0])
+	}
+	if !results[1].Timeout || results[1].Data != nil || results[1].Error == "" {
+		t.Errorf("results[1] = %+v, want timeout", results[1])
+	}
+}
+
+func TestHandleBatchTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	_, app := newBatchTestApp(t,
+		&fakeBatchHandler{path: "/fast"},
+		&fakeBatchHandler{path: "/slow", release: release},
+	)
+
+	post := func(target string) (int, map[string]interface{}) {
+		body, _ := json.Marshal([]BatchQuery{{Path: "/fast"}, {Path: "/slow"}})
+		req := httptest.NewRequest("POST", target, bytes.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req, 5000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	status, result := post("/batch?timeout=50ms")
+	if status != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if result["partial"] != true {
+		t.Errorf("partial = %v, want true", result["partial"])
+	}
+	items, _ := result["results"].([]interface{})
+	if len(items) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(items))
+	}
+	if slow, _ := items[1].(map[string]interface{}); slow["timeout"] != true {
+		t.Errorf("results[1] = %v, want timeout", slow)
+	}
+
+	for _, timeout := range []string{"abc", "0s", "2m"} {
+		if status, _ := post("/batch?timeout=" + timeout); status != fiber.StatusBadRequest {
+			t.Errorf("timeout=%s: status = %d, want 400", timeout, status)
+		}
+	}
+}
+
+func TestRunBatchAppliesFieldMapping(t *testing.T) {
+	handler := &fakeBatchHandler{path: "/fast"}
+	r := NewRegistry(newPlatformTestFetcher(t, map[string]config.PlatformConfig{
+		"fast": {Fields: config.FieldsConfig{
+			Aliases: map[string][]string{"url": {"link"}},
+			Rename:  map[string]string{"title": "name"},
+		}},
+	}))
+	r.app = fiber.New()
+	r.handlers[handler.path] = handler
+	r.app.Get(handler.path, handler.Handle)
+
+	results, _ := r.runBatch(context.Background(), []BatchQuery{{Path: "/fast"}})
+	body, err := json.Marshal(results[0].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	item := resp.Data[0]
+	if item["link"] != "https://example.com" || item["name"] != "/fast" || item["title"] != nil {
+		t.Errorf("item = %v, want link alias and title renamed to name", item)
+	}
+}
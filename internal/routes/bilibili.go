@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
@@ -12,6 +13,16 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// bilibiliListMap 入站排行榜单映射
+var bilibiliListMap = map[string]string{
+	"weekly":   "每周必看",
+	"precious": "入站必刷",
+}
+
+// bilibiliListCacheDuration 入站排行缓存时长
+// 每周必看每周更新一期,入站必刷更新更少,无需频繁抓取
+const bilibiliListCacheDuration = 6 * time.Hour
+
 // BilibiliHandler B站热榜处理器
 type BilibiliHandler struct {
 	fetcher *service.Fetcher
@@ -31,19 +42,29 @@ func (h *BilibiliHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *BilibiliHandler) Handle(c *fiber.Ctx) error {
-	// 获取 type 参数 (分区ID)
-	typeParam := c.Query("type", "0")
-
 	// 获取缓存标志
 	noCache := c.Query("cache") == "false"
 
+	// 入站排行(每周必看/入站必刷)
+	if listName, ok := bilibiliListMap[c.Query("list")]; ok {
+		return h.handleList(c, c.Query("list"), listName, noCache)
+	}
+
+	// 获取 type 参数 (分区ID)
+	typeParam := c.Query("type", "0")
+	if err := validateParam("type", typeParam, isNumeric); err != nil {
+		return paramError(c, err)
+	}
+
 	// 构建缓存键
 	cacheKey := fmt.Sprintf("bilibili_hot_%s", typeParam)
 
 	// 获取数据
-	data, err := h.fetchBilibiliHot(c.Context(), typeParam, noCache)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchBilibiliHot(ctx, typeParam)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 获取类型映射
@@ -77,18 +98,112 @@ func (h *BilibiliHandler) Handle(c *fiber.Ctx) error {
 		"https://www.bilibili.com/v/popular/rank/all", // link: 官方链接
 		map[string]interface{}{ // params: 参数说明
 			"type": typeMap,
+			"list": bilibiliListMap,
 		},
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存 (简化处理)
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
-	_ = cacheKey // 避免未使用警告,实际应该用缓存键
+	return c.JSON(resp)
+}
+
+// handleList 处理入站排行请求
+func (h *BilibiliHandler) handleList(c *fiber.Ctx, list, listName string, noCache bool) error {
+	cacheKey := fmt.Sprintf("bilibili_%s", list)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, bilibiliListCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if list == "weekly" {
+			return h.fetchWeekly(ctx)
+		}
+		return h.fetchPrecious(ctx)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	resp := models.SuccessResponse(
+		"bilibili",
+		"哔哩哔哩",
+		listName,
+		"你所热爱的，就是你的生活",
+		"https://www.bilibili.com/v/popular/"+list,
+		map[string]interface{}{
+			"list": bilibiliListMap,
+		},
+		data,
+		fromCache,
+	)
 
 	return c.JSON(resp)
 }
 
+// fetchWeekly 获取最新一期每周必看
+// 先从期数列表取最新期号,再获取该期的视频列表
+func (h *BilibiliHandler) fetchWeekly(ctx context.Context) ([]models.HotData, error) {
+	headers := map[string]string{
+		"Referer":    "https://www.bilibili.com/v/popular/weekly",
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/123.0.0.0 Safari/537.36",
+	}
+	httpClient := h.fetcher.GetHTTPClient()
+
+	body, err := httpClient.GetContext(ctx, "https://api.bilibili.com/x/web-interface/popular/series/list", headers)
+	if err != nil {
+		return nil, fmt.Errorf("请求 B站每周必看期数列表失败: %w", err)
+	}
+
+	var seriesResp BilibiliSeriesListResponse
+	if err := json.Unmarshal(body, &seriesResp); err != nil {
+		return nil, fmt.Errorf("解析 B站每周必看期数列表失败: %w", err)
+	}
+	if seriesResp.Code != 0 {
+		return nil, fmt.Errorf("B站每周必看期数列表返回错误(code=%d): %s", seriesResp.Code, seriesResp.Message)
+	}
+	if len(seriesResp.Data.List) == 0 {
+		return nil, fmt.Errorf("B站每周必看无期数数据")
+	}
+
+	// 列表按期号倒序,第一项即最新一期
+	number := seriesResp.Data.List[0].Number
+	apiURL := fmt.Sprintf("https://api.bilibili.com/x/web-interface/popular/series/one?number=%d", number)
+	body, err = httpClient.GetContext(ctx, apiURL, headers)
+	if err != nil {
+		return nil, fmt.Errorf("请求 B站每周必看失败: %w", err)
+	}
+
+	var apiResp BilibiliRankingResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析 B站每周必看响应失败: %w", err)
+	}
+	if apiResp.Code != 0 {
+		return nil, fmt.Errorf("B站每周必看返回错误(code=%d): %s", apiResp.Code, apiResp.Message)
+	}
+
+	return h.transformRankingData(apiResp.Data.List), nil
+}
+
+// fetchPrecious 获取入站必刷
+func (h *BilibiliHandler) fetchPrecious(ctx context.Context) ([]models.HotData, error) {
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, "https://api.bilibili.com/x/web-interface/popular/precious?page_size=100&page=1", map[string]string{
+		"Referer":    "https://www.bilibili.com/v/popular/history",
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/123.0.0.0 Safari/537.36",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求 B站入站必刷失败: %w", err)
+	}
+
+	var apiResp BilibiliRankingResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析 B站入站必刷响应失败: %w", err)
+	}
+	if apiResp.Code != 0 {
+		return nil, fmt.Errorf("B站入站必刷返回错误(code=%d): %s", apiResp.Code, apiResp.Message)
+	}
+
+	return h.transformRankingData(apiResp.Data.List), nil
+}
+
 // fetchBilibiliHot 从 B站 API 获取热榜数据(双接口策略)
-func (h *BilibiliHandler) fetchBilibiliHot(ctx context.Context, typeParam string, noCache bool) ([]models.HotData, error) {
+func (h *BilibiliHandler) fetchBilibiliHot(ctx context.Context, typeParam string) ([]models.HotData, error) {
 	// 策略1: 尝试主接口(ranking/v2)
 	data, err := h.tryMainAPI(ctx, typeParam)
 	if err == nil && len(data) > 0 {
@@ -121,7 +236,7 @@ func (h *BilibiliHandler) tryMainAPI(ctx context.Context, typeParam string) ([]m
 
 	// 5. 发起 HTTP 请求(添加完整的浏览器请求头)
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"Referer":            "https://www.bilibili.com/ranking/all",
 		"User-Agent":         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/123.0.0.0 Safari/537.36",
 		"Accept":             "application/json, text/plain, */*",
@@ -165,7 +280,7 @@ func (h *BilibiliHandler) tryBackupAPI(ctx context.Context, typeParam string) ([
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"Referer":    "https://www.bilibili.com/ranking/all",
 		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/123.0.0.0 Safari/537.36",
 	})
@@ -233,6 +348,27 @@ func (h *BilibiliHandler) transformRankingData(items []BilibiliRankingItem) []mo
 			Author:    item.Owner.Name,
 			Timestamp: timestamp, // 时间戳转换为毫秒级 (interface{} 类型)
 			MobileURL: fmt.Sprintf("https://m.bilibili.com/video/%s", bvid),
+
+			// 扩展字段: 时长、UP主头像与各项统计数据
+			Extra: map[string]interface{}{
+				"duration":      formatBilibiliDuration(item.Duration),
+				"author_avatar": item.Owner.Face,
+				"views":         item.Stat.View,
+				"danmaku":       item.Stat.Danmaku,
+				"comments":      item.Stat.Reply,
+				"favorites":     item.Stat.Favorite,
+				"coins":         item.Stat.Coin,
+				"shares":        item.Stat.Share,
+				"likes":         item.Stat.Like,
+			},
+		}
+
+		// 入站排行的推荐语/成就
+		if item.RcmdReason != "" {
+			hotData.Extra["reason"] = item.RcmdReason
+		}
+		if item.Achievement != "" {
+			hotData.Extra["achievement"] = item.Achievement
 		}
 
 		result = append(result, hotData)
@@ -241,6 +377,17 @@ func (h *BilibiliHandler) transformRankingData(items []BilibiliRankingItem) []mo
 	return result
 }
 
+// formatBilibiliDuration 将秒数格式化为 mm:ss,超过一小时时为 h:mm:ss
+func formatBilibiliDuration(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
 // 以下是 B站 API 的响应结构体定义
 
 // BilibiliRankingResponse B站排行榜API响应
@@ -269,6 +416,9 @@ type BilibiliRankingItem struct {
 	Owner    BilibiliOwner `json:"owner"`    // 作者信息
 	Stat     BilibiliStat  `json:"stat"`     // 统计信息
 	Score    int64         `json:"score"`    // 排行分数
+
+	RcmdReason  string `json:"rcmd_reason"` // 推荐语(每周必看)
+	Achievement string `json:"achievement"` // 成就说明(入站必刷)
 }
 
 // BilibiliOwner 作者信息
@@ -288,3 +438,19 @@ type BilibiliStat struct {
 	Share    int   `json:"share"`    // 分享数
 	Like     int   `json:"like"`     // 点赞数
 }
+
+// BilibiliSeriesListResponse 每周必看期数列表响应
+type BilibiliSeriesListResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		List []BilibiliSeries `json:"list"`
+	} `json:"data"`
+}
+
+// BilibiliSeries 每周必看单期信息
+type BilibiliSeries struct {
+	Number  int    `json:"number"`  // 期号
+	Subject string `json:"subject"` // 本期主题
+	Name    string `json:"name"`    // 期数名称,如 "2024第10期"
+}
@@ -0,0 +1,147 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// cnbetaTypeMap 榜单类型映射
+var cnbetaTypeMap = map[string]string{
+	"latest": "最新资讯",
+	"hot":    "热门资讯",
+}
+
+// cnbetaArticlePattern 从文章链接中提取 ID
+// 如 https://www.cnbeta.com.tw/articles/tech/1234567.htm -> 1234567
+var cnbetaArticlePattern = regexp.MustCompile(`/articles/[a-z]+/(\d+)\.htm`)
+
+// CnbetaHandler cnBeta 科技资讯处理器
+type CnbetaHandler struct {
+	fetcher *service.Fetcher
+}
+
+// NewCnbetaHandler 创建 cnBeta 处理器
+func NewCnbetaHandler(fetcher *service.Fetcher) *CnbetaHandler {
+	return &CnbetaHandler{
+		fetcher: fetcher,
+	}
+}
+
+// GetPath 获取路由路径
+func (h *CnbetaHandler) GetPath() string {
+	return "/cnbeta"
+}
+
+// Handle 处理请求
+func (h *CnbetaHandler) Handle(c *fiber.Ctx) error {
+	listType := c.Query("type", "latest")
+	if _, ok := cnbetaTypeMap[listType]; !ok {
+		listType = "latest"
+	}
+	noCache := c.Query("cache") == "false"
+
+	cacheKey := fmt.Sprintf("cnbeta_%s", listType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, 10*time.Minute, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if listType == "hot" {
+			return h.fetchHot(ctx)
+		}
+		return h.fetchLatest(ctx)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	resp := models.SuccessResponse(
+		"cnbeta",                     // name: 平台调用名称
+		"cnBeta",                     // title: 平台显示名称
+		cnbetaTypeMap[listType],      // type: 榜单类型
+		"中文业界资讯站",                    // description: 平台描述
+		"https://www.cnbeta.com.tw/", // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": cnbetaTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
+	)
+
+	return c.JSON(resp)
+}
+
+// fetchLatest 通过 RSS 获取最新资讯
+func (h *CnbetaHandler) fetchLatest(ctx context.Context) ([]models.HotData, error) {
+	parser := NewFeedParser(h.fetcher.GetHTTPClient())
+	feed, err := parser.Fetch(ctx, "https://www.cnbeta.com.tw/backend.php", nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取 cnBeta 最新资讯失败: %w", err)
+	}
+
+	data := parser.ToHotData(feed.Items)
+	for i := range data {
+		// RSS 的 GUID 是完整链接,统一为文章数字 ID
+		if matches := cnbetaArticlePattern.FindStringSubmatch(data[i].URL); len(matches) > 1 {
+			data[i].ID = matches[1]
+			data[i].MobileURL = fmt.Sprintf("https://m.cnbeta.com.tw/view/%s.htm", matches[1])
+		}
+	}
+
+	return data, nil
+}
+
+// fetchHot 从首页侧边栏获取热门资讯排行
+func (h *CnbetaHandler) fetchHot(ctx context.Context) ([]models.HotData, error) {
+	body, err := h.fetcher.Get(ctx, "https://www.cnbeta.com.tw/", map[string]string{
+		"Referer": "https://www.cnbeta.com.tw/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求 cnBeta 首页失败: %w", err)
+	}
+
+	return h.parseHotHTML(string(body)), nil
+}
+
+// parseHotHTML 解析首页热门排行
+func (h *CnbetaHandler) parseHotHTML(html string) []models.HotData {
+	result := make([]models.HotData, 0)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return result
+	}
+
+	seen := make(map[string]bool)
+	doc.Find(".cnbeta-side-rank li a, .side-rank li a").Each(func(i int, s *goquery.Selection) {
+		title := strings.TrimSpace(s.Text())
+		href, _ := s.Attr("href")
+		if title == "" || href == "" {
+			return
+		}
+
+		// 补全协议相对地址
+		if strings.HasPrefix(href, "//") {
+			href = "https:" + href
+		}
+
+		matches := cnbetaArticlePattern.FindStringSubmatch(href)
+		if len(matches) < 2 || seen[matches[1]] {
+			return
+		}
+		seen[matches[1]] = true
+
+		result = append(result, models.HotData{
+			ID:        matches[1],
+			Title:     title,
+			URL:       href,
+			MobileURL: fmt.Sprintf("https://m.cnbeta.com.tw/view/%s.htm", matches[1]),
+		})
+	})
+
+	return result
+}
@@ -4,24 +4,46 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	httpclient "github.com/dailyhot/api/internal/http"
+	"github.com/dailyhot/api/internal/logger"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/dailyhot/api/pkg/utils"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
+// coolapkTypeMap 榜单类型映射
+var coolapkTypeMap = map[string]string{
+	"day":   "今日热门",
+	"week":  "本周热门",
+	"topic": "热门话题",
+}
+
+// coolapkListURLs 各榜单的接口地址
+// 今日/本周热门为同一动态统计接口,通过 statType 区分;热门话题为话题列表接口
+var coolapkListURLs = map[string]string{
+	"day":   "https://api.coolapk.com/v6/page/dataList?url=/feed/statList?cacheExpires=300&statType=day&sortField=detailnum&title=今日热门&title=今日热门&subTitle=&page=1",
+	"week":  "https://api.coolapk.com/v6/page/dataList?url=/feed/statList?cacheExpires=300&statType=7days&sortField=detailnum&title=本周热门&title=本周热门&subTitle=&page=1",
+	"topic": "https://api.coolapk.com/v6/page/dataList?url=/topic/tagList?cacheExpires=300&sort=hot&title=热门话题&title=热门话题&subTitle=&page=1",
+}
+
 // CoolapkHandler 酷安处理器
 type CoolapkHandler struct {
 	fetcher *service.Fetcher
+	now     func() time.Time // 签名的时间基准,测试中可替换
 }
 
 // NewCoolapkHandler 创建酷安处理器
 func NewCoolapkHandler(fetcher *service.Fetcher) *CoolapkHandler {
 	return &CoolapkHandler{
 		fetcher: fetcher,
+		now:     time.Now,
 	}
 }
 
@@ -32,51 +54,107 @@ func (h *CoolapkHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *CoolapkHandler) Handle(c *fiber.Ctx) error {
-	// 获取缓存标志
+	// 获取查询参数
+	listType := c.Query("type", "day")
+	if _, ok := coolapkTypeMap[listType]; !ok {
+		listType = "day"
+	}
 	noCache := c.Query("cache") == "false"
 
-	// 获取数据
-	data, err := h.fetchCoolapkHot(c.Context())
+	// 获取数据(今日热门沿用原缓存键)
+	cacheKey := "coolapk"
+	if listType != "day" {
+		cacheKey = fmt.Sprintf("coolapk_%s", listType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchCoolapkHot(ctx, listType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
 		"coolapk",                  // name: 平台调用名称
 		"酷安",                       // title: 平台显示名称
-		"热榜",                       // type: 榜单类型
+		coolapkTypeMap[listType],   // type: 榜单类型
 		"发现酷安平台热门动态",               // description: 平台描述
 		"https://www.coolapk.com/", // link: 官方链接
-		nil,                        // params: 无参数映射
-		data,                       // data: 热榜数据
-		!noCache,                   // fromCache: 是否来自缓存
+		map[string]interface{}{ // params: 参数说明
+			"type": coolapkTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
 // fetchCoolapkHot 从酷安 API 获取数据
-func (h *CoolapkHandler) fetchCoolapkHot(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://api.coolapk.com/v6/page/dataList?url=/feed/statList?cacheExpires=300&statType=day&sortField=detailnum&title=今日热门&title=今日热门&subTitle=&page=1"
+func (h *CoolapkHandler) fetchCoolapkHot(ctx context.Context, listType string) ([]models.HotData, error) {
+	apiURL := coolapkListURLs[listType]
 
 	// 生成酷安特殊请求头(包含签名token)
-	headers := utils.GenCoolapkHeaders()
+	headers := utils.GenCoolapkHeadersAt(h.now())
+
+	// 酷安用 401/403 表示签名失效,需要立即重新签名重试,只把 429 视为风控
+	ctx = httpclient.WithThrottleStatuses(ctx, http.StatusTooManyRequests)
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, headers)
+	resp, err := httpClient.GetWithResponseContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求酷安 API 失败: %w", err)
 	}
 
+	// 签名失效通常是本地时钟与酷安服务器不同步导致的
+	// 用服务器返回的 Date 头校正时间后重新签名,只重试一次
+	if isCoolapkAuthError(resp.StatusCode(), resp.Body()) {
+		serverTime, parseErr := http.ParseTime(resp.Header().Get("Date"))
+		if parseErr != nil {
+			serverTime = h.now()
+		}
+
+		logger.Warn("酷安签名校验失败,使用服务器时间重新签名",
+			zap.Int("status", resp.StatusCode()),
+			zap.Duration("drift", h.now().Sub(serverTime)),
+		)
+
+		resp, err = httpClient.GetWithResponseContext(ctx, apiURL, utils.GenCoolapkHeadersAt(serverTime))
+		if err != nil {
+			return nil, fmt.Errorf("请求酷安 API 失败: %w", err)
+		}
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("HTTP 状态码异常: %d", resp.StatusCode())
+	}
+
 	var apiResp CoolapkAPIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := json.Unmarshal(resp.Body(), &apiResp); err != nil {
 		return nil, fmt.Errorf("解析酷安响应失败: %w", err)
 	}
 
+	if apiResp.Status != 0 {
+		return nil, fmt.Errorf("酷安 API 返回错误(status=%d): %s", apiResp.Status, apiResp.Message)
+	}
+
 	return h.transformData(apiResp.Data), nil
 }
 
+// isCoolapkAuthError 判断响应是否为签名/鉴权失败
+// 酷安在 token 校验失败时会返回 401/403,或在 JSON 中返回非零 status
+func isCoolapkAuthError(statusCode int, body []byte) bool {
+	if statusCode == 401 || statusCode == 403 {
+		return true
+	}
+
+	var apiResp CoolapkAPIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return false
+	}
+	return apiResp.Status != 0 && len(apiResp.Data) == 0
+}
+
 // transformData 转换数据格式
 func (h *CoolapkHandler) transformData(items []CoolapkItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
@@ -97,10 +175,12 @@ func (h *CoolapkHandler) transformData(items []CoolapkItem) []models.HotData {
 			continue
 		}
 
+		// 话题没有动态正文,使用话题简介
 		desc := strings.TrimSpace(item.Message)
-		if len(desc) > 200 {
-			desc = desc[:200] + "..."
+		if desc == "" {
+			desc = strings.TrimSpace(item.Description)
 		}
+		desc = models.TruncateRunes(desc, 200)
 
 		shareURL := strings.TrimSpace(item.ShareURL)
 		if shareURL == "" {
@@ -115,13 +195,24 @@ func (h *CoolapkHandler) transformData(items []CoolapkItem) []models.HotData {
 			}
 		}
 
+		cover := item.TPic
+		if cover == "" {
+			cover = item.Logo
+		}
+
+		// 动态以点赞数作为热度,话题以热度值作为热度
+		hot := int64(item.LikeNum)
+		if hot == 0 {
+			hot = item.HotNum
+		}
+
 		hotData := models.HotData{
 			ID:        strconv.FormatInt(item.ID, 10),
 			Title:     title,
-			Cover:     item.TPic,
+			Cover:     cover,
 			Desc:      desc,
 			Author:    item.Username,
-			Hot:       int64(item.LikeNum),
+			Hot:       hot,
 			Timestamp: item.Dateline * 1000,
 			URL:       shareURL,
 			MobileURL: shareURL,
@@ -135,7 +226,9 @@ func (h *CoolapkHandler) transformData(items []CoolapkItem) []models.HotData {
 
 // CoolapkAPIResponse 酷安 API 响应
 type CoolapkAPIResponse struct {
-	Data []CoolapkItem `json:"data"`
+	Status  int           `json:"status"`  // 错误状态码,成功时不返回(为 0)
+	Message string        `json:"message"` // 错误信息
+	Data    []CoolapkItem `json:"data"`
 }
 
 // CoolapkItem 动态项
@@ -150,4 +243,9 @@ type CoolapkItem struct {
 	URL      string `json:"url"`
 	Dateline int64  `json:"dateline"`
 	LikeNum  int    `json:"likenum"`
+
+	// 话题字段
+	Logo        string `json:"logo"`        // 话题图标
+	Description string `json:"description"` // 话题简介
+	HotNum      int64  `json:"hot_num"`     // 话题热度
 }
@@ -0,0 +1,121 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsCoolapkAuthError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{"401", 401, "", true},
+		{"403", 403, `<html>Forbidden</html>`, true},
+		{"签名错误", 200, `{"status":-2,"message":"请求签名错误","data":[]}`, true},
+		{"正常数据", 200, `{"data":[{"id":1,"title":"标题"}]}`, false},
+		{"非 JSON", 200, `<html></html>`, false},
+		{"有数据时忽略 status", 200, `{"status":1,"data":[{"id":1}]}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCoolapkAuthError(tt.status, []byte(tt.body)); got != tt.want {
+				t.Errorf("isCoolapkAuthError(%d, %s) = %v, want %v", tt.status, tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+// coolapkDayFixture 今日热门接口的响应(节选)
+const coolapkDayFixture = `{"data":[
+	{"id":58123456,"title":"","message":"新系统升级后续航提升明显","ttitle":"","tpic":"https://image.coolapk.com/feed/1.jpg","username":"酷友A","shareUrl":"https://www.coolapk.com/feed/58123456?shareKey=x","url":"/feed/58123456","dateline":1714550400,"likenum":321},
+	{"id":0,"message":"广告位"},
+	{"id":58123457,"message":"  ","username":"酷友B"}
+]}`
+
+func TestFetchCoolapkHotResignsWithServerTime(t *testing.T) {
+	local := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	server := local.Add(-10 * time.Minute)
+
+	var (
+		mu     sync.Mutex
+		tokens []string
+	)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get("X-App-Token"))
+		first := len(tokens) == 1
+		mu.Unlock()
+
+		w.Header().Set("Date", server.Format(http.TimeFormat))
+		if first {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, coolapkDayFixture)
+	}))
+	defer upstream.Close()
+
+	original := coolapkListURLs["day"]
+	coolapkListURLs["day"] = upstream.URL
+	defer func() { coolapkListURLs["day"] = original }()
+
+	h := &CoolapkHandler{fetcher: newTestFetcher(t), now: func() time.Time { return local }}
+	data, err := h.fetchCoolapkHot(context.Background(), "day")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokens) != 2 {
+		t.Fatalf("requests = %d, want 2 (one re-sign retry)", len(tokens))
+	}
+	if !strings.HasSuffix(tokens[0], fmt.Sprintf("0x%x", local.Unix())) {
+		t.Errorf("first token = %q, want signed with local time", tokens[0])
+	}
+	if !strings.HasSuffix(tokens[1], fmt.Sprintf("0x%x", server.Unix())) {
+		t.Errorf("retry token = %q, want signed with server time", tokens[1])
+	}
+
+	if len(data) != 1 {
+		t.Fatalf("len(data) = %d, want 1", len(data))
+	}
+	item := data[0]
+	if item.ID != "58123456" || item.Title != "新系统升级后续航提升明显" || item.Hot != int64(321) ||
+		item.Timestamp != int64(1714550400000) || item.URL != "https://www.coolapk.com/feed/58123456?shareKey=x" {
+		t.Errorf("item = %+v", item)
+	}
+}
+
+func TestFetchCoolapkHotRetriesOnce(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer upstream.Close()
+
+	original := coolapkListURLs["day"]
+	coolapkListURLs["day"] = upstream.URL
+	defer func() { coolapkListURLs["day"] = original }()
+
+	h := &CoolapkHandler{fetcher: newTestFetcher(t), now: time.Now}
+	if _, err := h.fetchCoolapkHot(context.Background(), "day"); err == nil {
+		t.Error("err = nil, want status error")
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
@@ -0,0 +1,98 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	coverageConcurrency = 5                // 统计时的并发抓取数
+	coverageTimeout     = 30 * time.Second // 单个平台的超时时间
+)
+
+// coverageFields 参与统计的字段及其非空判断
+// 热度与时间戳为 0 通常意味着解析失败,同样视为空
+var coverageFields = []struct {
+	name  string
+	empty func(item models.HotData) bool
+}{
+	{"id", func(item models.HotData) bool { return item.ID == "" }},
+	{"title", func(item models.HotData) bool { return item.Title == "" }},
+	{"desc", func(item models.HotData) bool { return item.Desc == "" }},
+	{"cover", func(item models.HotData) bool { return item.Cover == "" }},
+	{"author", func(item models.HotData) bool { return item.Author == "" }},
+	{"hot", func(item models.HotData) bool {
+		value, ok := models.HotValue(item.Hot)
+		return !ok || value == 0
+	}},
+	{"timestamp", func(item models.HotData) bool { return isEmptyValue(item.Timestamp) }},
+	{"url", func(item models.HotData) bool { return item.URL == "" }},
+	{"mobileUrl", func(item models.HotData) bool { return item.MobileURL == "" }},
+}
+
+// CoverageReport 单个平台的字段完整性统计
+type CoverageReport struct {
+	Platform string             `json:"platform"`
+	Success  bool               `json:"success"`
+	Count    int                `json:"count"`
+	Error    string             `json:"error,omitempty"`
+	Coverage map[string]float64 `json:"coverage,omitempty"` // 字段 -> 非空比例(0~1)
+}
+
+// handleCoverage 字段完整性统计处理器
+// 对每个平台抓取一次,统计各字段的非空比例,用于发现静默的解析退化
+// 默认读取缓存以免集中请求上游,?cache=false 时强制重新抓取;需开启管理接口并携带令牌,见 requireAdmin
+func (r *Registry) handleCoverage(c *fiber.Ctx) error {
+	noCache := c.Query("cache") == "false"
+	results := r.probeAll(c.UserContext(), coverageConcurrency, coverageTimeout, noCache)
+
+	reports := make([]CoverageReport, 0, len(results))
+	for _, result := range results {
+		report := CoverageReport{
+			Platform: result.Platform,
+			Success:  result.Success,
+			Count:    result.Count,
+			Error:    result.Error,
+		}
+		if result.Count > 0 {
+			report.Coverage = fieldCoverage(result.Data)
+		}
+		reports = append(reports, report)
+	}
+
+	c.Set("Content-Type", fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.JSON(fiber.Map{
+		"code":      200,
+		"count":     len(reports),
+		"platforms": reports,
+	})
+}
+
+// fieldCoverage 统计各字段的非空比例,保留两位小数
+func fieldCoverage(data []models.HotData) map[string]float64 {
+	coverage := make(map[string]float64, len(coverageFields))
+	for _, field := range coverageFields {
+		filled := 0
+		for _, item := range data {
+			if !field.empty(item) {
+				filled++
+			}
+		}
+		coverage[field.name] = float64(filled*100/len(data)) / 100
+	}
+	return coverage
+}
+
+// isEmptyValue 判断时间戳等动态类型字段是否为空
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	}
+	number, ok := models.HotValue(value)
+	return ok && number == 0
+}
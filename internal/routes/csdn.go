@@ -4,13 +4,58 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"net/url"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
 )
 
+// csdnTypeMap 榜单类型映射: type 参数 -> 榜单名称
+var csdnTypeMap = map[string]string{
+	"all":        "综合",
+	"c":          "C/C++",
+	"java":       "Java",
+	"javascript": "JavaScript",
+	"php":        "PHP",
+	"python":     "Python",
+	"ai":         "人工智能",
+	"blockchain": "区块链",
+	"bigdata":    "大数据",
+	"mobile":     "移动开发",
+	"embedded":   "嵌入式",
+	"devtools":   "开发工具",
+	"algorithm":  "数据结构与算法",
+	"testing":    "测试",
+	"game":       "游戏",
+	"network":    "网络",
+	"ops":        "运维",
+}
+
+// csdnChannelMap 榜单类型对应的 child_channel 参数
+// 与 https://blog.csdn.net/rank/list/content 页面的分类一致,综合榜不带该参数
+var csdnChannelMap = map[string]string{
+	"c":          "c/c++",
+	"java":       "java",
+	"javascript": "javascript",
+	"php":        "php",
+	"python":     "python",
+	"ai":         "人工智能",
+	"blockchain": "区块链",
+	"bigdata":    "大数据",
+	"mobile":     "移动开发",
+	"embedded":   "嵌入式",
+	"devtools":   "开发工具",
+	"algorithm":  "数据结构与算法",
+	"testing":    "测试",
+	"game":       "游戏",
+	"network":    "网络",
+	"ops":        "运维",
+}
+
+// csdnHotRankURL 博文热榜接口
+var csdnHotRankURL = "https://blog.csdn.net/phoenix/web/blog/hot-rank"
+
 // CSDNHandler CSDN处理器
 type CSDNHandler struct {
 	fetcher *service.Fetcher
@@ -30,36 +75,57 @@ func (h *CSDNHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *CSDNHandler) Handle(c *fiber.Ctx) error {
+	// 获取榜单分类
+	rankType := c.Query("type", "all")
+	if _, ok := csdnTypeMap[rankType]; !ok {
+		rankType = "all"
+	}
+
 	// 获取缓存标志
 	noCache := c.Query("cache") == "false"
 
-	// 获取数据
-	data, err := h.fetchCSDNHot(c.Context())
+	// 获取数据(综合榜沿用原缓存键)
+	cacheKey := "csdn"
+	if rankType != "all" {
+		cacheKey = fmt.Sprintf("csdn_%s", rankType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchCSDNHot(ctx, rankType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
+	typeName := "排行榜"
+	if rankType != "all" {
+		typeName = csdnTypeMap[rankType] + "排行榜"
+	}
 	resp := models.SuccessResponse(
 		"csdn",                   // name: 平台调用名称
 		"CSDN",                   // title: 平台显示名称
-		"排行榜",                    // type: 榜单类型
+		typeName,                 // type: 榜单类型
 		"发现CSDN热门博文",             // description: 平台描述
 		"https://blog.csdn.net/", // link: 官方链接
-		nil,                      // params: 无参数映射
-		data,                     // data: 热榜数据
-		!noCache,                 // fromCache: 是否来自缓存
+		map[string]interface{}{ // params: 参数说明
+			"type": csdnTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
-// fetchCSDNHot 从CSDN API 获取数据
-func (h *CSDNHandler) fetchCSDNHot(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://blog.csdn.net/phoenix/web/blog/hot-rank?page=0&pageSize=30"
+// fetchCSDNHot 从CSDN API 获取指定分类的热榜
+func (h *CSDNHandler) fetchCSDNHot(ctx context.Context, rankType string) ([]models.HotData, error) {
+	apiURL := csdnHotRankURL + "?page=0&pageSize=30"
+	if channel, ok := csdnChannelMap[rankType]; ok {
+		apiURL += "&child_channel=" + url.QueryEscape(channel)
+	}
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求CSDN API 失败: %w", err)
 	}
@@ -83,33 +149,9 @@ func (h *CSDNHandler) transformData(items []CSDNItem) []models.HotData {
 			cover = item.PicList[0]
 		}
 
-		// 处理HotRankScore字段(可能是int64或string)
-		var hotRankScore int64
-		switch v := item.HotRankScore.(type) {
-		case float64:
-			hotRankScore = int64(v)
-		case int64:
-			hotRankScore = v
-		case string:
-			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-				hotRankScore = parsed
-			}
-		default:
-			hotRankScore = 0
-		}
-
-		// 处理Period字段(可能是int64或string)
-		var timestamp string
-		switch v := item.Period.(type) {
-		case float64:
-			timestamp = strconv.FormatInt(int64(v), 10)
-		case int64:
-			timestamp = strconv.FormatInt(v, 10)
-		case string:
-			timestamp = v
-		default:
-			timestamp = ""
-		}
+		// 处理HotRankScore、Period字段(可能是int64或string)
+		hotRankScore := models.ToInt64(item.HotRankScore)
+		timestamp := models.ToString(item.Period)
 
 		hotData := models.HotData{
 			ID:        item.ProductID,
@@ -120,6 +162,11 @@ func (h *CSDNHandler) transformData(items []CSDNItem) []models.HotData {
 			URL:       item.ArticleDetailURL,
 			MobileURL: item.ArticleDetailURL,
 			Timestamp: timestamp,
+			Extra: map[string]interface{}{
+				"views":     h.count(item.ViewCount),
+				"comments":  h.count(item.CommentCount),
+				"favorites": h.count(item.FavorCount),
+			},
 		}
 
 		result = append(result, hotData)
@@ -128,6 +175,12 @@ func (h *CSDNHandler) transformData(items []CSDNItem) []models.HotData {
 	return result
 }
 
+// count 将计数字段(可能是数字或字符串)转换为整数
+func (h *CSDNHandler) count(value interface{}) int64 {
+	n, _ := models.HotValue(value)
+	return int64(n)
+}
+
 // CSDNAPIResponse CSDN API 响应
 type CSDNAPIResponse struct {
 	Data []CSDNItem `json:"data"`
@@ -141,5 +194,8 @@ type CSDNItem struct {
 	NickName         string      `json:"nickName"`
 	HotRankScore     interface{} `json:"hotRankScore"` // 可能是int64或string
 	PicList          []string    `json:"picList"`
-	Period           interface{} `json:"period"` // 可能是int64或string
+	Period           interface{} `json:"period"`       // 可能是int64或string
+	ViewCount        interface{} `json:"viewCount"`    // 阅读数,可能是int64或string
+	CommentCount     interface{} `json:"commentCount"` // 评论数,可能是int64或string
+	FavorCount       interface{} `json:"favorCount"`   // 收藏数,可能是int64或string
 }
@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// csdnFixture 博文热榜接口响应(节选),计数字段有数字也有字符串
+const csdnFixture = `{"code":200,"message":"success","traceId":"abc","data":[
+  {"hotRankScore":"18542","pcHotRankScore":"1.8w","loginUserIsFollow":false,"nickName":"程序员小王","avatarUrl":"https://profile-avatar.csdnimg.cn/a.jpg","userName":"wang","articleTitle":"Spring Boot 3 升级踩坑实录","articleDetailUrl":"https://blog.csdn.net/wang/article/details/138312345","commentCount":"56","favorCount":"1,203","viewCount":"2.3万","hotComment":null,"picList":["https://i-blog.csdnimg.cn/direct/cover.png"],"isNew":null,"productId":"138312345","productType":"blog","recommendType":"ali","period":"1714550400"},
+  {"hotRankScore":9000,"nickName":"算法君","articleTitle":"动态规划入门","articleDetailUrl":"https://blog.csdn.net/alg/article/details/138300001","commentCount":12,"favorCount":null,"viewCount":4567,"picList":[],"productId":"138300001","period":1714464000}
+]}`
+
+func TestCSDNTransformData(t *testing.T) {
+	var resp CSDNAPIResponse
+	if err := json.Unmarshal([]byte(csdnFixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &CSDNHandler{}
+	data := h.transformData(resp.Data)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "138312345" || first.Title != "Spring Boot 3 升级踩坑实录" || first.Author != "程序员小王" || first.Hot != int64(18542) {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://i-blog.csdnimg.cn/direct/cover.png" || first.Timestamp != "1714550400" {
+		t.Errorf("Cover = %q, Timestamp = %v", first.Cover, first.Timestamp)
+	}
+	if first.Extra["views"] != int64(23000) || first.Extra["comments"] != int64(56) || first.Extra["favorites"] != int64(1203) {
+		t.Errorf("Extra = %v", first.Extra)
+	}
+
+	// 数字形式的计数与缺失的封面
+	second := data[1]
+	if second.Hot != int64(9000) || second.Cover != "" || second.Extra["views"] != int64(4567) || second.Extra["favorites"] != int64(0) {
+		t.Errorf("data[1] = %+v", second)
+	}
+}
+
+func TestCSDNFetchChannel(t *testing.T) {
+	var channel string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		channel = r.URL.Query().Get("child_channel")
+		fmt.Fprint(w, csdnFixture)
+	}))
+	defer upstream.Close()
+
+	original := csdnHotRankURL
+	csdnHotRankURL = upstream.URL
+	defer func() { csdnHotRankURL = original }()
+
+	h := &CSDNHandler{fetcher: newTestFetcher(t)}
+	tests := []struct {
+		rankType string
+		want     string
+	}{
+		{"all", ""},
+		{"c", "c/c++"},
+		{"ai", "人工智能"},
+	}
+	for _, tt := range tests {
+		if _, err := h.fetchCSDNHot(context.Background(), tt.rankType); err != nil {
+			t.Fatalf("%s: %v", tt.rankType, err)
+		}
+		if channel != tt.want {
+			t.Errorf("%s: child_channel = %q, want %q", tt.rankType, channel, tt.want)
+		}
+	}
+}
@@ -35,13 +35,16 @@ func (h *CTO51Handler) GetPath() string {
 // Handle 处理请求
 func (h *CTO51Handler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetch51CTOHot(c.Context())
+	cacheKey := "51cto"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetch51CTOHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 	resp := models.SuccessResponse(
 		"51cto", "51CTO", "推荐榜", "发现51CTO热门资讯",
-		"https://www.51cto.com/", nil, data, !noCache,
+		"https://www.51cto.com/", nil, data, fromCache,
 	)
 	return c.JSON(resp)
 }
@@ -78,7 +81,7 @@ func (h *CTO51Handler) fetch51CTOHot(ctx context.Context) ([]models.HotData, err
 	)
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求51CTO API 失败: %w", err)
 	}
@@ -97,15 +100,7 @@ func (h *CTO51Handler) transformData(items []CTO51Item) []models.HotData {
 
 	for _, item := range items {
 		// 处理Pubdate字段（可能是int64或string）
-		timestamp := ""
-		switch v := item.Pubdate.(type) {
-		case float64:
-			timestamp = strconv.FormatInt(int64(v), 10)
-		case int64:
-			timestamp = strconv.FormatInt(v, 10)
-		case string:
-			timestamp = v
-		}
+		timestamp := models.ToString(item.Pubdate)
 
 		hotData := models.HotData{
 			ID:        strconv.FormatInt(item.SourceID, 10),
@@ -5,12 +5,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"sync"
 
+	"github.com/dailyhot/api/internal/logger"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
+const (
+	// dgtleMaxPages ?page 允许的最大页数
+	dgtleMaxPages = 5
+	// dgtlePageConcurrency 多页抓取的最大并发数
+	dgtlePageConcurrency = 3
+)
+
+// dgtleNewsURL 资讯列表接口
+var dgtleNewsURL = "https://opser.api.dgtle.com/v2/news/index"
+
 // DgtleHandler 数字尾巴处理器
 type DgtleHandler struct {
 	fetcher *service.Fetcher
@@ -30,10 +43,24 @@ func (h *DgtleHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *DgtleHandler) Handle(c *fiber.Ctx) error {
+	// 瀑布流页数: 抓取第 1 ~ page 页并合并
+	pageParam := c.Query("page", "1")
+	if err := validateParam("page", pageParam, intRange(1, dgtleMaxPages)); err != nil {
+		return paramError(c, err)
+	}
+	pages, _ := strconv.Atoi(pageParam)
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchDgtle(c.Context())
+
+	// 单页沿用原缓存键
+	cacheKey := "dgtle"
+	if pages > 1 {
+		cacheKey = fmt.Sprintf("dgtle_pages_%d", pages)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchPages(ctx, pages)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -42,21 +69,79 @@ func (h *DgtleHandler) Handle(c *fiber.Ctx) error {
 		"热门文章",
 		"数字尾巴热门文章列表",
 		"https://www.dgtle.com",
-		nil,
+		map[string]interface{}{
+			"page": fmt.Sprintf("抓取的页数,1~%d", dgtleMaxPages),
+		},
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
-// fetchDgtle 从数字尾巴 API 获取数据
-func (h *DgtleHandler) fetchDgtle(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://opser.api.dgtle.com/v2/news/index"
+// fetchPages 并发抓取第 1 ~ pages 页并按页序合并
+// 并发数受 dgtlePageConcurrency 限制;失败的页跳过,全部失败时返回第一页的错误
+// 瀑布流翻页期间有新文章时相邻页可能重复,按 ID 去重
+func (h *DgtleHandler) fetchPages(ctx context.Context, pages int) ([]models.HotData, error) {
+	if pages <= 1 {
+		return h.fetchDgtle(ctx, 1)
+	}
+
+	results := make([][]models.HotData, pages)
+	errs := make([]error, pages)
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, dgtlePageConcurrency)
+	for i := 0; i < pages; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i], errs[i] = h.fetchDgtle(ctx, i+1)
+		}(i)
+	}
+	wg.Wait()
+
+	merged := make([]models.HotData, 0)
+	seen := make(map[string]bool)
+	for i, items := range results {
+		if errs[i] != nil {
+			logger.Warn("数字尾巴分页抓取失败,跳过该页",
+				zap.Int("page", i+1),
+				zap.Error(errs[i]),
+			)
+			continue
+		}
+		for _, item := range items {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			merged = append(merged, item)
+		}
+	}
+
+	if len(merged) == 0 {
+		for _, err := range errs {
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return merged, nil
+}
+
+// fetchDgtle 从数字尾巴 API 获取指定页的数据
+func (h *DgtleHandler) fetchDgtle(ctx context.Context, page int) ([]models.HotData, error) {
+	apiURL := dgtleNewsURL
+	if page > 1 {
+		apiURL = fmt.Sprintf("%s?page=%d", apiURL, page)
+	}
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("请求数字尾巴 API 失败: %w", err)
+		return nil, fmt.Errorf("请求数字尾巴 API 失败(第 %d 页): %w", page, err)
 	}
 
 	// 解析 JSON 响应
@@ -0,0 +1,127 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// dgtleItemJSON 资讯列表接口中的单条文章
+func dgtleItemJSON(id int64) string {
+	return fmt.Sprintf(`{"id":%d,"title":"文章 %d","content":"正文摘要","cover":"https://s1.dgtle.com/dgtle_img/news/2024/05/%d.jpeg","from":"数字尾巴","membernum":%d,"created_at":1714528800,"type":4}`, id, id, id, id*10)
+}
+
+// dgtlePagesUpstream 第 1~5 页各 2 条文章,第 2 页的第一条与第 1 页重复,第 3 页返回 502
+// 记录并发抓取的最大页数
+type dgtlePagesUpstream struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (u *dgtlePagesUpstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	u.inFlight++
+	if u.inFlight > u.maxInFlight {
+		u.maxInFlight = u.inFlight
+	}
+	u.mu.Unlock()
+	defer func() {
+		u.mu.Lock()
+		u.inFlight--
+		u.mu.Unlock()
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	page := r.URL.Query().Get("page")
+	switch page {
+	case "", "1":
+		fmt.Fprintf(w, `{"items":[%s,%s]}`, dgtleItemJSON(101), dgtleItemJSON(102))
+	case "2":
+		fmt.Fprintf(w, `{"items":[%s,%s]}`, dgtleItemJSON(102), dgtleItemJSON(201))
+	case "3":
+		w.WriteHeader(http.StatusBadGateway)
+	default:
+		var n int64
+		fmt.Sscan(page, &n)
+		fmt.Fprintf(w, `{"items":[%s,%s]}`, dgtleItemJSON(n*100+1), dgtleItemJSON(n*100+2))
+	}
+}
+
+func newDgtleTestHandler(t *testing.T, upstream http.Handler) *DgtleHandler {
+	t.Helper()
+	server := httptest.NewServer(upstream)
+	t.Cleanup(server.Close)
+
+	original := dgtleNewsURL
+	dgtleNewsURL = server.URL
+	t.Cleanup(func() { dgtleNewsURL = original })
+	return &DgtleHandler{fetcher: newTestFetcher(t)}
+}
+
+func TestDgtleFetchPages(t *testing.T) {
+	upstream := &dgtlePagesUpstream{}
+	h := newDgtleTestHandler(t, upstream)
+
+	data, err := h.fetchPages(context.Background(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 按页序合并,跳过失败的第 3 页,重复的文章只保留一次
+	var ids []string
+	for _, item := range data {
+		ids = append(ids, item.ID)
+	}
+	want := []string{"101", "102", "201", "401", "402", "501", "502"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if upstream.maxInFlight > dgtlePageConcurrency {
+		t.Errorf("maxInFlight = %d, want <= %d", upstream.maxInFlight, dgtlePageConcurrency)
+	}
+
+	first := data[0]
+	if first.Title != "文章 101" || first.Author != "数字尾巴" || first.Hot != int64(1010) || first.Timestamp != "1714528800" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://s1.dgtle.com/dgtle_img/news/2024/05/101.jpeg" || first.URL != "https://www.dgtle.com/news-101-4.html" || first.MobileURL != "https://m.dgtle.com/news-details/101" {
+		t.Errorf("data[0] = %+v", first)
+	}
+}
+
+func TestDgtleFetchPagesAllFailed(t *testing.T) {
+	h := newDgtleTestHandler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	if _, err := h.fetchPages(context.Background(), 3); err == nil {
+		t.Error("err = nil, want error when all pages fail")
+	}
+}
+
+func TestDgtlePageParam(t *testing.T) {
+	h := newDgtleTestHandler(t, &dgtlePagesUpstream{})
+	app := fiber.New()
+	app.Get(h.GetPath(), h.Handle)
+
+	status, result := getJSON(t, app, "/dgtle?page=2")
+	if status != fiber.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	if items, _ := result["data"].([]interface{}); len(items) != 3 {
+		t.Errorf("len(data) = %d, want 3", len(items))
+	}
+
+	for _, page := range []string{"0", "6", "abc"} {
+		if status, _ := getJSON(t, app, "/dgtle?page="+page); status != fiber.StatusBadRequest {
+			t.Errorf("page=%s: status = %d, want 400", page, status)
+		}
+	}
+}
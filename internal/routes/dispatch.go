@@ -0,0 +1,112 @@
+package routes
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// internalRequestHeader 进程内调用的请求头,值为 internalRequestToken
+// 带有该请求头的请求返回规范字段,不展开平台配置的字段映射
+const internalRequestHeader = "X-DailyHot-Internal"
+
+// internalWriteBatchHeader 进程内调用指定缓存写入批次的请求头,值为 Registry.writeBatches 中的批次 ID
+// 只对带有有效凭证的进程内调用生效
+const internalWriteBatchHeader = "X-DailyHot-Write-Batch"
+
+// internalRequestToken 进程内调用的凭证,进程启动时随机生成
+// 外部请求无法得知该值,伪造请求头不会被当作进程内调用
+var internalRequestToken = newInternalRequestToken()
+
+// routeContextKey 路由层写入请求上下文(Locals)的键类型
+type routeContextKey string
+
+// internalCallContextKey 请求上下文中标记进程内调用的键,值为 bool,由平台路由在校验凭证后写入
+const internalCallContextKey routeContextKey = "internal_call"
+
+// newInternalRequestToken 生成随机凭证
+func newInternalRequestToken() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("生成进程内调用凭证失败: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// isInternalRequest 判断请求是否来自 invoke 发起的进程内调用
+func isInternalRequest(c *fiber.Ctx) bool {
+	token := c.Get(internalRequestHeader)
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(internalRequestToken)) == 1
+}
+
+// isInternalCall 读取平台路由写入的进程内调用标记
+func isInternalCall(c *fiber.Ctx) bool {
+	internal, _ := c.Locals(internalCallContextKey).(bool)
+	return internal
+}
+
+// Dispatch 在进程内调用平台路由并返回统一响应
+// 请求会完整经过 Fiber 的中间件与处理器,但不经过网络,供后台刷新等内部任务使用
+// 必须在 RegisterRoutes 之后调用
+func (r *Registry) Dispatch(ctx context.Context, path string) (*models.Response, error) {
+	// 内部调用总是绕过缓存,保证拿到最新数据
+	return r.invoke(ctx, path+"?cache=false", "DailyHotApi/Refresher")
+}
+
+// Current 在进程内调用平台路由并返回统一响应(正常使用缓存)
+// 供 SSE 等需要当前数据、但不应每次都回源的内部任务使用
+func (r *Registry) Current(ctx context.Context, path string) (*models.Response, error) {
+	return r.invoke(ctx, path, "DailyHotApi/SSE")
+}
+
+// invoke 在进程内发起 GET 请求并解析统一响应
+// target 为带查询参数的路径,如 "/bilibili?type=188"
+func (r *Registry) invoke(ctx context.Context, target, userAgent string) (*models.Response, error) {
+	return r.invokeWithHeaders(ctx, target, userAgent, nil)
+}
+
+// invokeWithHeaders 与 invoke 相同,并附带额外的请求头(如缓存写入批次)
+func (r *Registry) invokeWithHeaders(ctx context.Context, target, userAgent string, headers map[string]string) (*models.Response, error) {
+	if r.app == nil {
+		return nil, fmt.Errorf("路由尚未注册到 Fiber 应用")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建内部请求失败: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(internalRequestHeader, internalRequestToken)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	// timeout 为 -1 表示不限制,超时由各平台处理器自身控制
+	res, err := r.app.Test(req, -1)
+	if err != nil {
+		return nil, fmt.Errorf("内部请求 %s 失败: %w", target, err)
+	}
+	defer res.Body.Close()
+
+	// ?cache=only 且缓存未命中
+	if res.StatusCode == http.StatusNoContent {
+		return nil, fmt.Errorf("内部请求 %s 缓存未命中", target)
+	}
+
+	var resp models.Response
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("解析 %s 响应失败: %w", target, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("内部请求 %s 返回状态码 %d: %s", target, res.StatusCode, resp.Message)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// newDispatchTestApp 返回一个按请求是否为进程内调用设置 title 的应用
+func newDispatchTestApp() (*Registry, *fiber.App) {
+	r := NewRegistry(nil)
+	app := fiber.New()
+	r.app = app
+	app.Get("/probe", func(c *fiber.Ctx) error {
+		title := "external"
+		if isInternalRequest(c) {
+			title = "internal"
+		}
+		return c.JSON(models.SuccessResponse("probe", title, "", "", "", nil, nil, false))
+	})
+	return r, app
+}
+
+func TestInvokeMarksInternalRequest(t *testing.T) {
+	r, _ := newDispatchTestApp()
+
+	resp, err := r.invoke(context.Background(), "/probe", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Title != "internal" {
+		t.Errorf("title = %q, want internal", resp.Title)
+	}
+}
+
+func TestForgedInternalHeader(t *testing.T) {
+	_, app := newDispatchTestApp()
+
+	for _, value := range []string{"1", "true", internalRequestToken[:8]} {
+		req := httptest.NewRequest("GET", "/probe", nil)
+		req.Header.Set(internalRequestHeader, value)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp models.Response
+		if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Title != "external" {
+			t.Errorf("header %q: title = %q, want external", value, resp.Title)
+		}
+	}
+}
@@ -7,11 +7,38 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
+// doubanReleaseDatePattern 上映日期片段,如 "2024-02-10(中国大陆)"、"2023-12(美国)"、"2024"
+var doubanReleaseDatePattern = regexp.MustCompile(`^(\d{4})(?:-(\d{1,2}))?(?:-(\d{1,2}))?\s*(?:\(.*\))?$`)
+
+// doubanDurationPattern 片长片段,如 "129分钟"
+var doubanDurationPattern = regexp.MustCompile(`^\d+\s*分钟`)
+
+// doubanRegions 常见制片国家/地区,用于区分主演与导演片段
+var doubanRegions = map[string]bool{
+	"中国大陆": true, "中国香港": true, "中国台湾": true, "中国澳门": true,
+	"美国": true, "英国": true, "日本": true, "韩国": true, "法国": true,
+	"德国": true, "意大利": true, "西班牙": true, "加拿大": true, "澳大利亚": true,
+	"印度": true, "泰国": true, "俄罗斯": true, "新西兰": true, "爱尔兰": true,
+	"比利时": true, "瑞典": true, "丹麦": true, "挪威": true, "芬兰": true,
+	"荷兰": true, "瑞士": true, "奥地利": true, "波兰": true, "捷克": true,
+	"匈牙利": true, "巴西": true, "墨西哥": true, "阿根廷": true, "智利": true,
+	"土耳其": true, "伊朗": true, "以色列": true, "新加坡": true, "马来西亚": true,
+	"印度尼西亚": true, "菲律宾": true, "越南": true, "南非": true, "冰岛": true,
+}
+
+// doubanMovieTypeMap 榜单类型映射: type 参数 -> 榜单名称
+var doubanMovieTypeMap = map[string]string{
+	"chart":      "新片榜",
+	"nowplaying": "正在热映",
+}
+
 // DoubanHandler 豆瓣电影处理器
 type DoubanHandler struct {
 	fetcher *service.Fetcher
@@ -31,25 +58,48 @@ func (h *DoubanHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *DoubanHandler) Handle(c *fiber.Ctx) error {
+	// 获取查询参数: 榜单类型;正在热映按城市区分(城市沿用豆瓣同城的城市 ID)
+	listType := c.Query("type", "chart")
+	if err := validateParam("type", listType, oneOf(doubanMovieTypeMap)); err != nil {
+		return paramError(c, err)
+	}
+	city := c.Query("city", "beijing")
+	if err := validateParam("city", city, oneOf(doubanEventCityMap)); err != nil {
+		return paramError(c, err)
+	}
 	// 获取缓存标志
 	noCache := c.Query("cache") == "false"
 
-	// 获取数据
-	data, err := h.fetchDoubanMovieHot(c.Context())
+	// 获取数据(新片榜沿用原缓存键)
+	cacheKey := "douban-movie"
+	typeName := doubanMovieTypeMap[listType]
+	if listType == "nowplaying" {
+		cacheKey = fmt.Sprintf("douban-movie_nowplaying_%s", city)
+		typeName = fmt.Sprintf("%s · %s", typeName, doubanEventCityMap[city])
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if listType == "nowplaying" {
+			return h.fetchNowPlaying(ctx, city)
+		}
+		return h.fetchDoubanMovieHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
 		"douban-movie",              // name: 平台调用名称
 		"豆瓣电影",                      // title: 平台显示名称
-		"新片榜",                       // type: 榜单类型
+		typeName,                    // type: 榜单类型
 		"发现豆瓣电影热门作品",                // description: 平台描述
 		"https://movie.douban.com/", // link: 官方链接
-		nil,                         // params: 无参数映射
-		data,                        // data: 热榜数据
-		!noCache,                    // fromCache: 是否来自缓存
+		map[string]interface{}{ // params: 参数说明
+			"type": doubanMovieTypeMap,
+			"city": doubanEventCityMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -60,7 +110,7 @@ func (h *DoubanHandler) fetchDoubanMovieHot(ctx context.Context) ([]models.HotDa
 	apiURL := "https://movie.douban.com/chart/"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"User-Agent": "Mozilla/5.0 (iPhone; CPU iPhone OS 15_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.0 Mobile/15E148 Safari/604.1",
 	})
 	if err != nil {
@@ -71,6 +121,98 @@ func (h *DoubanHandler) fetchDoubanMovieHot(ctx context.Context) ([]models.HotDa
 	return h.parseHTML(string(body)), nil
 }
 
+// fetchNowPlaying 获取城市正在热映的电影
+func (h *DoubanHandler) fetchNowPlaying(ctx context.Context, city string) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("https://movie.douban.com/cinema/nowplaying/%s/", city)
+
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Referer":    "https://movie.douban.com/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求豆瓣正在热映失败: %w", err)
+	}
+
+	data, err := h.parseNowPlaying(string(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("豆瓣正在热映没有影片: %w", service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// parseNowPlaying 解析正在热映页面
+// 影片信息都在 #nowplaying li.list-item 的 data-* 属性中,热度取评价人数(data-votecount),
+// 没有评价人数时退回想看人数(data-wish)
+func (h *DoubanHandler) parseNowPlaying(html string) ([]models.HotData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("解析豆瓣正在热映失败: %w", err)
+	}
+
+	result := make([]models.HotData, 0)
+	doc.Find("#nowplaying li.list-item").Each(func(i int, s *goquery.Selection) {
+		id := s.AttrOr("data-subject", s.AttrOr("id", ""))
+		title := strings.TrimSpace(s.AttrOr("data-title", ""))
+		if id == "" || title == "" {
+			return
+		}
+
+		score := strings.TrimSpace(s.AttrOr("data-score", ""))
+		if score == "" || score == "0" {
+			score = "0.0"
+		}
+
+		hot, _ := strconv.ParseInt(s.AttrOr("data-votecount", ""), 10, 64)
+		if hot == 0 {
+			hot, _ = strconv.ParseInt(s.AttrOr("data-wish", ""), 10, 64)
+		}
+
+		cover, _ := s.Find("li.poster img").Attr("src")
+
+		intro := doubanMovieIntro{
+			Directors: splitDoubanNames(s.AttrOr("data-director", "")),
+			Actors:    splitDoubanNames(s.AttrOr("data-actors", "")),
+			Regions:   splitDoubanNames(s.AttrOr("data-region", "")),
+			Duration:  strings.TrimSpace(s.AttrOr("data-duration", "")),
+		}
+
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     fmt.Sprintf("【%s】%s", score, title),
+			Cover:     cover,
+			Desc:      intro.summary(),
+			Hot:       hot,
+			URL:       fmt.Sprintf("https://movie.douban.com/subject/%s/", id),
+			MobileURL: fmt.Sprintf("https://m.douban.com/movie/subject/%s/", id),
+			Extra: map[string]interface{}{
+				"score":     score,
+				"release":   strings.TrimSpace(s.AttrOr("data-release", "")),
+				"directors": intro.Directors,
+				"actors":    intro.Actors,
+				"regions":   intro.Regions,
+				"duration":  intro.Duration,
+			},
+		})
+	})
+
+	return result, nil
+}
+
+// splitDoubanNames 拆分以 " / " 分隔的人名或地区列表
+func splitDoubanNames(raw string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(raw, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // parseHTML 解析 HTML
 func (h *DoubanHandler) parseHTML(html string) []models.HotData {
 	result := make([]models.HotData, 0)
@@ -119,12 +261,12 @@ func (h *DoubanHandler) parseHTML(html string) []models.HotData {
 			cover = imgMatches[1]
 		}
 
-		// 提取描述
-		desc := ""
+		// 提取简介并结构化(上映日期/主演/地区/导演/片长)
+		intro := doubanMovieIntro{}
 		descPattern := regexp.MustCompile(`<p class="pl">([^<]+)</p>`)
 		descMatches := descPattern.FindStringSubmatch(itemHTML)
 		if len(descMatches) > 1 {
-			desc = strings.TrimSpace(descMatches[1])
+			intro = parseDoubanMovieIntro(descMatches[1])
 		}
 
 		// 提取评价人数(作为热度)
@@ -139,10 +281,18 @@ func (h *DoubanHandler) parseHTML(html string) []models.HotData {
 			ID:        id,
 			Title:     fmt.Sprintf("【%s】%s", score, title),
 			Cover:     cover,
-			Desc:      desc,
+			Desc:      intro.summary(),
 			Hot:       hot,
+			Timestamp: intro.timestamp(),
 			URL:       url,
 			MobileURL: fmt.Sprintf("https://m.douban.com/movie/subject/%s/", id),
+			Extra: map[string]interface{}{
+				"release_dates": intro.ReleaseDates,
+				"directors":     intro.Directors,
+				"actors":        intro.Actors,
+				"regions":       intro.Regions,
+				"duration":      intro.Duration,
+			},
 		}
 
 		result = append(result, hotData)
@@ -160,3 +310,109 @@ func (h *DoubanHandler) extractID(url string) string {
 	}
 	return "0"
 }
+
+// doubanMovieIntro 豆瓣新片榜条目简介的结构化结果
+// 原始简介形如 "2024-02-10(中国大陆) / 贾玲 / 雷佳音 / 中国大陆 / 贾玲 / 129分钟 / 剧情 / ..."
+// 依次为: 上映日期、主演、制片国家/地区、导演、片长,片长之后为类型、编剧等其他信息
+type doubanMovieIntro struct {
+	ReleaseDates []string
+	Actors       []string
+	Regions      []string
+	Directors    []string
+	Duration     string
+	Raw          string
+}
+
+// parseDoubanMovieIntro 解析条目简介
+func parseDoubanMovieIntro(raw string) doubanMovieIntro {
+	intro := doubanMovieIntro{
+		ReleaseDates: make([]string, 0),
+		Actors:       make([]string, 0),
+		Regions:      make([]string, 0),
+		Directors:    make([]string, 0),
+		Raw:          strings.TrimSpace(raw),
+	}
+
+	parts := strings.Split(intro.Raw, "/")
+	i := 0
+	next := func() string { return strings.TrimSpace(parts[i]) }
+
+	// 上映日期
+	for ; i < len(parts) && doubanReleaseDatePattern.MatchString(next()); i++ {
+		intro.ReleaseDates = append(intro.ReleaseDates, next())
+	}
+	// 主演: 直到出现国家/地区
+	actors := make([]string, 0)
+	for ; i < len(parts) && !doubanRegions[next()]; i++ {
+		if doubanDurationPattern.MatchString(next()) {
+			break
+		}
+		if next() != "" {
+			actors = append(actors, next())
+		}
+	}
+	if i >= len(parts) || !doubanRegions[next()] {
+		// 没有识别到地区,无法区分主演与导演,其余信息不做结构化
+		return intro
+	}
+	intro.Actors = actors
+	// 国家/地区
+	for ; i < len(parts) && doubanRegions[next()]; i++ {
+		intro.Regions = append(intro.Regions, next())
+	}
+	// 导演: 直到出现片长
+	directors := make([]string, 0)
+	for ; i < len(parts); i++ {
+		if doubanDurationPattern.MatchString(next()) {
+			intro.Directors = directors
+			intro.Duration = next()
+			break
+		}
+		if next() != "" {
+			directors = append(directors, next())
+		}
+	}
+
+	return intro
+}
+
+// timestamp 取首个上映日期作为时间戳,只有年份或年月时归一到当年 1 月 1 日或当月 1 日
+func (d doubanMovieIntro) timestamp() int64 {
+	if len(d.ReleaseDates) == 0 {
+		return 0
+	}
+
+	matches := doubanReleaseDatePattern.FindStringSubmatch(d.ReleaseDates[0])
+	if matches == nil {
+		return 0
+	}
+	month, day := 1, 1
+	if matches[2] != "" {
+		month, _ = strconv.Atoi(matches[2])
+	}
+	if matches[3] != "" {
+		day, _ = strconv.Atoi(matches[3])
+	}
+
+	return timeutil.ParseTime(fmt.Sprintf("%s-%02d-%02d", matches[1], month, day))
+}
+
+// summary 生成简短描述: 导演与主演,无法结构化时退回原始简介
+func (d doubanMovieIntro) summary() string {
+	if len(d.Directors) == 0 && len(d.Actors) == 0 {
+		return d.Raw
+	}
+
+	parts := make([]string, 0, 2)
+	if len(d.Directors) > 0 {
+		parts = append(parts, "导演: "+strings.Join(d.Directors, "、"))
+	}
+	if len(d.Actors) > 0 {
+		actors := d.Actors
+		if len(actors) > 3 {
+			actors = actors[:3]
+		}
+		parts = append(parts, "主演: "+strings.Join(actors, "、"))
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,177 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// doubanEventCityMap 城市映射,键为豆瓣同城的城市 ID
+var doubanEventCityMap = map[string]string{
+	"beijing":   "北京",
+	"shanghai":  "上海",
+	"guangzhou": "广州",
+	"shenzhen":  "深圳",
+	"chengdu":   "成都",
+	"hangzhou":  "杭州",
+	"wuhan":     "武汉",
+	"nanjing":   "南京",
+	"xian":      "西安",
+	"chongqing": "重庆",
+	"tianjin":   "天津",
+	"suzhou":    "苏州",
+}
+
+// 活动的参加/感兴趣人数,如 "123人参加"、"4567人感兴趣"
+var (
+	doubanEventJoinPattern     = regexp.MustCompile(`(\d+)\s*人参加`)
+	doubanEventInterestPattern = regexp.MustCompile(`(\d+)\s*人感兴趣`)
+)
+
+// DoubanEventHandler 豆瓣同城处理器
+type DoubanEventHandler struct {
+	fetcher *service.Fetcher
+}
+
+// NewDoubanEventHandler 创建豆瓣同城处理器
+func NewDoubanEventHandler(fetcher *service.Fetcher) *DoubanEventHandler {
+	return &DoubanEventHandler{
+		fetcher: fetcher,
+	}
+}
+
+// GetPath 获取路由路径
+func (h *DoubanEventHandler) GetPath() string {
+	return "/douban-event"
+}
+
+// Handle 处理请求
+func (h *DoubanEventHandler) Handle(c *fiber.Ctx) error {
+	city := c.Query("city", "beijing")
+	if _, ok := doubanEventCityMap[city]; !ok {
+		city = "beijing"
+	}
+	noCache := c.Query("cache") == "false"
+
+	cacheKey := fmt.Sprintf("douban-event_%s", city)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchDoubanEvent(ctx, city)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	resp := models.SuccessResponse(
+		"douban-event",                     // name: 平台调用名称
+		"豆瓣同城",                             // title: 平台显示名称
+		doubanEventCityMap[city],           // type: 榜单类型
+		"豆瓣同城本周热门活动",                       // description: 平台描述
+		"https://www.douban.com/location/", // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"city": doubanEventCityMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
+	)
+
+	return c.JSON(resp)
+}
+
+// fetchDoubanEvent 抓取指定城市本周的全部活动
+func (h *DoubanEventHandler) fetchDoubanEvent(ctx context.Context, city string) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("https://www.douban.com/location/%s/events/week-all", city)
+
+	body, err := h.fetcher.GetHTTPClient().GetContext(ctx, apiURL, map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Referer":    "https://www.douban.com/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求豆瓣同城失败: %w", err)
+	}
+
+	data := h.parseHTML(string(body))
+	if len(data) == 0 {
+		return nil, fmt.Errorf("豆瓣同城%w", service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// parseHTML 解析活动列表页
+// 以感兴趣人数作为热度
+func (h *DoubanEventHandler) parseHTML(html string) []models.HotData {
+	result := make([]models.HotData, 0)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return result
+	}
+
+	doc.Find(".events-list .list-entry").Each(func(i int, s *goquery.Selection) {
+		link := s.Find(".title a").First()
+		url := link.AttrOr("href", "")
+		title := strings.TrimSpace(link.AttrOr("title", link.Text()))
+		if url == "" || title == "" {
+			return
+		}
+
+		// 封面图懒加载,真实地址在 data-lazy 中
+		img := s.Find(".pic img").First()
+		cover := img.AttrOr("data-lazy", img.AttrOr("src", ""))
+
+		eventTime := h.metaText(s.Find(".event-time"))
+		location := h.metaText(s.Find(".event-meta li").FilterFunction(func(_ int, li *goquery.Selection) bool {
+			return strings.Contains(li.Find(".event-label").Text(), "地点")
+		}))
+		fee := h.metaText(s.Find(".event-meta .fee"))
+
+		counts := s.Find(".counts").Text()
+		joined := h.matchCount(doubanEventJoinPattern, counts)
+		interested := h.matchCount(doubanEventInterestPattern, counts)
+
+		desc := strings.Trim(eventTime+" · "+location, " ·")
+
+		id := getNumbersFromURL(url)
+		result = append(result, models.HotData{
+			ID:        strconv.FormatInt(id, 10),
+			Title:     title,
+			Desc:      desc,
+			Cover:     cover,
+			Hot:       interested,
+			URL:       url,
+			MobileURL: fmt.Sprintf("https://m.douban.com/event/%d/", id),
+			Extra: map[string]interface{}{
+				"time":       eventTime,
+				"location":   location,
+				"fee":        fee,
+				"joined":     joined,
+				"interested": interested,
+			},
+		})
+	})
+
+	return result
+}
+
+// metaText 提取活动信息行的文本,去掉 "时间:"、"地点:" 等标签并合并空白
+func (h *DoubanEventHandler) metaText(s *goquery.Selection) string {
+	s = s.First().Clone()
+	s.Find(".event-label").Remove()
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
+
+// matchCount 用正则从文本中提取人数
+func (h *DoubanEventHandler) matchCount(pattern *regexp.Regexp, text string) int64 {
+	matches := pattern.FindStringSubmatch(text)
+	if len(matches) < 2 {
+		return 0
+	}
+	count, _ := strconv.ParseInt(matches[1], 10, 64)
+	return count
+}
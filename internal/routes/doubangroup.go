@@ -34,9 +34,12 @@ func (h *DoubanGroupHandler) GetPath() string {
 // Handle 处理请求
 func (h *DoubanGroupHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchDoubanGroup(c.Context())
+	cacheKey := "douban-group"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchDoubanGroup(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -47,7 +50,7 @@ func (h *DoubanGroupHandler) Handle(c *fiber.Ctx) error {
 		"https://www.douban.com/group/explore",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -57,7 +60,7 @@ func (h *DoubanGroupHandler) fetchDoubanGroup(ctx context.Context) ([]models.Hot
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求豆瓣失败: %w", err)
 	}
@@ -36,6 +36,20 @@ const (
 
 var errPassportCookieNotFound = errors.New("passport_csrf_token not found")
 
+const (
+	// douyinChallengeListURL 挑战榜,与热点榜同一接口,通过 board_sub_type 区分
+	douyinChallengeListURL = douyinHotListURL + "&board_type=2&board_sub_type=hotspot_challenge"
+	// douyinMusicListURL 音乐榜
+	douyinMusicListURL = "https://www.iesdouyin.com/web/api/v2/hotsearch/billboard/music/"
+)
+
+// douyinTypeMap 榜单类型映射
+var douyinTypeMap = map[string]string{
+	"hot":       "热点榜",
+	"music":     "音乐榜",
+	"challenge": "挑战榜",
+}
+
 // GetPath 获取路由路径
 func (h *DouyinHandler) GetPath() string {
 	return "/douyin"
@@ -44,24 +58,40 @@ func (h *DouyinHandler) GetPath() string {
 // Handle 处理请求
 func (h *DouyinHandler) Handle(c *fiber.Ctx) error {
 	// 获取查询参数
+	listType := c.Query("type", "hot")
+	if _, ok := douyinTypeMap[listType]; !ok {
+		listType = "hot"
+	}
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchDouyinHot(c.Context())
+	cacheKey := fmt.Sprintf("douyin_%s", listType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		switch listType {
+		case "music":
+			return h.fetchDouyinMusic(ctx)
+		case "challenge":
+			return h.fetchDouyinWordList(ctx, douyinChallengeListURL)
+		default:
+			return h.fetchDouyinHot(ctx)
+		}
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
 		"douyin",                  // name: 平台调用名称
 		"抖音",                      // title: 平台显示名称
-		"热点榜",                     // type: 榜单类型
+		douyinTypeMap[listType],   // type: 榜单类型
 		"发现最新最热的抖音内容",             // description: 平台描述
 		"https://www.douyin.com/", // link: 官方链接
-		nil,                       // params: 无特殊参数映射
-		data,                      // data: 热榜数据
-		!noCache,                  // fromCache: 是否来自缓存
+		map[string]interface{}{ // params: 参数说明
+			"type": douyinTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -69,6 +99,12 @@ func (h *DouyinHandler) Handle(c *fiber.Ctx) error {
 
 // fetchDouyinHot 从抖音 API 获取热点数据
 func (h *DouyinHandler) fetchDouyinHot(ctx context.Context) ([]models.HotData, error) {
+	return h.fetchDouyinWordList(ctx, douyinHotListURL)
+}
+
+// fetchDouyinWordList 获取热搜词类榜单(热点榜、挑战榜)
+// 这些榜单共用同一接口与 word_list 数据结构
+func (h *DouyinHandler) fetchDouyinWordList(ctx context.Context, listURL string) ([]models.HotData, error) {
 	// 1. 先获取临时 Cookie
 	cookieHeader, err := h.getDouyinCookie(ctx)
 	if err != nil {
@@ -87,12 +123,12 @@ func (h *DouyinHandler) fetchDouyinHot(ctx context.Context) ([]models.HotData, e
 		headers["Cookie"] = cookieHeader
 	}
 
-	body, err := httpClient.Get(douyinHotListURL, headers)
+	body, err := httpClient.GetContext(ctx, listURL, headers)
 	if err != nil {
 		if cookieHeader != "" {
 			logger.Warn("携带 Cookie 请求抖音失败, 将尝试不带 Cookie", zap.Error(err))
 			delete(headers, "Cookie")
-			body, err = httpClient.Get(douyinHotListURL, headers)
+			body, err = httpClient.GetContext(ctx, listURL, headers)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("请求抖音 API 失败: %w", err)
@@ -109,6 +145,25 @@ func (h *DouyinHandler) fetchDouyinHot(ctx context.Context) ([]models.HotData, e
 	return h.transformData(apiResp.Data.WordList), nil
 }
 
+// fetchDouyinMusic 获取音乐榜
+func (h *DouyinHandler) fetchDouyinMusic(ctx context.Context) ([]models.HotData, error) {
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, douyinMusicListURL, map[string]string{
+		"Referer":         "https://www.iesdouyin.com/",
+		"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求抖音音乐榜失败: %w", err)
+	}
+
+	var apiResp DouyinMusicResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析抖音音乐榜响应失败: %w", err)
+	}
+
+	return h.transformMusicData(apiResp.MusicList), nil
+}
+
 // getDouyinCookie 获取抖音临时 Cookie
 // 对标 TypeScript 版本的 getDyCookies() 函数，确保兼容性
 func (h *DouyinHandler) getDouyinCookie(ctx context.Context) (string, error) {
@@ -123,7 +178,7 @@ func (h *DouyinHandler) getDouyinCookie(ctx context.Context) (string, error) {
 	}
 
 	// 第一次尝试直接获取 passport_csrf_token
-	if tokenHeader, setCookies, err := h.requestPassportCookie(httpClient, baseHeaders, cookies); err == nil {
+	if tokenHeader, setCookies, err := h.requestPassportCookie(ctx, httpClient, baseHeaders, cookies); err == nil {
 		return tokenHeader, nil
 	} else if !errors.Is(err, errPassportCookieNotFound) {
 		return "", err
@@ -134,11 +189,11 @@ func (h *DouyinHandler) getDouyinCookie(ctx context.Context) (string, error) {
 	}
 
 	// 兜底: 访问主页尝试拿到 ttwid 等基础 Cookie 后再请求一次
-	if _, homeErr := h.prefetchDouyinHome(httpClient, cookies); homeErr != nil {
+	if _, homeErr := h.prefetchDouyinHome(ctx, httpClient, cookies); homeErr != nil {
 		logger.Warn("预热抖音主页 Cookie 失败, 将继续尝试", zap.Error(homeErr))
 	}
 
-	tokenHeader, setCookies, err := h.requestPassportCookie(httpClient, baseHeaders, cookies)
+	tokenHeader, setCookies, err := h.requestPassportCookie(ctx, httpClient, baseHeaders, cookies)
 	if err == nil {
 		return tokenHeader, nil
 	}
@@ -156,7 +211,7 @@ func (h *DouyinHandler) getDouyinCookie(ctx context.Context) (string, error) {
 }
 
 // requestPassportCookie 请求登录策略接口,尝试获取 passport_csrf_token
-func (h *DouyinHandler) requestPassportCookie(client *httpclient.Client, baseHeaders map[string]string, cookies map[string]string) (string, []string, error) {
+func (h *DouyinHandler) requestPassportCookie(ctx context.Context, client *httpclient.Client, baseHeaders map[string]string, cookies map[string]string) (string, []string, error) {
 	headers := make(map[string]string, len(baseHeaders)+1)
 	for k, v := range baseHeaders {
 		headers[k] = v
@@ -165,7 +220,7 @@ func (h *DouyinHandler) requestPassportCookie(client *httpclient.Client, baseHea
 		headers["Cookie"] = buildCookieHeader(cookies)
 	}
 
-	resp, err := client.GetWithResponse(douyinCookieURL, headers)
+	resp, err := client.GetWithResponseContext(ctx, douyinCookieURL, headers)
 	if err != nil {
 		return "", nil, fmt.Errorf("发起 Cookie 请求失败: %w", err)
 	}
@@ -180,7 +235,7 @@ func (h *DouyinHandler) requestPassportCookie(client *httpclient.Client, baseHea
 }
 
 // prefetchDouyinHome 访问抖音首页,获取 ttwid 等基础 Cookie
-func (h *DouyinHandler) prefetchDouyinHome(client *httpclient.Client, cookies map[string]string) ([]string, error) {
+func (h *DouyinHandler) prefetchDouyinHome(ctx context.Context, client *httpclient.Client, cookies map[string]string) ([]string, error) {
 	headers := map[string]string{
 		"Referer":         douyinBaseURL,
 		"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
@@ -190,7 +245,7 @@ func (h *DouyinHandler) prefetchDouyinHome(client *httpclient.Client, cookies ma
 		headers["Cookie"] = buildCookieHeader(cookies)
 	}
 
-	resp, err := client.GetWithResponse(douyinBaseURL, headers)
+	resp, err := client.GetWithResponseContext(ctx, douyinBaseURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求抖音主页失败: %w", err)
 	}
@@ -310,6 +365,36 @@ func (h *DouyinHandler) transformData(items []DouyinItem) []models.HotData {
 	return result
 }
 
+// transformMusicData 转换音乐榜数据格式
+func (h *DouyinHandler) transformMusicData(items []DouyinMusicItem) []models.HotData {
+	result := make([]models.HotData, 0, len(items))
+
+	for _, item := range items {
+		music := item.MusicInfo
+		id := music.IDStr
+		if id == "" {
+			id = fmt.Sprintf("%d", music.ID)
+		}
+
+		cover := ""
+		if len(music.CoverLarge.URLList) > 0 {
+			cover = music.CoverLarge.URLList[0]
+		}
+
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     music.Title,
+			Author:    music.Author,
+			Cover:     cover,
+			Hot:       item.HotValue,
+			URL:       fmt.Sprintf("https://www.douyin.com/music/%s", id),
+			MobileURL: fmt.Sprintf("https://www.iesdouyin.com/share/music/%s", id),
+		})
+	}
+
+	return result
+}
+
 // DouyinAPIResponse 抖音 API 响应
 type DouyinAPIResponse struct {
 	Data DouyinData `json:"data"`
@@ -327,3 +412,28 @@ type DouyinItem struct {
 	HotValue   int64  `json:"hot_value"`   // 热度值
 	EventTime  int64  `json:"event_time"`  // 时间戳
 }
+
+// DouyinMusicResponse 抖音音乐榜响应
+type DouyinMusicResponse struct {
+	MusicList []DouyinMusicItem `json:"music_list"`
+}
+
+// DouyinMusicItem 音乐榜单项
+type DouyinMusicItem struct {
+	HotValue  int64           `json:"hot_value"`  // 热度值
+	MusicInfo DouyinMusicInfo `json:"music_info"` // 音乐信息
+}
+
+// DouyinMusicInfo 音乐信息
+type DouyinMusicInfo struct {
+	ID         int64            `json:"id"`          // 音乐 ID
+	IDStr      string           `json:"id_str"`      // 字符串形式的音乐 ID
+	Title      string           `json:"title"`       // 歌名
+	Author     string           `json:"author"`      // 歌手
+	CoverLarge DouyinImageGroup `json:"cover_large"` // 封面
+}
+
+// DouyinImageGroup 图片地址列表
+type DouyinImageGroup struct {
+	URLList []string `json:"url_list"`
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -29,11 +32,40 @@ func (h *EarthquakeHandler) GetPath() string {
 }
 
 // Handle 处理请求
+// 支持 ?minMag=4.0 只返回达到指定震级的地震,?days=3 只返回近 N 天内的地震
+// 缓存保存全量数据,过滤在响应层完成
 func (h *EarthquakeHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchEarthquake(c.Context())
+
+	// 解析过滤参数
+	minMag := 0.0
+	if v := c.Query("minMag"); v != "" {
+		if err := validateParam("minMag", v, nonNegativeFloat); err != nil {
+			return paramError(c, err)
+		}
+		minMag, _ = strconv.ParseFloat(v, 64)
+	}
+
+	days := 0
+	if v := c.Query("days"); v != "" {
+		if err := validateParam("days", v, positiveInt); err != nil {
+			return paramError(c, err)
+		}
+		days, _ = strconv.Atoi(v)
+	}
+
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), "earthquake_speedsearch", 5*time.Minute, noCache, h.fetchEarthquake)
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
+	}
+
+	// 构建params
+	var params map[string]interface{}
+	if minMag > 0 || days > 0 {
+		params = map[string]interface{}{
+			"minMag": minMag,
+			"days":   days,
+		}
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -42,19 +74,51 @@ func (h *EarthquakeHandler) Handle(c *fiber.Ctx) error {
 		"地震速报",
 		"中国地震台地震速报列表",
 		"https://news.ceic.ac.cn/speedsearch.html",
-		nil,
-		data,
-		!noCache,
+		params,
+		h.filterData(data, minMag, days),
+		fromCache,
 	))
 }
 
+// filterData 按震级与时间范围过滤地震数据
+// minMag 为 0 表示不限震级,days 为 0 表示不限时间
+func (h *EarthquakeHandler) filterData(items []models.HotData, minMag float64, days int) []models.HotData {
+	if minMag <= 0 && days <= 0 {
+		return items
+	}
+
+	var since int64
+	if days > 0 {
+		since = time.Now().AddDate(0, 0, -days).UnixMilli()
+	}
+
+	result := make([]models.HotData, 0, len(items))
+	for _, item := range items {
+		// Hot 为震级(缓存反序列化后同样是 float64)
+		if minMag > 0 {
+			mag, ok := item.Hot.(float64)
+			if !ok || mag < minMag {
+				continue
+			}
+		}
+
+		if days > 0 && timeutil.ParseTime(item.Timestamp) < since {
+			continue
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
 // fetchEarthquake 从中国地震台网站获取数据
 func (h *EarthquakeHandler) fetchEarthquake(ctx context.Context) ([]models.HotData, error) {
 	apiURL := "https://news.ceic.ac.cn/speedsearch.html"
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求中国地震台失败: %w", err)
 	}
@@ -82,15 +146,8 @@ func (h *EarthquakeHandler) transformData(items []EarthquakeItem) []models.HotDa
 
 	for _, item := range items {
 		// 处理EpiDepth字段(可能是number或string)
-		var depth string
-		switch v := item.EpiDepth.(type) {
-		case float64:
-			depth = fmt.Sprintf("%.0f", v)
-		case int64:
-			depth = fmt.Sprintf("%d", v)
-		case string:
-			depth = v
-		default:
+		depth := models.ToString(item.EpiDepth)
+		if depth == "" {
 			depth = "0"
 		}
 
@@ -98,10 +155,14 @@ func (h *EarthquakeHandler) transformData(items []EarthquakeItem) []models.HotDa
 		desc := fmt.Sprintf("发震时刻(UTC+8)：%s\n参考位置：%s\n震级(M)：%s\n纬度(°)：%s\n经度(°)：%s\n深度(千米)：%s\n录入时间：%s",
 			item.OTime, item.LocationC, item.M, item.EpiLat, item.EpiLon, depth, item.SaveTime)
 
+		// 震级作为热度值,便于排序和过滤
+		magnitude, _ := strconv.ParseFloat(item.M, 64)
+
 		hotData := models.HotData{
 			ID:        item.NewDID,
 			Title:     fmt.Sprintf("%s发生%s级地震", item.LocationC, item.M),
 			Desc:      desc,
+			Hot:       magnitude,
 			Timestamp: item.OTime,
 			URL:       fmt.Sprintf("https://news.ceic.ac.cn/%s.html", item.NewDID),
 			MobileURL: fmt.Sprintf("https://news.ceic.ac.cn/%s.html", item.NewDID),
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestEarthquakeInvalidParams(t *testing.T) {
+	h := NewEarthquakeHandler(newTestFetcher(t))
+	app := fiber.New()
+	app.Get(h.GetPath(), h.Handle)
+
+	for _, query := range []string{"minMag=abc", "minMag=-1", "days=0", "days=1.5"} {
+		status, body := getJSON(t, app, "/earthquake?"+query)
+		if status != fiber.StatusBadRequest || body["type"] != models.ErrorTypeInvalidParam {
+			t.Errorf("%s: status = %d, type = %v, want 400 %s", query, status, body["type"], models.ErrorTypeInvalidParam)
+		}
+	}
+}
@@ -33,9 +33,12 @@ func (h *EconomistHandler) GetPath() string {
 func (h *EconomistHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
-	data, err := h.fetchEconomist(c.Context())
+	cacheKey := "economist"
+	data, fromCache, err := h.fetcher.FetchWithFallback(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, h.sources(), func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchEconomist(ctx)
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	resp := models.SuccessResponse(
@@ -46,7 +49,7 @@ func (h *EconomistHandler) Handle(c *fiber.Ctx) error {
 		"https://www.economist.com/latest",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -60,11 +63,24 @@ func (h *EconomistHandler) fetchEconomist(ctx context.Context) ([]models.HotData
 		"Accept-Language": "en-US,en;q=0.9",
 	}
 
-	body, err := httpClient.Get(economistFeedURL, headers)
+	body, err := httpClient.GetContext(ctx, economistFeedURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求 The Economist feed 失败: %w", err)
 	}
 
+	return h.parseEconomist(body)
+}
+
+// sources 备用源解析策略: feed2json 转换结果按主源格式解析,其余地址按订阅源解析(如官方 RSS)
+func (h *EconomistHandler) sources() service.SourceParsers {
+	return service.SourceParsers{
+		"feed2json.org": h.parseEconomist,
+		"*":             parseFeedSource,
+	}
+}
+
+// parseEconomist 解析 feed2json 转换后的 JSON Feed
+func (h *EconomistHandler) parseEconomist(body []byte) ([]models.HotData, error) {
 	var feed economistFeedResponse
 	if err := json.Unmarshal(body, &feed); err != nil {
 		return nil, fmt.Errorf("解析 The Economist feed 失败: %w", err)
@@ -2,17 +2,35 @@ package routes
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
-	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
-const engadgetFeedURL = "https://feed2json.org/convert?url=https://www.engadget.com/rss.xml"
+// engadgetRegionMap 站点映射: region 参数 -> 站点名称
+var engadgetRegionMap = map[string]string{
+	"global": "Engadget",
+	"cn":     "Engadget 中文版",
+}
+
+// engadgetSiteURLs 各站点首页,分类 RSS 为 <首页>/<分类>/rss.xml
+var engadgetSiteURLs = map[string]string{
+	"global": "https://www.engadget.com",
+	"cn":     "https://cn.engadget.com",
+}
+
+// engadgetCategoryMap 分类映射: category 参数 -> 分类名称(仅全球站)
+var engadgetCategoryMap = map[string]string{
+	"gaming":        "Gaming",
+	"entertainment": "Entertainment",
+	"science":       "Science",
+	"computing":     "Computing",
+	"mobile":        "Mobile",
+	"ai":            "AI",
+	"reviews":       "Reviews",
+}
 
 // EngadgetHandler Engadget 科技快讯处理器
 type EngadgetHandler struct {
@@ -31,116 +49,75 @@ func (h *EngadgetHandler) GetPath() string {
 
 // Handle 入口
 func (h *EngadgetHandler) Handle(c *fiber.Ctx) error {
+	region := c.Query("region", "global")
+	if _, ok := engadgetRegionMap[region]; !ok {
+		region = "global"
+	}
+	// 中文版没有分类订阅源,忽略 category
+	category := c.Query("category")
+	if _, ok := engadgetCategoryMap[category]; !ok || region != "global" {
+		category = ""
+	}
 	noCache := c.Query("cache") == "false"
 
-	data, err := h.fetchEngadget(c.Context())
+	// 默认(全球站全部文章)沿用原缓存键
+	cacheKey := "engadget"
+	if region != "global" || category != "" {
+		cacheKey = fmt.Sprintf("engadget_%s_%s", region, category)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchEngadget(ctx, region, category)
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
+	}
+
+	typeName := "Top Stories"
+	if category != "" {
+		typeName = engadgetCategoryMap[category]
 	}
 
 	resp := models.SuccessResponse(
 		"engadget",
-		"Engadget",
-		"Top Stories",
+		engadgetRegionMap[region],
+		typeName,
 		"Engadget 每日最新科技与数码资讯",
-		"https://www.engadget.com/",
-		nil,
+		engadgetSiteURLs[region]+"/",
+		map[string]interface{}{
+			"region":   engadgetRegionMap,
+			"category": engadgetCategoryMap,
+		},
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
 }
 
-func (h *EngadgetHandler) fetchEngadget(ctx context.Context) ([]models.HotData, error) {
-	httpClient := h.fetcher.GetHTTPClient()
-
-	headers := map[string]string{
-		"Accept":          "application/json",
-		"Accept-Language": "en-US,en;q=0.9",
+// fetchEngadget 抓取站点或分类的原生 RSS
+func (h *EngadgetHandler) fetchEngadget(ctx context.Context, region, category string) ([]models.HotData, error) {
+	feedURL := engadgetSiteURLs[region] + "/rss.xml"
+	if category != "" {
+		feedURL = fmt.Sprintf("%s/%s/rss.xml", engadgetSiteURLs[region], category)
 	}
 
-	body, err := httpClient.Get(engadgetFeedURL, headers)
+	parser := NewFeedParser(h.fetcher.GetHTTPClient())
+	feed, err := parser.Fetch(ctx, feedURL, map[string]string{
+		"User-Agent":      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Accept":          "application/rss+xml, application/xml, text/xml",
+		"Accept-Language": "en-US,en;q=0.9,zh-CN;q=0.8",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("请求 Engadget feed 失败: %w", err)
-	}
-
-	var feed engadgetFeedResponse
-	if err := json.Unmarshal(body, &feed); err != nil {
-		return nil, fmt.Errorf("解析 Engadget feed 失败: %w", err)
+		return nil, fmt.Errorf("获取 Engadget RSS 失败: %w", err)
 	}
 
-	return transformEngadgetItems(feed.Items), nil
+	return h.transformData(parser.ToHotData(feed.Items)), nil
 }
 
-func transformEngadgetItems(items []engadgetFeedItem) []models.HotData {
-	result := make([]models.HotData, 0, len(items))
-
-	for idx, item := range items {
-		title := strings.TrimSpace(item.Title)
-		link := strings.TrimSpace(item.URL)
-		if title == "" || link == "" {
-			continue
-		}
-
-		desc := item.ContentText
-		if desc == "" {
-			desc = item.Summary
-		}
-		desc = strings.TrimSpace(desc)
-
-		id := item.GUID
-		if id == "" {
-			id = fmt.Sprintf("%d", idx)
-		}
-
-		author := strings.TrimSpace(item.Author.Name)
-		timestamp := timeutil.ParseTime(item.DatePublished)
-
-		result = append(result, models.HotData{
-			ID:        id,
-			Title:     title,
-			Desc:      desc,
-			Author:    author,
-			Timestamp: timestamp,
-			URL:       link,
-			MobileURL: link,
-			Cover:     extractFirstAttachment(item.Attachments),
-		})
+// transformData 清理 RSS 描述中的 HTML 标签
+func (h *EngadgetHandler) transformData(items []models.HotData) []models.HotData {
+	for i := range items {
+		items[i].Desc = stripHTMLTags(items[i].Desc)
 	}
-
-	return result
-}
-
-func extractFirstAttachment(attachments []engadgetAttachment) string {
-	for _, att := range attachments {
-		if att.URL != "" {
-			return att.URL
-		}
-	}
-	return ""
-}
-
-type engadgetFeedResponse struct {
-	Items []engadgetFeedItem `json:"items"`
-}
-
-type engadgetFeedItem struct {
-	GUID          string               `json:"guid"`
-	URL           string               `json:"url"`
-	Title         string               `json:"title"`
-	Summary       string               `json:"summary"`
-	ContentText   string               `json:"content_text"`
-	ContentHTML   string               `json:"content_html"`
-	DatePublished string               `json:"date_published"`
-	Author        engadgetFeedAuthor   `json:"author"`
-	Attachments   []engadgetAttachment `json:"attachments"`
-}
-
-type engadgetFeedAuthor struct {
-	Name string `json:"name"`
-}
-
-type engadgetAttachment struct {
-	URL string `json:"url"`
+	return items
 }
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// engadgetFixture Engadget 原生 RSS(节选)
+const engadgetFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+  <title>Engadget is a web magazine with obsessive daily coverage of everything new in gadgets and consumer electronics</title>
+  <link>https://www.engadget.com/</link>
+  <item>
+    <title><![CDATA[The best wireless earbuds for 2024]]></title>
+    <link>https://www.engadget.com/audio/headphones/best-wireless-earbuds-120058222.html?src=rss</link>
+    <guid isPermaLink="false">5a9c3d4e-1111-2222-3333-444455556666</guid>
+    <dc:creator><![CDATA[Billy Steele]]></dc:creator>
+    <pubDate>Wed, 01 May 2024 12:00:00 +0000</pubDate>
+    <description><![CDATA[<p>Our <a href="https://www.engadget.com/">top picks</a> after months of testing.</p>]]></description>
+    <media:content url="https://s.yimg.com/os/creatr-uploaded-images/2024-05/earbuds.jpg" height="1200" width="1800"/>
+  </item>
+</channel>
+</rss>`
+
+func TestEngadgetRegionAndCategory(t *testing.T) {
+	var requested string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.Host + r.URL.Path
+		fmt.Fprint(w, engadgetFixture)
+	}))
+	defer upstream.Close()
+
+	original := engadgetSiteURLs
+	engadgetSiteURLs = map[string]string{
+		"global": upstream.URL + "/global",
+		"cn":     upstream.URL + "/cn",
+	}
+	defer func() { engadgetSiteURLs = original }()
+
+	h := &EngadgetHandler{fetcher: newTestFetcher(t)}
+	app := fiber.New()
+	app.Get(h.GetPath(), h.Handle)
+
+	host := upstream.Listener.Addr().String()
+	tests := []struct {
+		query     string
+		wantPath  string
+		wantTitle string
+		wantType  string
+	}{
+		{"", "/global/rss.xml", "Engadget", "Top Stories"},
+		{"?category=gaming", "/global/gaming/rss.xml", "Engadget", "Gaming"},
+		{"?region=cn", "/cn/rss.xml", "Engadget 中文版", "Top Stories"},
+		// 中文版没有分类,未知的站点与分类回退到默认
+		{"?region=cn&category=gaming", "/cn/rss.xml", "Engadget 中文版", "Top Stories"},
+		{"?region=jp&category=unknown", "/global/rss.xml", "Engadget", "Top Stories"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			requested = ""
+			target := "/engadget?cache=false"
+			if tt.query != "" {
+				target += "&" + tt.query[1:]
+			}
+			status, result := getJSON(t, app, target)
+			if status != fiber.StatusOK {
+				t.Fatalf("status = %d", status)
+			}
+			if requested != host+tt.wantPath {
+				t.Errorf("requested %q, want %q", requested, host+tt.wantPath)
+			}
+			if result["title"] != tt.wantTitle || result["type"] != tt.wantType {
+				t.Errorf("title = %v, type = %v", result["title"], result["type"])
+			}
+			items, _ := result["data"].([]interface{})
+			if len(items) != 1 {
+				t.Fatalf("len(data) = %d, want 1", len(items))
+			}
+			item, _ := items[0].(map[string]interface{})
+			if item["desc"] != "Our top picks after months of testing." || item["author"] != "Billy Steele" {
+				t.Errorf("item = %v", item)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"regexp"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// traceIDHeader 追踪 ID 响应头;请求携带 X-Request-ID 时沿用调用方的 ID
+const traceIDHeader = "X-Trace-Id"
+
+// traceIDPattern 允许沿用的调用方请求 ID
+var traceIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`)
+
+// fetchError 返回平台抓取失败的错误响应
+// 按错误链归类错误类型,并附带平台名与追踪 ID,同时以相同的 trace_id 记录日志便于定位
+func fetchError(c *fiber.Ctx, err error) error {
+	platform, _ := c.Locals(service.PlatformContextKey).(string)
+	errType := service.ClassifyError(err)
+	traceID := requestTraceID(c)
+	status := fetchErrorStatus(err)
+
+	logger.Warn("平台抓取失败",
+		zap.String("platform", platform),
+		zap.String("type", errType),
+		zap.Int("status", status),
+		zap.String("trace_id", traceID),
+		zap.Error(err),
+	)
+
+	return c.Status(status).JSON(models.ErrorResponseObj(status, err.Error()).
+		WithType(errType).
+		WithPlatform(platform).
+		WithTraceID(traceID))
+}
+
+// fetchErrorStatus 按错误链确定响应状态码
+// 超时(含抓取超时)返回 504,其余返回 500
+// 抓取在独立的上下文中进行(结果要写入缓存供后续请求复用),不随客户端断开而取消,因此没有 499 的情况
+func fetchErrorStatus(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fiber.StatusGatewayTimeout
+	}
+	return fiber.StatusInternalServerError
+}
+
+// requestTraceID 获取当前请求的追踪 ID 并写入响应头
+// 同一请求多次调用返回相同的 ID
+func requestTraceID(c *fiber.Ctx) string {
+	if traceID := string(c.Response().Header.Peek(traceIDHeader)); traceID != "" {
+		return traceID
+	}
+
+	traceID := c.Get(fiber.HeaderXRequestID)
+	if !traceIDPattern.MatchString(traceID) {
+		buf := make([]byte, 8)
+		_, _ = rand.Read(buf)
+		traceID = hex.EncodeToString(buf)
+	}
+
+	c.Set(traceIDHeader, traceID)
+	return traceID
+}
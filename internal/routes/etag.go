@@ -0,0 +1,164 @@
+package routes
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// etagVolatileFields 每次请求都会变化、不代表内容变化的顶层字段
+// updateTime 为响应生成时间,fromCache 随缓存命中情况变化,cacheRemaining 随时间递减,计算 ETag 时需要排除
+var etagVolatileFields = []*regexp.Regexp{
+	regexp.MustCompile(`"updateTime":"[^"]*",?`),
+	regexp.MustCompile(`"fromCache":(?:true|false),?`),
+	regexp.MustCompile(`"cacheRemaining":\d+,?`),
+}
+
+// lastModifiedMaxEntries Last-Modified 记录的最大条数,超出时淘汰最久未访问的记录
+// 被淘汰的键再次出现时按新内容处理,Last-Modified 只会前移,客户端多拿一次完整响应
+const lastModifiedMaxEntries = 4096
+
+// lastModifiedTracker 按请求(路径与查询参数)记录响应内容最后一次变化的时间,作为 Last-Modified
+// 统一响应中的 updateTime 是响应生成时间,每次请求都会变化;这里只在同一请求的 ETag 与上次不同时更新时间,
+// 缓存刷新后数据没变不会前移,内容变回旧值(A→B→A)时同样前移。
+// 记录只保存在当前进程内(prefork 下各子进程独立记录),未见过的请求按当前时间处理,只会让 Last-Modified 偏晚
+type lastModifiedTracker struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List // 按最近访问排序,队首最新
+	entries map[string]*list.Element
+}
+
+// lastModifiedEntry 单个请求的内容记录
+type lastModifiedEntry struct {
+	key      string
+	etag     string
+	modified time.Time
+}
+
+// newLastModifiedTracker 创建最多保存 max 条记录的 Last-Modified 记录表
+func newLastModifiedTracker(max int) *lastModifiedTracker {
+	return &lastModifiedTracker{
+		max:     max,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// responseLastModified 平台响应的 Last-Modified 记录
+var responseLastModified = newLastModifiedTracker(lastModifiedMaxEntries)
+
+// observe 返回 key 对应内容最后一次变化的时间(精确到秒)
+// 首次出现或 ETag 与上次不同时记为 now
+func (t *lastModifiedTracker) observe(key, etag string, now time.Time) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	modified := now.UTC().Truncate(time.Second)
+	if elem, ok := t.entries[key]; ok {
+		t.order.MoveToFront(elem)
+		entry := elem.Value.(*lastModifiedEntry)
+		if entry.etag != etag {
+			// 同一秒内再次变化时仍需晚于上次的时间,否则持有旧内容的客户端会得到 304
+			if !modified.After(entry.modified) {
+				modified = entry.modified.Add(time.Second)
+			}
+			entry.etag = etag
+			entry.modified = modified
+		}
+		return entry.modified
+	}
+
+	t.entries[key] = t.order.PushFront(&lastModifiedEntry{key: key, etag: etag, modified: modified})
+	for t.order.Len() > t.max {
+		oldest := t.order.Back()
+		t.order.Remove(oldest)
+		delete(t.entries, oldest.Value.(*lastModifiedEntry).key)
+	}
+	return modified
+}
+
+// applyETag 为成功的平台响应设置 ETag 与 Last-Modified,并处理条件请求
+// ETag 由响应体(排除 updateTime、fromCache)的哈希计算,数据不变时保持稳定;
+// Last-Modified 为同一请求的内容最后一次变化的时间。Content-Length 由 Fiber 按最终响应体自动设置。
+// 按 RFC 9110,请求携带 If-None-Match 时只按 ETag 判断,否则再看 If-Modified-Since,命中时返回 304 空响应
+func applyETag(c *fiber.Ctx) {
+	if c.Response().StatusCode() != fiber.StatusOK {
+		return
+	}
+
+	etag := responseETag(c.Response().Body())
+	c.Set(fiber.HeaderETag, etag)
+
+	modified := responseLastModified.observe(lastModifiedKey(c), etag, time.Now())
+	c.Set(fiber.HeaderLastModified, modified.Format(http.TimeFormat))
+
+	notModified := false
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" {
+		notModified = etagMatches(ifNoneMatch, etag)
+	} else {
+		notModified = notModifiedSince(c.Get(fiber.HeaderIfModifiedSince), modified)
+	}
+
+	if notModified {
+		c.Response().ResetBody()
+		c.Status(fiber.StatusNotModified)
+	}
+}
+
+// lastModifiedKey Last-Modified 记录的键: 路径与原始查询参数
+// 同一平台的不同参数(如 type、lang)响应内容不同,需要分别记录
+func lastModifiedKey(c *fiber.Ctx) string {
+	return c.Path() + "?" + string(c.Request().URI().QueryString())
+}
+
+// responseETag 计算响应体的 ETag
+func responseETag(body []byte) string {
+	stable := body
+	for _, pattern := range etagVolatileFields {
+		// 只替换第一次出现的位置,即统一响应的顶层字段
+		if loc := pattern.FindIndex(stable); loc != nil {
+			stable = append(append(make([]byte, 0, len(stable)), stable[:loc[0]]...), stable[loc[1]:]...)
+		}
+	}
+
+	sum := sha256.Sum256(bytes.TrimSpace(stable))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches 判断 If-None-Match 是否与 ETag 匹配
+// 支持逗号分隔的多个值、弱校验前缀 W/ 以及通配符 *
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedSince 判断内容在 If-Modified-Since 之后是否没有变化
+// 无法解析的日期按未携带处理
+func notModifiedSince(ifModifiedSince string, modified time.Time) bool {
+	if ifModifiedSince == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastModifiedTrackerObserve(t *testing.T) {
+	tracker := newLastModifiedTracker(10)
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if got := tracker.observe("/a", `"A"`, t0); !got.Equal(t0) {
+		t.Fatalf("首次出现 = %v, want %v", got, t0)
+	}
+	if got := tracker.observe("/a", `"A"`, t0.Add(time.Minute)); !got.Equal(t0) {
+		t.Errorf("内容未变 = %v, want %v", got, t0)
+	}
+
+	// A→B→A: 每次变化都前移
+	t1 := t0.Add(2 * time.Minute)
+	if got := tracker.observe("/a", `"B"`, t1); !got.Equal(t1) {
+		t.Errorf("变为 B = %v, want %v", got, t1)
+	}
+	t2 := t0.Add(3 * time.Minute)
+	if got := tracker.observe("/a", `"A"`, t2); !got.Equal(t2) {
+		t.Errorf("变回 A = %v, want %v", got, t2)
+	}
+
+	// 不同请求分别记录
+	if got := tracker.observe("/b", `"A"`, t0.Add(4*time.Minute)); !got.Equal(t0.Add(4 * time.Minute)) {
+		t.Errorf("另一请求 = %v, want %v", got, t0.Add(4*time.Minute))
+	}
+	if got := tracker.observe("/a", `"A"`, t0.Add(5*time.Minute)); !got.Equal(t2) {
+		t.Errorf("原请求 = %v, want %v", got, t2)
+	}
+}
+
+func TestLastModifiedTrackerSameSecond(t *testing.T) {
+	tracker := newLastModifiedTracker(10)
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tracker.observe("/a", `"A"`, t0)
+	got := tracker.observe("/a", `"B"`, t0.Add(500*time.Millisecond))
+	if !got.After(t0) {
+		t.Errorf("同一秒内变化 = %v, want after %v", got, t0)
+	}
+}
+
+func TestLastModifiedTrackerEvict(t *testing.T) {
+	tracker := newLastModifiedTracker(2)
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tracker.observe("/a", `"A"`, t0)
+	tracker.observe("/b", `"B"`, t0)
+	tracker.observe("/a", `"A"`, t0) // /a 最近访问,/b 最久未访问
+	tracker.observe("/c", `"C"`, t0)
+
+	if len(tracker.entries) != 2 {
+		t.Fatalf("len = %d, want 2", len(tracker.entries))
+	}
+	if _, ok := tracker.entries["/b"]; ok {
+		t.Error("/b 应被淘汰")
+	}
+
+	// 被淘汰的请求再次出现时按新内容处理
+	later := t0.Add(time.Hour)
+	if got := tracker.observe("/b", `"B"`, later); !got.Equal(later) {
+		t.Errorf("淘汰后再次出现 = %v, want %v", got, later)
+	}
+}
@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	httpclient "github.com/dailyhot/api/internal/http"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/mmcdole/gofeed"
+)
+
+// FeedParser RSS/Atom 订阅源解析器
+// 封装"抓取 + 解析 + 转换"的通用流程,供基于订阅源的平台复用
+type FeedParser struct {
+	client *httpclient.Client
+}
+
+// NewFeedParser 创建订阅源解析器
+func NewFeedParser(client *httpclient.Client) *FeedParser {
+	return &FeedParser{
+		client: client,
+	}
+}
+
+// Fetch 抓取并解析订阅源
+// 同时支持 RSS 2.0、Atom 与 JSON Feed 格式,ctx 取消时请求立即中止
+func (p *FeedParser) Fetch(ctx context.Context, feedURL string, headers map[string]string) (*gofeed.Feed, error) {
+	body, err := p.client.GetContext(ctx, feedURL, headers)
+	if err != nil {
+		return nil, fmt.Errorf("请求订阅源失败: %w", err)
+	}
+
+	return p.Parse(string(body))
+}
+
+// Parse 解析订阅源内容
+func (p *FeedParser) Parse(content string) (*gofeed.Feed, error) {
+	feed, err := gofeed.NewParser().ParseString(content)
+	if err != nil {
+		return nil, fmt.Errorf("解析订阅源失败: %w", err)
+	}
+	return feed, nil
+}
+
+// ToHotData 将订阅源条目转换为统一格式
+// 时间戳统一为毫秒级,封面优先取 enclosure/media 中的图片
+func (p *FeedParser) ToHotData(items []*gofeed.Item) []models.HotData {
+	result := make([]models.HotData, 0, len(items))
+
+	for i, item := range items {
+		if item == nil || strings.TrimSpace(item.Title) == "" {
+			continue
+		}
+
+		// 获取 ID(优先 GUID,其次链接,最后索引)
+		id := item.GUID
+		if id == "" {
+			id = item.Link
+		}
+		if id == "" {
+			id = fmt.Sprintf("%d", i)
+		}
+
+		// 描述
+		desc := item.Description
+		if desc == "" {
+			desc = item.Content
+		}
+
+		// 时间戳(毫秒)
+		var timestamp interface{}
+		if published := feedItemTime(item); published != nil {
+			timestamp = published.UnixMilli()
+		}
+
+		// 作者
+		author := ""
+		if item.Author != nil {
+			author = item.Author.Name
+		}
+
+		hotData := models.HotData{
+			ID:        id,
+			Title:     strings.TrimSpace(item.Title),
+			Desc:      strings.TrimSpace(desc),
+			Cover:     feedItemCover(item),
+			Author:    author,
+			Timestamp: timestamp,
+			URL:       item.Link,
+			MobileURL: item.Link,
+		}
+
+		result = append(result, hotData)
+	}
+
+	return result
+}
+
+// parseFeedSource 按 RSS/Atom/JSON Feed 解析备用源响应(如 RSSHub 实例)
+// 作为各平台 service.SourceParsers 的 "*" 默认解析函数
+func parseFeedSource(body []byte) ([]models.HotData, error) {
+	parser := NewFeedParser(nil)
+	feed, err := parser.Parse(string(body))
+	if err != nil {
+		return nil, err
+	}
+	return parser.ToHotData(feed.Items), nil
+}
+
+// feedItemTime 获取条目发布时间,没有发布时间时使用更新时间
+func feedItemTime(item *gofeed.Item) *time.Time {
+	if item.PublishedParsed != nil {
+		return item.PublishedParsed
+	}
+	return item.UpdatedParsed
+}
+
+// feedItemCover 获取条目封面图片
+func feedItemCover(item *gofeed.Item) string {
+	if item.Image != nil && item.Image.URL != "" {
+		return item.Image.URL
+	}
+
+	for _, enclosure := range item.Enclosures {
+		if enclosure != nil && strings.HasPrefix(enclosure.Type, "image/") {
+			return enclosure.URL
+		}
+	}
+
+	// media:content / media:thumbnail 扩展
+	if media, ok := item.Extensions["media"]; ok {
+		for _, name := range []string{"content", "thumbnail"} {
+			for _, ext := range media[name] {
+				if u := ext.Attrs["url"]; u != "" {
+					return u
+				}
+			}
+		}
+	}
+
+	return ""
+}
@@ -32,9 +32,12 @@ func (h *GameresHandler) GetPath() string {
 // Handle 处理请求
 func (h *GameresHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchGameres(c.Context())
+	cacheKey := "gameres"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchGameres(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -45,7 +48,7 @@ func (h *GameresHandler) Handle(c *fiber.Ctx) error {
 		"https://www.gameres.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -55,7 +58,7 @@ func (h *GameresHandler) fetchGameres(ctx context.Context) ([]models.HotData, er
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求 GameRes 失败: %w", err)
 	}
@@ -0,0 +1,209 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// gamesDefaultList 未指定 list 时聚合的游戏
+const gamesDefaultList = "genshin,starrail"
+
+// gamesTypeMap 资讯类型映射: type 参数 -> 类型名称
+var gamesTypeMap = map[string]string{
+	"1": "公告",
+	"2": "活动",
+	"3": "资讯",
+}
+
+// gameSource 米游社游戏资讯源
+type gameSource struct {
+	Name     string // 游戏名称
+	Gids     string // 米游社游戏 ID
+	Code     string // 游戏代号,用于拼接文章链接
+	CacheKey string // 缓存键前缀,与单游戏接口一致以共用缓存
+	PageSize int    // 每页条数,与单游戏接口一致
+}
+
+// gamesSourceMap 可聚合的游戏: list 参数中的名称 -> 资讯源
+var gamesSourceMap = map[string]gameSource{
+	"genshin":  {Name: "原神", Gids: "2", Code: "ys", CacheKey: "genshin", PageSize: 20},
+	"starrail": {Name: "崩坏：星穹铁道", Gids: "6", Code: "sr", CacheKey: "starrail", PageSize: 20},
+	"honkai":   {Name: "崩坏3", Gids: "1", Code: "bh3", CacheKey: "honkai", PageSize: 20},
+	"zzz":      {Name: "绝区零", Gids: "8", Code: "zzz", CacheKey: "miyoushe_8", PageSize: 30},
+}
+
+// GamesHandler 多游戏动态聚合处理器
+// 并发抓取 list 中各游戏的米游社资讯,合并后按发布时间倒序返回
+type GamesHandler struct {
+	fetcher *service.Fetcher
+}
+
+// NewGamesHandler 创建多游戏动态聚合处理器
+func NewGamesHandler(fetcher *service.Fetcher) *GamesHandler {
+	return &GamesHandler{
+		fetcher: fetcher,
+	}
+}
+
+// GetPath 获取路由路径
+func (h *GamesHandler) GetPath() string {
+	return "/games"
+}
+
+// Handle 处理请求
+func (h *GamesHandler) Handle(c *fiber.Ctx) error {
+	games := h.parseList(c.Query("list", gamesDefaultList))
+	newsType := c.Query("type", "2") // 默认活动
+	if err := validateParam("type", newsType, oneOf(gamesTypeMap)); err != nil {
+		return paramError(c, err)
+	}
+	noCache := c.Query("cache") == "false"
+
+	data, fromCache, err := h.fetchGames(c.Context(), games, newsType, noCache)
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	names := make([]string, 0, len(games))
+	for _, game := range games {
+		names = append(names, gamesSourceMap[game].Name)
+	}
+
+	gameNames := make(map[string]string, len(gamesSourceMap))
+	for key, source := range gamesSourceMap {
+		gameNames[key] = source.Name
+	}
+
+	return c.JSON(models.SuccessResponse(
+		fmt.Sprintf("games_%s", newsType),
+		"游戏动态聚合",
+		fmt.Sprintf("最新%s", gamesTypeMap[newsType]),
+		fmt.Sprintf("%s最新%s", strings.Join(names, "、"), gamesTypeMap[newsType]),
+		"https://www.miyoushe.com",
+		map[string]interface{}{
+			"list": gameNames,
+			"type": gamesTypeMap,
+		},
+		data,
+		fromCache,
+	))
+}
+
+// parseList 解析逗号分隔的游戏列表,忽略未知与重复的名称
+// 没有有效名称时使用默认列表
+func (h *GamesHandler) parseList(raw string) []string {
+	games := make([]string, 0)
+	picked := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := gamesSourceMap[name]; !ok || picked[name] {
+			continue
+		}
+		picked[name] = true
+		games = append(games, name)
+	}
+	if len(games) == 0 && raw != gamesDefaultList {
+		return h.parseList(gamesDefaultList)
+	}
+	return games
+}
+
+// fetchGames 并发抓取各游戏资讯并合并
+// 每个游戏沿用单游戏接口的缓存键,与 /genshin、/starrail 等共用缓存,缓存通过 CachedMany 一次读取;
+// 部分游戏失败时只记录日志并返回其余结果,全部失败时返回第一个错误
+// 合并结果只有在所有游戏都命中缓存时才标记为 fromCache
+func (h *GamesHandler) fetchGames(ctx context.Context, games []string, newsType string, noCache bool) ([]models.HotData, bool, error) {
+	type gameResult struct {
+		data      []models.HotData
+		fromCache bool
+		err       error
+	}
+
+	cacheKeys := make([]string, len(games))
+	for i, game := range games {
+		cacheKeys[i] = fmt.Sprintf("%s_%s", gamesSourceMap[game].CacheKey, newsType)
+	}
+
+	// 先批量读取缓存,减少多个游戏逐个读取 Redis 的往返
+	cached := make(map[string][]models.HotData)
+	if !noCache {
+		cached = h.fetcher.CachedMany(ctx, cacheKeys)
+	}
+
+	results := make([]gameResult, len(games))
+	var wg sync.WaitGroup
+	for i, game := range games {
+		if data, ok := cached[cacheKeys[i]]; ok {
+			results[i] = gameResult{data: data, fromCache: true}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, game string) {
+			defer wg.Done()
+
+			// 未命中的键仍走 Fetch,保留缓存故障时返回陈旧数据、只读缓存等逻辑
+			source := gamesSourceMap[game]
+			data, fromCache, err := h.fetcher.Fetch(ctx, cacheKeys[i], service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+				return fetchMiyousheNews(ctx, h.fetcher, source.Gids, newsType, source.PageSize, source.Code)
+			})
+			results[i] = gameResult{data: data, fromCache: fromCache, err: err}
+		}(i, game)
+	}
+	wg.Wait()
+
+	merged := make([]models.HotData, 0)
+	allFromCache := true
+	var firstErr error
+	for i, result := range results {
+		if result.err != nil {
+			logger.Warn("聚合游戏动态时抓取失败",
+				zap.String("game", games[i]),
+				zap.Error(result.err),
+			)
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			continue
+		}
+		allFromCache = allFromCache && result.fromCache
+		merged = append(merged, h.transformData(games[i], result.data)...)
+	}
+
+	if firstErr != nil && len(merged) == 0 {
+		return nil, false, firstErr
+	}
+
+	// 按发布时间倒序
+	sort.SliceStable(merged, func(i, j int) bool {
+		return models.ToInt64(merged[i].Timestamp) > models.ToInt64(merged[j].Timestamp)
+	})
+
+	return merged, allFromCache, nil
+}
+
+// transformData 在条目上标注来源游戏
+// 缓存中的条目可能被其他请求共用,这里复制条目与 Extra 后再修改
+func (h *GamesHandler) transformData(game string, items []models.HotData) []models.HotData {
+	result := make([]models.HotData, 0, len(items))
+	for _, item := range items {
+		extra := make(map[string]interface{}, len(item.Extra)+2)
+		for key, value := range item.Extra {
+			extra[key] = value
+		}
+		extra["game"] = game
+		extra["game_name"] = gamesSourceMap[game].Name
+		item.Extra = extra
+		result = append(result, item)
+	}
+	return result
+}
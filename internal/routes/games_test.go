@@ -0,0 +1,140 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dailyhot/api/internal/models"
+)
+
+// gamesNewsFixtures 各游戏资讯列表接口响应(节选): gids -> 响应
+var gamesNewsFixtures = map[string]string{
+	"2": `{"retcode":0,"message":"OK","data":{"list":[
+  {"post":{"post_id":"50001","subject":"「月草的赐慧」祈愿现已开启","content":"活动期间限定5星角色概率UP","cover":"https://upload-bbs.miyoushe.com/upload/2024/05/01/ys.png","images":[],"view_status":305000,"created_at":1714528800},"user":{"nickname":"原神"}},
+  {"post":{"post_id":"50000","subject":"网页活动「星与月的旅途」","content":"","cover":"","images":["https://upload-bbs.miyoushe.com/upload/2024/04/20/a.png"],"view_status":120000,"created_at":1713578400},"user":null}
+]}}`,
+	"6": `{"retcode":0,"message":"OK","data":{"list":[
+  {"post":{"post_id":"60001","subject":"「流光忆庭」活动说明","content":"","cover":"https://upload-bbs.miyoushe.com/upload/2024/05/05/sr.png","view_status":98000,"created_at":1714874400},"user":{"nickname":"崩坏星穹铁道"}}
+]}}`,
+}
+
+// newGamesUpstream 按 gids 返回对应游戏的资讯,未知 gids 返回 502
+func newGamesUpstream(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		fixture, ok := gamesNewsFixtures[r.URL.Query().Get("gids")]
+		if !ok {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, fixture)
+	}))
+	t.Cleanup(upstream.Close)
+
+	original := miyousheNewsURL
+	miyousheNewsURL = upstream.URL
+	t.Cleanup(func() { miyousheNewsURL = original })
+	return upstream
+}
+
+func TestGamesParseList(t *testing.T) {
+	h := &GamesHandler{}
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"genshin,starrail", []string{"genshin", "starrail"}},
+		{" ZZZ , honkai,zzz", []string{"zzz", "honkai"}},
+		{"genshin,unknown", []string{"genshin"}},
+		// 没有有效名称时使用默认列表
+		{"unknown", []string{"genshin", "starrail"}},
+		{"", []string{"genshin", "starrail"}},
+	}
+	for _, tt := range tests {
+		if got := h.parseList(tt.raw); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseList(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestGamesFetchGames(t *testing.T) {
+	var hits int32
+	newGamesUpstream(t, &hits)
+
+	h := &GamesHandler{fetcher: newTestFetcher(t)}
+	data, fromCache, err := h.fetchGames(context.Background(), []string{"genshin", "starrail"}, "2", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromCache {
+		t.Error("fromCache = true on first fetch")
+	}
+
+	// 合并后按发布时间倒序,并标注来源游戏
+	var ids []string
+	for _, item := range data {
+		ids = append(ids, item.ID)
+	}
+	if want := []string{"60001", "50001", "50000"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	if data[0].Extra["game"] != "starrail" || data[0].Extra["game_name"] != "崩坏：星穹铁道" {
+		t.Errorf("data[0].Extra = %v", data[0].Extra)
+	}
+	if data[1].URL != "https://www.miyoushe.com/ys/article/50001" || data[1].Author != "原神" || data[1].Hot != int64(305000) {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+	if data[2].Cover != "https://upload-bbs.miyoushe.com/upload/2024/04/20/a.png" {
+		t.Errorf("data[2].Cover = %q", data[2].Cover)
+	}
+
+	// 与单游戏接口共用缓存,再次请求不回源
+	if cached := h.fetcher.CachedMany(context.Background(), []string{"genshin_2", "starrail_2"}); len(cached) != 2 {
+		t.Errorf("cached = %v, want genshin_2 and starrail_2", cached)
+	}
+	atomic.StoreInt32(&hits, 0)
+	_, fromCache, err = h.fetchGames(context.Background(), []string{"starrail", "genshin"}, "2", false)
+	if err != nil || !fromCache || atomic.LoadInt32(&hits) != 0 {
+		t.Errorf("fromCache = %v, err = %v, hits = %d, want all from cache", fromCache, err, atomic.LoadInt32(&hits))
+	}
+}
+
+func TestGamesFetchGamesPartialFailure(t *testing.T) {
+	var hits int32
+	newGamesUpstream(t, &hits)
+	h := &GamesHandler{fetcher: newTestFetcher(t)}
+
+	// 部分游戏失败时返回其余游戏
+	data, _, err := h.fetchGames(context.Background(), []string{"genshin", "zzz"}, "1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 || data[0].Extra["game"] != "genshin" {
+		t.Errorf("data = %+v, want genshin only", data)
+	}
+
+	// 全部失败时返回错误
+	if _, _, err := h.fetchGames(context.Background(), []string{"honkai", "zzz"}, "1", true); err == nil {
+		t.Error("err = nil, want error when all games fail")
+	}
+}
+
+func TestGamesTransformDataCopiesExtra(t *testing.T) {
+	h := &GamesHandler{}
+	items := []models.HotData{{ID: "1", Extra: map[string]interface{}{"views": 1}}}
+	result := h.transformData("genshin", items)
+
+	if result[0].Extra["game"] != "genshin" || result[0].Extra["views"] != 1 {
+		t.Errorf("Extra = %v", result[0].Extra)
+	}
+	// 缓存中的条目不能被修改
+	if _, ok := items[0].Extra["game"]; ok {
+		t.Errorf("source Extra modified: %v", items[0].Extra)
+	}
+}
@@ -8,9 +8,27 @@ import (
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
+// geekparkTypeMap 类型映射: type 参数 -> 类型名称
+var geekparkTypeMap = map[string]string{
+	"hot":   "热门文章",
+	"news":  "资讯",
+	"video": "视频",
+	"topic": "专题",
+}
+
+// geekparkAPIMap 各类型对应的接口
+// 首页推荐、综合资讯栏目、视频列表与专题列表的返回结构各不相同,由 transformData 分别适配
+var geekparkAPIMap = map[string]string{
+	"hot":   "https://mainssl.geekpark.net/api/v2",
+	"news":  "https://mainssl.geekpark.net/api/v1/columns/179",
+	"video": "https://mainssl.geekpark.net/api/v1/videos",
+	"topic": "https://mainssl.geekpark.net/api/v1/topics",
+}
+
 // GeekParkHandler 极客公园处理器
 type GeekParkHandler struct {
 	fetcher *service.Fetcher
@@ -30,48 +48,89 @@ func (h *GeekParkHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *GeekParkHandler) Handle(c *fiber.Ctx) error {
+	listType := c.Query("type", "hot")
+	if err := validateParam("type", listType, oneOf(geekparkTypeMap)); err != nil {
+		return paramError(c, err)
+	}
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchGeekParkHot(c.Context())
+
+	// 热门文章沿用原缓存键
+	cacheKey := "geekpark"
+	if listType != "hot" {
+		cacheKey = fmt.Sprintf("geekpark_%s", listType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchGeekPark(ctx, listType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
-		"geekpark_hot",
+		fmt.Sprintf("geekpark_%s", listType),
 		"极客公园",
-		"热门文章",
-		"极客公园热门文章列表",
+		geekparkTypeMap[listType],
+		fmt.Sprintf("极客公园%s列表", geekparkTypeMap[listType]),
 		"https://www.geekpark.net",
-		nil,
+		map[string]interface{}{
+			"type": geekparkTypeMap,
+		},
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
-// fetchGeekParkHot 从极客公园 API 获取数据
-func (h *GeekParkHandler) fetchGeekParkHot(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://mainssl.geekpark.net/api/v2"
-
+// fetchGeekPark 从极客公园 API 获取指定类型的数据
+func (h *GeekParkHandler) fetchGeekPark(ctx context.Context, listType string) ([]models.HotData, error) {
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, geekparkAPIMap[listType], nil)
 	if err != nil {
-		return nil, fmt.Errorf("请求极客公园 API 失败: %w", err)
+		return nil, fmt.Errorf("请求极客公园%s失败: %w", geekparkTypeMap[listType], err)
 	}
 
 	var apiResp GeekParkAPIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("解析极客公园响应失败: %w", err)
+		return nil, fmt.Errorf("解析极客公园%s响应失败: %w", geekparkTypeMap[listType], err)
 	}
 
-	return h.transformData(apiResp.HomepagePosts), nil
+	data := h.transformData(listType, apiResp)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("极客公园%s%w", geekparkTypeMap[listType], service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// transformData 按类型转换数据格式
+// 首页推荐的文章包在 homepage_posts[].post 中,资讯栏目在 column.posts 中,视频列表在 videos(或 posts)中,
+// 三者的条目结构相同;专题是独立的结构,链接指向专题页
+func (h *GeekParkHandler) transformData(listType string, apiResp GeekParkAPIResponse) []models.HotData {
+	switch listType {
+	case "topic":
+		return h.transformTopics(apiResp.Topics)
+	case "news":
+		return h.transformPosts(apiResp.Column.Posts)
+	case "video":
+		if len(apiResp.Videos) > 0 {
+			return h.transformPosts(apiResp.Videos)
+		}
+		return h.transformPosts(apiResp.Posts)
+	}
+
+	posts := make([]GeekParkPost, 0, len(apiResp.HomepagePosts))
+	for _, item := range apiResp.HomepagePosts {
+		posts = append(posts, item.Post)
+	}
+	return h.transformPosts(posts)
 }
 
-// transformData 转换数据格式
-func (h *GeekParkHandler) transformData(items []GeekParkHomeItem) []models.HotData {
-	result := make([]models.HotData, 0, len(items))
+// transformPosts 转换文章与视频
+func (h *GeekParkHandler) transformPosts(posts []GeekParkPost) []models.HotData {
+	result := make([]models.HotData, 0, len(posts))
 
-	for _, item := range items {
-		post := item.Post
+	for _, post := range posts {
+		if post.ID == 0 || post.Title == "" {
+			continue
+		}
 
 		// 提取作者
 		author := ""
@@ -97,9 +156,61 @@ func (h *GeekParkHandler) transformData(items []GeekParkHomeItem) []models.HotDa
 	return result
 }
 
+// transformTopics 转换专题
+func (h *GeekParkHandler) transformTopics(topics []GeekParkTopic) []models.HotData {
+	result := make([]models.HotData, 0, len(topics))
+
+	for _, topic := range topics {
+		if topic.ID == 0 || topic.Title == "" {
+			continue
+		}
+
+		cover := topic.CoverURL
+		if cover == "" {
+			cover = topic.BannerURL
+		}
+
+		url := fmt.Sprintf("https://www.geekpark.net/topics/%d", topic.ID)
+		result = append(result, models.HotData{
+			ID:        strconv.FormatInt(topic.ID, 10),
+			Title:     topic.Title,
+			Desc:      topic.Description,
+			Cover:     cover,
+			Hot:       topic.PostsCount,
+			Timestamp: timeutil.ParseTime(topic.CreatedAt),
+			URL:       url,
+			MobileURL: url,
+		})
+	}
+
+	return result
+}
+
 // GeekParkAPIResponse 极客公园 API 响应
+// 各类型接口只会返回其中一个字段
 type GeekParkAPIResponse struct {
-	HomepagePosts []GeekParkHomeItem `json:"homepage_posts"`
+	HomepagePosts []GeekParkHomeItem `json:"homepage_posts"` // 首页推荐
+	Column        GeekParkColumn     `json:"column"`         // 栏目
+	Videos        []GeekParkPost     `json:"videos"`         // 视频列表
+	Posts         []GeekParkPost     `json:"posts"`          // 视频列表(部分版本的字段名)
+	Topics        []GeekParkTopic    `json:"topics"`         // 专题列表
+}
+
+// GeekParkColumn 栏目
+type GeekParkColumn struct {
+	Title string         `json:"title"`
+	Posts []GeekParkPost `json:"posts"`
+}
+
+// GeekParkTopic 专题
+type GeekParkTopic struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	CoverURL    string `json:"cover_url"`
+	BannerURL   string `json:"banner_url"`
+	PostsCount  int64  `json:"posts_count"`
+	CreatedAt   string `json:"created_at"`
 }
 
 // GeekParkHomeItem 首页项
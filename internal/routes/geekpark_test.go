@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// geekparkFixtures 各类型接口响应(节选)
+var geekparkFixtures = map[string]string{
+	"hot": `{"homepage_posts":[
+  {"post":{"id":330001,"title":"苹果发布新款 iPad Pro","abstract":"史上最薄的苹果产品","cover_url":"https://imgslim.geekpark.net/uploads/image/file/a.jpg","views":12800,"published_timestamp":1715313600,"authors":[{"nickname":"Li Yuan"}]}},
+  {"post":{"id":0,"title":"广告位"}}
+]}`,
+	"news": `{"column":{"title":"综合资讯","posts":[
+  {"id":330002,"title":"早报｜OpenAI 将发布搜索产品","abstract":"","cover_url":"https://imgslim.geekpark.net/uploads/image/file/b.jpg","views":5600,"published_timestamp":1715300000,"authors":[]}
+]}}`,
+	"video": `{"posts":[
+  {"id":330003,"title":"【视频】开箱 Vision Pro","cover_url":"https://imgslim.geekpark.net/uploads/image/file/v.jpg","views":900,"published_timestamp":1715200000,"authors":[{"nickname":"极客公园视频"}]}
+]}`,
+	"topic": `{"topics":[
+  {"id":88,"title":"AI 大模型观察","description":"持续追踪大模型进展","cover_url":"","banner_url":"https://imgslim.geekpark.net/uploads/topic/banner.jpg","posts_count":126,"created_at":"2023-03-15T10:00:00.000+08:00"},
+  {"id":0,"title":"无效专题"}
+]}`,
+}
+
+func TestGeekParkTransformData(t *testing.T) {
+	h := &GeekParkHandler{}
+	decode := func(listType string) GeekParkAPIResponse {
+		var resp GeekParkAPIResponse
+		if err := json.Unmarshal([]byte(geekparkFixtures[listType]), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	hot := h.transformData("hot", decode("hot"))
+	if len(hot) != 1 {
+		t.Fatalf("hot: len = %d, want 1", len(hot))
+	}
+	if hot[0].ID != "330001" || hot[0].Author != "Li Yuan" || hot[0].Hot != int64(12800) || hot[0].Timestamp != int64(1715313600000) {
+		t.Errorf("hot[0] = %+v", hot[0])
+	}
+	if hot[0].URL != "https://www.geekpark.net/news/330001" {
+		t.Errorf("hot[0].URL = %q", hot[0].URL)
+	}
+
+	news := h.transformData("news", decode("news"))
+	if len(news) != 1 || news[0].ID != "330002" || news[0].Author != "" {
+		t.Errorf("news = %+v", news)
+	}
+
+	// 视频列表的字段名可能是 posts
+	video := h.transformData("video", decode("video"))
+	if len(video) != 1 || video[0].ID != "330003" || video[0].Author != "极客公园视频" {
+		t.Errorf("video = %+v", video)
+	}
+	video = h.transformData("video", GeekParkAPIResponse{Videos: []GeekParkPost{{ID: 1, Title: "videos 字段"}}})
+	if len(video) != 1 || video[0].Title != "videos 字段" {
+		t.Errorf("video = %+v", video)
+	}
+
+	// 专题没有封面时使用横幅图,链接指向专题页
+	topics := h.transformData("topic", decode("topic"))
+	if len(topics) != 1 {
+		t.Fatalf("topic: len = %d, want 1", len(topics))
+	}
+	topic := topics[0]
+	if topic.ID != "88" || topic.Cover != "https://imgslim.geekpark.net/uploads/topic/banner.jpg" || topic.Hot != int64(126) {
+		t.Errorf("topic = %+v", topic)
+	}
+	if topic.URL != "https://www.geekpark.net/topics/88" || topic.Timestamp != int64(1678845600000) {
+		t.Errorf("URL = %q, Timestamp = %v", topic.URL, topic.Timestamp)
+	}
+}
+
+func TestGeekParkType(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, geekparkFixtures[r.URL.Path[1:]])
+	}))
+	defer upstream.Close()
+
+	original := geekparkAPIMap
+	geekparkAPIMap = make(map[string]string, len(original))
+	for listType := range original {
+		geekparkAPIMap[listType] = upstream.URL + "/" + listType
+	}
+	defer func() { geekparkAPIMap = original }()
+
+	h := &GeekParkHandler{fetcher: newTestFetcher(t)}
+	app := fiber.New()
+	app.Get(h.GetPath(), h.Handle)
+
+	tests := []struct {
+		listType string
+		wantID   string
+		wantType string
+	}{
+		{"", "330001", "热门文章"},
+		{"news", "330002", "资讯"},
+		{"video", "330003", "视频"},
+		{"topic", "88", "专题"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			// type 为空时使用默认的热门文章
+			status, result := getJSON(t, app, "/geekpark?cache=false&type="+tt.listType)
+			if status != fiber.StatusOK {
+				t.Fatalf("status = %d", status)
+			}
+			if result["type"] != tt.wantType {
+				t.Errorf("type = %v, want %s", result["type"], tt.wantType)
+			}
+			items, _ := result["data"].([]interface{})
+			if len(items) != 1 {
+				t.Fatalf("len(data) = %d, want 1", len(items))
+			}
+			if item, _ := items[0].(map[string]interface{}); item["id"] != tt.wantID {
+				t.Errorf("id = %v, want %s", item["id"], tt.wantID)
+			}
+		})
+	}
+
+	if status, _ := getJSON(t, app, "/geekpark?type=unknown"); status != fiber.StatusBadRequest {
+		t.Errorf("type=unknown: status = %d, want 400", status)
+	}
+}
@@ -0,0 +1,185 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GiteeHandler Gitee 热门仓库处理器
+type GiteeHandler struct {
+	fetcher *service.Fetcher
+}
+
+// NewGiteeHandler 创建 Gitee 处理器
+func NewGiteeHandler(fetcher *service.Fetcher) *GiteeHandler {
+	return &GiteeHandler{
+		fetcher: fetcher,
+	}
+}
+
+// GetPath 获取路由路径
+func (h *GiteeHandler) GetPath() string {
+	return "/gitee"
+}
+
+// Handle 处理请求
+func (h *GiteeHandler) Handle(c *fiber.Ctx) error {
+	// 获取语言过滤参数,如 Go、Java、Python
+	lang := strings.TrimSpace(c.Query("lang"))
+	noCache := c.Query("cache") == "false"
+
+	cacheKey := fmt.Sprintf("gitee_%s", strings.ToLower(lang))
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, 30*time.Minute, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchGiteeExplore(ctx, lang)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	typeName := "热门仓库"
+	if lang != "" {
+		typeName = fmt.Sprintf("热门仓库 · %s", lang)
+	}
+
+	resp := models.SuccessResponse(
+		"gitee",                     // name: 平台调用名称
+		"Gitee",                     // title: 平台显示名称
+		typeName,                    // type: 榜单类型
+		"发现 Gitee 热门开源项目",           // description: 平台描述
+		"https://gitee.com/explore", // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"lang": "编程语言,如 Go、Java、Python,为空时不过滤",
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
+	)
+
+	return c.JSON(resp)
+}
+
+// fetchGiteeExplore 从 Gitee 开源探索页获取按 Star 排序的仓库列表
+func (h *GiteeHandler) fetchGiteeExplore(ctx context.Context, lang string) ([]models.HotData, error) {
+	query := url.Values{}
+	query.Set("order", "starred")
+	if lang != "" {
+		query.Set("lang", lang)
+	}
+	apiURL := "https://gitee.com/explore/all?" + query.Encode()
+
+	body, err := h.fetcher.Get(ctx, apiURL, map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Referer":    "https://gitee.com/explore",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求 Gitee 失败: %w", err)
+	}
+
+	data := h.parseHTML(string(body))
+
+	// 上游可能忽略 lang 参数,这里再按语言过滤一次
+	if lang != "" {
+		filtered := make([]models.HotData, 0, len(data))
+		for _, item := range data {
+			if language, _ := item.Extra["language"].(string); strings.EqualFold(language, lang) {
+				filtered = append(filtered, item)
+			}
+		}
+		data = filtered
+	}
+
+	return data, nil
+}
+
+// parseHTML 解析探索页仓库列表
+func (h *GiteeHandler) parseHTML(html string) []models.HotData {
+	result := make([]models.HotData, 0)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return result
+	}
+
+	doc.Find(".explore-repo__list .item, .project-list .item").Each(func(i int, s *goquery.Selection) {
+		link := s.Find(".project-title a.title, .project-title a").First()
+		href, _ := link.Attr("href")
+		if href == "" {
+			return
+		}
+
+		// 仓库全名优先取 title 属性(保持原始大小写的 owner/repo)
+		name, _ := link.Attr("title")
+		if name == "" {
+			name = strings.TrimSpace(link.Text())
+		}
+		name = strings.Join(strings.Fields(name), "")
+
+		repoURL := href
+		if strings.HasPrefix(repoURL, "/") {
+			repoURL = "https://gitee.com" + repoURL
+		}
+
+		desc := strings.TrimSpace(s.Find(".project-desc").First().Text())
+		if desc == "" {
+			desc = name
+		}
+		language := strings.TrimSpace(s.Find(".project-language").First().Text())
+
+		starsNode := s.Find(".stars-count").First()
+		starsText, ok := starsNode.Attr("data-count")
+		if !ok {
+			starsText = starsNode.Text()
+		}
+		stars := parseAbbrevCount(starsText)
+
+		owner := strings.SplitN(strings.Trim(href, "/"), "/", 2)[0]
+
+		result = append(result, models.HotData{
+			ID:        strings.Trim(href, "/"),
+			Title:     name,
+			Desc:      desc,
+			Author:    owner,
+			Hot:       stars,
+			URL:       repoURL,
+			MobileURL: repoURL,
+			Extra: map[string]interface{}{
+				"language": language,
+				"stars":    stars,
+			},
+		})
+	})
+
+	return result
+}
+
+// parseAbbrevCount 解析带缩写单位的计数,支持 "1234"、"1,234"、"1.2k"、"3.5w" 等格式
+func parseAbbrevCount(text string) int64 {
+	text = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(text), ",", ""))
+	if text == "" {
+		return 0
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(text, "k"):
+		multiplier = 1000
+		text = strings.TrimSuffix(text, "k")
+	case strings.HasSuffix(text, "w"):
+		multiplier = 10000
+		text = strings.TrimSuffix(text, "w")
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(value * multiplier)
+}
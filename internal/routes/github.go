@@ -35,6 +35,9 @@ func (h *GitHubHandler) Handle(c *fiber.Ctx) error {
 	// 获取类型参数 (daily/weekly/monthly)
 	since := c.Query("type", "daily")
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", since, isSlug); err != nil {
+		return paramError(c, err)
+	}
 
 	// 类型映射表
 	typeMap := map[string]string{
@@ -50,9 +53,12 @@ func (h *GitHubHandler) Handle(c *fiber.Ctx) error {
 	}
 
 	// 获取数据
-	data, err := h.fetchGitHubTrending(c.Context(), since)
+	cacheKey := fmt.Sprintf("github_%s", since)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchGitHubTrending(ctx, since)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -65,8 +71,8 @@ func (h *GitHubHandler) Handle(c *fiber.Ctx) error {
 		map[string]interface{}{ // params: 参数说明
 			"type": typeMap,
 		},
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -89,7 +95,7 @@ func (h *GitHubHandler) fetchGitHubTrending(ctx context.Context, since string) (
 
 	// 重试逻辑(3 次重试,指数退避)
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		body, err := httpClient.Get(apiURL, headers)
+		body, err := httpClient.GetContext(ctx, apiURL, headers)
 		if err == nil && len(body) > 0 {
 			return h.parseGitHubMarkdown(string(body)), nil
 		}
@@ -0,0 +1,342 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// GraphQL 查询端点
+//
+// 基于 graphql-go 实现,schema 为:
+//
+//	type Query {
+//	  platform(name: String!, type: String, limit: Int): [HotItem]
+//	}
+//	type HotItem { id title desc cover author hot timestamp url mobileUrl extra }
+//
+// 平台配置了字段映射(platforms.<name>.fields)时,映射中的别名与新名称作为额外的 JSON 字段加入 HotItem,
+// 按条目所属平台的映射取值(未配置的平台为 null);改名的规范字段仍可按原名查询,保证 schema 对所有平台一致
+//
+// 支持别名、变量与片段,如:
+//
+//	query($n: Int) {
+//	  b: platform(name: "bilibili", type: "188", limit: $n) { title url hot }
+//	  g: platform(name: "github") { title url }
+//	}
+
+const (
+	// graphqlMaxQueryLength 查询文本最大长度
+	graphqlMaxQueryLength = 4096
+	// graphqlMaxPlatforms 单次查询最多包含的 platform 字段数
+	graphqlMaxPlatforms = 10
+	// graphqlMaxLimit 单个 platform 字段的最大条数,未指定 limit 时也以此截断
+	graphqlMaxLimit = 50
+)
+
+// graphqlJSON 原样输出的标量,用于 hot、timestamp、extra 等类型不固定的字段
+var graphqlJSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "任意 JSON 值",
+	Serialize:   func(value interface{}) interface{} { return value },
+})
+
+// graphqlFieldNamePattern GraphQL 字段名的合法格式,不合法的映射名称不加入 schema
+var graphqlFieldNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// graphqlHotItemFields 热榜条目的规范字段,字段名与 HotData 的 JSON 字段名一致(默认解析器按 json 标签取值)
+func graphqlHotItemFields() graphql.Fields {
+	return graphql.Fields{
+		"id":        &graphql.Field{Type: graphql.String},
+		"title":     &graphql.Field{Type: graphql.String},
+		"desc":      &graphql.Field{Type: graphql.String},
+		"cover":     &graphql.Field{Type: graphql.String},
+		"author":    &graphql.Field{Type: graphql.String},
+		"hot":       &graphql.Field{Type: graphqlJSON},
+		"timestamp": &graphql.Field{Type: graphqlJSON},
+		"url":       &graphql.Field{Type: graphql.String},
+		"mobileUrl": &graphql.Field{Type: graphql.String},
+		"extra":     &graphql.Field{Type: graphqlJSON},
+	}
+}
+
+// graphQLSchema 返回注册表的 GraphQL schema,首次调用时构建
+// HotItem 包含平台字段映射中的名称,因此 schema 随配置而定,不能全局共用
+func (r *Registry) graphQLSchema() graphql.Schema {
+	r.graphqlOnce.Do(func() {
+		r.graphqlSchema = mustGraphQLSchema(r.mappedFieldNames())
+	})
+	return r.graphqlSchema
+}
+
+// mappedFieldNames 收集各平台字段映射中的别名与新名称(去重、排序)
+// 与规范字段重名或不是合法 GraphQL 字段名的名称跳过
+func (r *Registry) mappedFieldNames() []string {
+	canonical := graphqlHotItemFields()
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if _, ok := canonical[name]; ok || seen[name] || !graphqlFieldNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	for path := range r.handlers {
+		mapping := r.fetcher.FieldMapping(strings.TrimPrefix(path, "/"))
+		for _, aliases := range mapping.Aliases {
+			for _, alias := range aliases {
+				add(alias)
+			}
+		}
+		for _, newName := range mapping.Rename {
+			add(newName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// graphqlMappedItems 按平台字段映射展开条目,规范字段保留,映射出的别名与新名称供 HotItem 的额外字段取值
+func graphqlMappedItems(items []models.HotData, mapping models.FieldMapping) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		fields, err := models.FieldMapping{}.Expand(item)
+		if err != nil {
+			return nil, err
+		}
+		mapped, err := mapping.Expand(item)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range mapped {
+			if _, ok := fields[name]; !ok {
+				fields[name] = value
+			}
+		}
+
+		// 使用 UseNumber 保留数字原始精度
+		values := make(map[string]interface{}, len(fields))
+		for name, raw := range fields {
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.UseNumber()
+			var value interface{}
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+			values[name] = value
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
+
+// graphqlContextKey 单次查询的执行上下文键
+type graphqlContextKey struct{}
+
+// graphqlExecution 单次查询的执行上下文: 所属注册表与并发信号量
+type graphqlExecution struct {
+	registry  *Registry
+	semaphore chan struct{}
+}
+
+// graphqlRequest GraphQL 请求体
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// handleGraphQL GraphQL 查询处理器
+// 支持 POST {"query": "...", "variables": {...}} 与 GET ?query=...
+// 各 platform 字段并发执行,复用平台路由的缓存与中间件
+func (r *Registry) handleGraphQL(c *fiber.Ctx) error {
+	var req graphqlRequest
+	if c.Method() == fiber.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return graphqlFail(c, "variables 格式错误: "+err.Error())
+			}
+		}
+	} else if err := c.BodyParser(&req); err != nil {
+		return graphqlFail(c, "请求体格式错误: "+err.Error())
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return graphqlFail(c, "query 不能为空")
+	}
+	if len(req.Query) > graphqlMaxQueryLength {
+		return graphqlFail(c, fmt.Sprintf("query 长度不能超过 %d", graphqlMaxQueryLength))
+	}
+
+	// 执行前先统计 platform 字段数,拒绝过于复杂的查询
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return graphqlFail(c, err.Error())
+	}
+	if countGraphQLPlatforms(doc) > graphqlMaxPlatforms {
+		return graphqlFail(c, fmt.Sprintf("查询过于复杂: 最多包含 %d 个 platform 字段", graphqlMaxPlatforms))
+	}
+
+	ctx := context.WithValue(c.UserContext(), graphqlContextKey{}, &graphqlExecution{
+		registry:  r,
+		semaphore: make(chan struct{}, batchConcurrency),
+	})
+	result := graphql.Do(graphql.Params{
+		Schema:         r.graphQLSchema(),
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+
+	// 校验失败等请求级错误: 没有 data
+	if result.Data == nil && len(result.Errors) > 0 {
+		c.Status(fiber.StatusBadRequest)
+	}
+	return c.JSON(result)
+}
+
+// resolvePlatform 解析 platform 字段: 在后台调用平台路由,返回等待结果的 thunk
+// graphql-go 会在所有字段都返回后再依次调用 thunk,因此多个 platform 字段可以并发抓取
+func resolvePlatform(p graphql.ResolveParams) (interface{}, error) {
+	exec, ok := p.Context.Value(graphqlContextKey{}).(*graphqlExecution)
+	if !ok {
+		return nil, fmt.Errorf("缺少 GraphQL 执行上下文")
+	}
+
+	name, _ := p.Args["name"].(string)
+	path := "/" + strings.TrimPrefix(name, "/")
+	if _, ok := exec.registry.handlers[path]; !ok || name == "" {
+		return nil, fmt.Errorf("未知的平台: %q", name)
+	}
+
+	limit := graphqlMaxLimit
+	if value, ok := p.Args["limit"]; ok {
+		n, ok := value.(int)
+		if !ok || n <= 0 {
+			return nil, fmt.Errorf("limit 必须是正整数")
+		}
+		if n < limit {
+			limit = n
+		}
+	}
+
+	target := path
+	if listType, ok := p.Args["type"].(string); ok {
+		target += "?" + url.Values{"type": {listType}}.Encode()
+	}
+
+	type result struct {
+		items interface{}
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		exec.semaphore <- struct{}{}
+		defer func() { <-exec.semaphore }()
+
+		resp, err := exec.registry.invoke(p.Context, target, "DailyHotApi/GraphQL")
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		items := resp.Data
+		if len(items) > limit {
+			items = items[:limit]
+		}
+
+		// 进程内调用返回规范字段,平台配置了字段映射时在这里展开
+		mapping := exec.registry.fetcher.FieldMapping(strings.TrimPrefix(path, "/"))
+		if mapping.IsZero() {
+			done <- result{items: items}
+			return
+		}
+		mapped, err := graphqlMappedItems(items, mapping)
+		done <- result{items: mapped, err: err}
+	}()
+
+	return func() (interface{}, error) {
+		res := <-done
+		if res.err != nil {
+			return nil, res.err
+		}
+		return res.items, nil
+	}, nil
+}
+
+// countGraphQLPlatforms 统计文档中 platform 字段的数量(包括片段中的字段)
+// 响应键相同的字段会被合并执行,因此这是实际抓取次数的上限
+func countGraphQLPlatforms(doc *ast.Document) int {
+	count := 0
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if field, ok := p.Node.(*ast.Field); ok && field.Name != nil && field.Name.Value == "platform" {
+						count++
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return count
+}
+
+// graphqlFail 返回请求级错误(查询无法执行)
+func graphqlFail(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"data":   nil,
+		"errors": []fiber.Map{{"message": message}},
+	})
+}
+
+// mustGraphQLSchema 构建 GraphQL schema,mapped 为 HotItem 的额外字段(平台字段映射中的名称)
+// 字段名已校验,构建失败说明代码有误
+func mustGraphQLSchema(mapped []string) graphql.Schema {
+	fields := graphqlHotItemFields()
+	for _, name := range mapped {
+		fields[name] = &graphql.Field{Type: graphqlJSON}
+	}
+	hotItem := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "HotItem",
+		Fields: fields,
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"platform": &graphql.Field{
+					Type: graphql.NewList(hotItem),
+					Args: graphql.FieldConfigArgument{
+						"name":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+						"type":  &graphql.ArgumentConfig{Type: graphql.String},
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: resolvePlatform,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		panic(fmt.Sprintf("构建 GraphQL schema 失败: %v", err))
+	}
+	return schema
+}
@@ -0,0 +1,231 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dailyhot/api/internal/config"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeGraphQLHandler 返回固定条数的平台处理器
+type fakeGraphQLHandler struct {
+	count int
+}
+
+func (h *fakeGraphQLHandler) GetPath() string { return "/fake" }
+
+func (h *fakeGraphQLHandler) Handle(c *fiber.Ctx) error {
+	items := make([]models.HotData, h.count)
+	for i := range items {
+		items[i] = models.HotData{
+			ID:    fmt.Sprint(i),
+			Title: fmt.Sprintf("标题 %d %s", i, c.Query("type")),
+			URL:   fmt.Sprintf("https://example.com/%d", i),
+			Hot:   i * 10,
+		}
+	}
+	return c.JSON(models.SuccessResponse("fake", "测试", "热榜", "", "", nil, items, false))
+}
+
+func newGraphQLTestApp(t *testing.T) *fiber.App {
+	t.Helper()
+	handler := &fakeGraphQLHandler{count: 60}
+	r := NewRegistry(newTestFetcher(t))
+	r.handlers[handler.GetPath()] = handler
+	app := fiber.New()
+	r.app = app
+	app.Get(handler.GetPath(), handler.Handle)
+	app.Get("/graphql", r.handleGraphQL)
+	app.Post("/graphql", r.handleGraphQL)
+	return app
+}
+
+type graphqlTestResult struct {
+	Data   map[string][]map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path"`
+	} `json:"errors"`
+}
+
+func postGraphQL(t *testing.T, app *fiber.App, query string, variables map[string]interface{}) (int, graphqlTestResult) {
+	t.Helper()
+	body, _ := json.Marshal(fiber.Map{"query": query, "variables": variables})
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var result graphqlTestResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, result
+}
+
+func TestGraphQLLimit(t *testing.T) {
+	app := newGraphQLTestApp(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"未指定 limit 截断到上限", `{ platform(name: "fake") { title } }`, graphqlMaxLimit},
+		{"limit 小于上限", `{ platform(name: "fake", limit: 3) { title } }`, 3},
+		{"limit 超过上限", `{ platform(name: "fake", limit: 500) { title } }`, graphqlMaxLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, result := postGraphQL(t, app, tt.query, nil)
+			if status != fiber.StatusOK || len(result.Errors) > 0 {
+				t.Fatalf("status = %d, errors = %+v", status, result.Errors)
+			}
+			if got := len(result.Data["platform"]); got != tt.want {
+				t.Errorf("len = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLInvalidLimit(t *testing.T) {
+	status, result := postGraphQL(t, newGraphQLTestApp(t), `{ a: platform(name: "fake", limit: 0) { title } b: platform(name: "fake", limit: 1) { title } }`, nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if result.Data["a"] != nil {
+		t.Errorf("a = %v, want null", result.Data["a"])
+	}
+	if len(result.Data["b"]) != 1 {
+		t.Errorf("len(b) = %d, want 1", len(result.Data["b"]))
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "limit") {
+		t.Fatalf("errors = %+v, want one limit error", result.Errors)
+	}
+	if len(result.Errors[0].Path) != 1 || result.Errors[0].Path[0] != "a" {
+		t.Errorf("path = %v, want [a]", result.Errors[0].Path)
+	}
+}
+
+func TestGraphQLSelectionAndVariables(t *testing.T) {
+	query := `query($n: Int, $t: String) { platform(name: "fake", type: $t, limit: $n) { id title } }`
+	status, result := postGraphQL(t, newGraphQLTestApp(t), query, map[string]interface{}{"n": 2, "t": "hour"})
+	if status != fiber.StatusOK || len(result.Errors) > 0 {
+		t.Fatalf("status = %d, errors = %+v", status, result.Errors)
+	}
+
+	items := result.Data["platform"]
+	if len(items) != 2 {
+		t.Fatalf("len = %d, want 2", len(items))
+	}
+	if len(items[0]) != 2 || items[0]["id"] != "0" || items[0]["title"] != "标题 0 hour" {
+		t.Errorf("item = %v, want only id and title", items[0])
+	}
+}
+
+func TestGraphQLUnknownPlatform(t *testing.T) {
+	status, result := postGraphQL(t, newGraphQLTestApp(t), `{ platform(name: "nope") { title } }`, nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "未知的平台") {
+		t.Errorf("errors = %+v, want unknown platform", result.Errors)
+	}
+}
+
+func TestGraphQLRejectsQuery(t *testing.T) {
+	var tooMany strings.Builder
+	tooMany.WriteString("{")
+	for i := 0; i <= graphqlMaxPlatforms; i++ {
+		fmt.Fprintf(&tooMany, ` p%d: platform(name: "fake") { title }`, i)
+	}
+	tooMany.WriteString(" }")
+
+	// 片段中的字段同样计数
+	var tooManyInFragment strings.Builder
+	tooManyInFragment.WriteString("{ ...f } fragment f on Query {")
+	for i := 0; i <= graphqlMaxPlatforms; i++ {
+		fmt.Fprintf(&tooManyInFragment, ` p%d: platform(name: "fake") { title }`, i)
+	}
+	tooManyInFragment.WriteString(" }")
+
+	tooLong := `{ platform(name: "fake") { title } }` + strings.Repeat(" ", graphqlMaxQueryLength)
+
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"空查询", "  ", "不能为空"},
+		{"超过长度", tooLong, "长度"},
+		{"platform 字段过多", tooMany.String(), "过于复杂"},
+		{"片段中 platform 字段过多", tooManyInFragment.String(), "过于复杂"},
+		{"语法错误", `{ platform(name: "fake" { title }`, "Syntax Error"},
+		{"未闭合", `{ platform(name: "fake") { title }`, "Syntax Error"},
+		{"未知字段", `{ foo }`, "foo"},
+		{"未知子字段", `{ platform(name: "fake") { nope } }`, "nope"},
+		{"缺少必填参数", `{ platform { title } }`, "name"},
+		{"缺少选择集", `{ platform(name: "fake") }`, "selection"},
+		{"mutation", `mutation { platform(name: "fake") { title } }`, "mutation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, result := postGraphQL(t, newGraphQLTestApp(t), tt.query, nil)
+			if status != fiber.StatusBadRequest {
+				t.Errorf("status = %d, want 400", status)
+			}
+			if result.Data != nil {
+				t.Errorf("data = %v, want null", result.Data)
+			}
+			if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, tt.message) {
+				t.Errorf("errors = %+v, want message containing %q", result.Errors, tt.message)
+			}
+		})
+	}
+}
+
+func TestGraphQLGet(t *testing.T) {
+	query := `{ platform(name: "fake", limit: 1) { title } }`
+	req := httptest.NewRequest("GET", "/graphql?query="+strings.ReplaceAll(query, " ", "%20"), nil)
+	resp, err := newGraphQLTestApp(t).Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestGraphQLFieldMapping(t *testing.T) {
+	handler := &fakeGraphQLHandler{count: 2}
+	r := NewRegistry(newPlatformTestFetcher(t, map[string]config.PlatformConfig{
+		"fake": {Fields: config.FieldsConfig{
+			Aliases: map[string][]string{"url": {"link", "bad-name"}},
+			Rename:  map[string]string{"title": "name"},
+		}},
+	}))
+	r.handlers[handler.GetPath()] = handler
+	app := fiber.New()
+	r.app = app
+	app.Get(handler.GetPath(), handler.Handle)
+	app.Post("/graphql", r.handleGraphQL)
+
+	// 规范字段与映射出的名称都可以查询
+	status, result := postGraphQL(t, app, `{ platform(name: "fake", limit: 1) { title url hot name link } }`, nil)
+	if status != fiber.StatusOK || len(result.Errors) > 0 {
+		t.Fatalf("status = %d, errors = %v", status, result.Errors)
+	}
+	item := result.Data["platform"][0]
+	if item["name"] != item["title"] || item["link"] != "https://example.com/0" || item["hot"] != float64(0) {
+		t.Errorf("item = %v, want mapped fields alongside canonical ones", item)
+	}
+}
@@ -11,6 +11,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// guokrTypeMap 分类映射,all 为全站最新文章,其余为学科分类的 subject_key
+var guokrTypeMap = map[string]string{
+	"all":        "热门文章",
+	"biology":    "生物",
+	"medicine":   "医学",
+	"physics":    "物理",
+	"astronomy":  "天文",
+	"earth":      "地学",
+	"chemistry":  "化学",
+	"psychology": "心理",
+	"technology": "科技",
+	"food":       "食品",
+}
+
+// guokrArticlesURL 文章列表接口
+var guokrArticlesURL = "https://www.guokr.com/beta/proxy/science_api/articles"
+
 // GuokrHandler 果壳处理器
 type GuokrHandler struct {
 	fetcher *service.Fetcher
@@ -30,33 +47,49 @@ func (h *GuokrHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *GuokrHandler) Handle(c *fiber.Ctx) error {
-	// 获取缓存标志
+	// 获取分类与缓存标志
+	articleType := c.Query("type", "all")
+	if _, ok := guokrTypeMap[articleType]; !ok {
+		articleType = "all"
+	}
 	noCache := c.Query("cache") == "false"
 
-	// 获取数据
-	data, err := h.fetchGuokr(c.Context())
+	// 获取数据(全站保持原缓存键)
+	cacheKey := "guokr"
+	if articleType != "all" {
+		cacheKey = fmt.Sprintf("guokr_%s", articleType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchGuokr(ctx, articleType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
-		"guokr",                  // name: 平台调用名称
-		"果壳",                     // title: 平台显示名称
-		"热门文章",                   // type: 榜单类型
-		"发现果壳平台科技热门文章",           // description: 平台描述
-		"https://www.guokr.com/", // link: 官方链接
-		nil,                      // params: 无参数映射
-		data,                     // data: 热榜数据
-		!noCache,                 // fromCache: 是否来自缓存
+		"guokr",                   // name: 平台调用名称
+		"果壳",                      // title: 平台显示名称
+		guokrTypeMap[articleType], // type: 榜单类型
+		"发现果壳平台科技热门文章",            // description: 平台描述
+		"https://www.guokr.com/",  // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": guokrTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
 // fetchGuokr 从果壳 API 获取数据
-func (h *GuokrHandler) fetchGuokr(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://www.guokr.com/beta/proxy/science_api/articles?limit=30"
+// 学科分类通过 retrieve_type=by_subject 与 subject_key 过滤
+func (h *GuokrHandler) fetchGuokr(ctx context.Context, articleType string) ([]models.HotData, error) {
+	apiURL := guokrArticlesURL + "?limit=30"
+	if articleType != "all" {
+		apiURL += "&retrieve_type=by_subject&subject_key=" + articleType
+	}
 
 	// 发起 HTTP 请求(需要特定 User-Agent)
 	httpClient := h.fetcher.GetHTTPClient()
@@ -64,7 +97,7 @@ func (h *GuokrHandler) fetchGuokr(ctx context.Context) ([]models.HotData, error)
 		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.0.0",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求果壳 API 失败: %w", err)
 	}
@@ -84,8 +117,17 @@ func (h *GuokrHandler) transformData(items []GuokrItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
-		// 时间戳转换
-		timestamp := item.DateModified
+		// 优先使用发布时间,没有时退回修改时间
+		timestamp := item.DateCreated
+		if timestamp == "" {
+			timestamp = item.DateModified
+		}
+
+		// 封面优先使用小图,没有时退回大图
+		cover := item.SmallImage
+		if cover == "" {
+			cover = item.Image
+		}
 
 		// 作者昵称
 		author := ""
@@ -97,7 +139,7 @@ func (h *GuokrHandler) transformData(items []GuokrItem) []models.HotData {
 			ID:        strconv.FormatInt(item.ID, 10),
 			Title:     item.Title,
 			Desc:      item.Summary,
-			Cover:     item.SmallImage,
+			Cover:     cover,
 			Author:    author,
 			Timestamp: timestamp,
 			URL:       fmt.Sprintf("https://www.guokr.com/article/%d", item.ID),
@@ -118,7 +160,9 @@ type GuokrItem struct {
 	Title        string       `json:"title"`         // 标题
 	Summary      string       `json:"summary"`       // 摘要
 	SmallImage   string       `json:"small_image"`   // 封面图
+	Image        string       `json:"image"`         // 大图
 	Author       *GuokrAuthor `json:"author"`        // 作者信息
+	DateCreated  string       `json:"date_created"`  // 发布时间
 	DateModified string       `json:"date_modified"` // 修改时间
 }
 
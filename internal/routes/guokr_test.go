@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// guokrFixture 文章列表接口响应(节选),接口直接返回数组
+const guokrFixture = `[
+  {"id":2012345,"title":"为什么猫咪喜欢钻纸箱？","summary":"纸箱能给猫带来安全感。","small_image":"https://3-im.guokr.com/small.jpg","image":"https://3-im.guokr.com/large.jpg","author":{"nickname":"科学松鼠会","avatar":{"large":"https://3-im.guokr.com/avatar.jpg"}},"date_created":"2024-05-01T10:30:00+08:00","date_modified":"2024-05-02T08:00:00+08:00","subject":{"key":"biology","name":"生物"}},
+  {"id":2012346,"title":"黑洞照片背后的故事","summary":"","small_image":"","image":"https://3-im.guokr.com/bh.jpg","author":null,"date_created":"","date_modified":"2024-04-30T20:00:00+08:00"}
+]`
+
+func TestGuokrTransformData(t *testing.T) {
+	var items []GuokrItem
+	if err := json.Unmarshal([]byte(guokrFixture), &items); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &GuokrHandler{}
+	data := h.transformData(items)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "2012345" || first.Title != "为什么猫咪喜欢钻纸箱？" || first.Desc != "纸箱能给猫带来安全感。" || first.Author != "科学松鼠会" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://3-im.guokr.com/small.jpg" || first.Timestamp != "2024-05-01T10:30:00+08:00" {
+		t.Errorf("Cover = %q, Timestamp = %v", first.Cover, first.Timestamp)
+	}
+	if first.URL != "https://www.guokr.com/article/2012345" || first.MobileURL != "https://m.guokr.com/article/2012345" {
+		t.Errorf("URL = %q, MobileURL = %q", first.URL, first.MobileURL)
+	}
+
+	// 没有小图与发布时间时退回大图与修改时间,作者为空
+	second := data[1]
+	if second.Cover != "https://3-im.guokr.com/bh.jpg" || second.Timestamp != "2024-04-30T20:00:00+08:00" || second.Author != "" {
+		t.Errorf("data[1] = %+v", second)
+	}
+}
+
+func TestGuokrFetchSubject(t *testing.T) {
+	var query string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, guokrFixture)
+	}))
+	defer upstream.Close()
+
+	original := guokrArticlesURL
+	guokrArticlesURL = upstream.URL
+	defer func() { guokrArticlesURL = original }()
+
+	h := &GuokrHandler{fetcher: newTestFetcher(t)}
+	tests := []struct {
+		articleType string
+		wantQuery   string
+	}{
+		{"all", "limit=30"},
+		{"biology", "limit=30&retrieve_type=by_subject&subject_key=biology"},
+	}
+	for _, tt := range tests {
+		data, err := h.fetchGuokr(context.Background(), tt.articleType)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.articleType, err)
+		}
+		if query != tt.wantQuery || len(data) != 2 {
+			t.Errorf("%s: query = %q with %d items, want %q", tt.articleType, query, len(data), tt.wantQuery)
+		}
+	}
+}
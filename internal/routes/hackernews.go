@@ -34,9 +34,12 @@ func (h *HackerNewsHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchHackerNews(c.Context())
+	cacheKey := "hackernews"
+	data, fromCache, err := h.fetcher.FetchWithFallback(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, h.sources(), func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchHackerNews(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -48,7 +51,7 @@ func (h *HackerNewsHandler) Handle(c *fiber.Ctx) error {
 		"https://news.ycombinator.com/", // link: 官方链接
 		nil,                             // params: 无参数映射
 		data,                            // data: 热榜数据
-		!noCache,                        // fromCache: 是否来自缓存
+		fromCache,                       // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -64,11 +67,24 @@ func (h *HackerNewsHandler) fetchHackerNews(ctx context.Context) ([]models.HotDa
 		"Accept":     "application/json",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求 Hacker News 失败: %w", err)
 	}
 
+	return h.parseHackerNews(body)
+}
+
+// sources 备用源解析策略: Algolia 接口按主源格式解析,其余地址按订阅源解析(如 hnrss.org)
+func (h *HackerNewsHandler) sources() service.SourceParsers {
+	return service.SourceParsers{
+		"hn.algolia.com": h.parseHackerNews,
+		"*":              parseFeedSource,
+	}
+}
+
+// parseHackerNews 解析 Algolia 搜索接口的响应
+func (h *HackerNewsHandler) parseHackerNews(body []byte) ([]models.HotData, error) {
 	var apiResp hackerNewsAlgoliaResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return nil, fmt.Errorf("解析 Hacker News 响应失败: %w", err)
@@ -3,14 +3,25 @@ package routes
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	httpclient "github.com/dailyhot/api/internal/http"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
 )
 
+// helloGitHubVolumeCacheDuration 月刊缓存时长
+// 已发布的期号内容不再变化,最新一期每月更新一次,都可以长时间缓存
+const helloGitHubVolumeCacheDuration = 24 * time.Hour
+
+// errHelloGitHubVolumeNotFound 期号不存在
+var errHelloGitHubVolumeNotFound = errors.New("HelloGitHub 月刊期号不存在")
+
 // HelloGitHubHandler HelloGitHub处理器
 type HelloGitHubHandler struct {
 	fetcher *service.Fetcher
@@ -30,13 +41,24 @@ func (h *HelloGitHubHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *HelloGitHubHandler) Handle(c *fiber.Ctx) error {
+	// ?volume= 查询月刊,缺省或为 latest 时取最新一期
+	if c.Context().QueryArgs().Has("volume") {
+		return h.handleVolume(c, c.Query("volume"))
+	}
+
 	// 支持排序: featured-精选, all-全部
 	sortType := c.Query("sort", "featured")
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("sort", sortType, isSlug); err != nil {
+		return paramError(c, err)
+	}
 
-	data, err := h.fetchHelloGitHubHot(c.Context(), sortType)
+	cacheKey := fmt.Sprintf("hellogithub_%s", sortType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchHelloGitHubHot(ctx, sortType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -47,16 +69,166 @@ func (h *HelloGitHubHandler) Handle(c *fiber.Ctx) error {
 		"https://hellogithub.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
+	))
+}
+
+// handleVolume 处理月刊请求
+func (h *HelloGitHubHandler) handleVolume(c *fiber.Ctx, volume string) error {
+	num := 0
+	if volume != "" && volume != "latest" {
+		n, err := strconv.Atoi(volume)
+		if err != nil || n <= 0 {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, "非法的月刊期号: "+volume))
+		}
+		num = n
+	}
+	noCache := c.Query("cache") == "false"
+
+	cacheKey := "hellogithub_volume_latest"
+	if num > 0 {
+		cacheKey = fmt.Sprintf("hellogithub_volume_%d", num)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, helloGitHubVolumeCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchVolume(ctx, num)
+	})
+	if errors.Is(err, errHelloGitHubVolumeNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, err.Error()))
+	}
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	// 最新一期的期号从数据中取
+	listType := "最新月刊"
+	if len(data) > 0 {
+		if n, ok := data[0].Extra["volume"]; ok {
+			listType = fmt.Sprintf("第 %v 期", n)
+		}
+	}
+
+	return c.JSON(models.SuccessResponse(
+		"hellogithub",
+		"HelloGitHub",
+		listType,
+		"HelloGitHub 月刊:分享 GitHub 上有趣、入门级的开源项目",
+		"https://hellogithub.com/periodical",
+		map[string]interface{}{
+			"volume": "月刊期号,缺省或 latest 为最新一期",
+		},
+		data,
+		fromCache,
 	))
 }
 
+// fetchVolume 获取指定期号的月刊内容,num 为 0 时先查询最新期号
+func (h *HelloGitHubHandler) fetchVolume(ctx context.Context, num int) ([]models.HotData, error) {
+	httpClient := h.fetcher.GetHTTPClient()
+
+	if num == 0 {
+		latest, err := h.fetchLatestVolume(ctx)
+		if err != nil {
+			return nil, err
+		}
+		num = latest
+	}
+
+	body, err := httpClient.GetContext(ctx, fmt.Sprintf("https://api.hellogithub.com/v1/periodical/volume/%d", num), nil)
+	if err != nil {
+		// 不存在的期号上游返回 404
+		var statusErr *httpclient.StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == fiber.StatusNotFound {
+			return nil, fmt.Errorf("%w: %d", errHelloGitHubVolumeNotFound, num)
+		}
+		return nil, fmt.Errorf("请求HelloGitHub月刊失败: %w", err)
+	}
+
+	var apiResp HelloGitHubVolumeResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析HelloGitHub月刊响应失败: %w", err)
+	}
+	if !apiResp.Success || len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("%w: %d", errHelloGitHubVolumeNotFound, num)
+	}
+
+	return h.transformVolume(apiResp.Data, num), nil
+}
+
+// fetchLatestVolume 查询最新一期的期号
+func (h *HelloGitHubHandler) fetchLatestVolume(ctx context.Context) (int, error) {
+	body, err := h.fetcher.GetHTTPClient().GetContext(ctx, "https://api.hellogithub.com/v1/periodical/", nil)
+	if err != nil {
+		return 0, fmt.Errorf("请求HelloGitHub月刊列表失败: %w", err)
+	}
+
+	var apiResp HelloGitHubPeriodicalResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return 0, fmt.Errorf("解析HelloGitHub月刊列表失败: %w", err)
+	}
+
+	latest := 0
+	for _, volume := range apiResp.Volumes {
+		if volume.Num > latest {
+			latest = volume.Num
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("HelloGitHub月刊列表%w", service.ErrEmptyData)
+	}
+	return latest, nil
+}
+
+// transformVolume 转换月刊数据,以 star 数作为热度
+func (h *HelloGitHubHandler) transformVolume(categories []HelloGitHubVolumeCategory, num int) []models.HotData {
+	result := make([]models.HotData, 0)
+
+	for _, category := range categories {
+		for _, item := range category.Items {
+			name := item.FullName
+			if name == "" {
+				name = item.Name
+			}
+
+			// full_name 形如 "owner/repo"
+			author := ""
+			if owner, _, ok := strings.Cut(item.FullName, "/"); ok {
+				author = owner
+			}
+
+			url := item.GithubURL
+			if url == "" {
+				url = "https://github.com/" + item.FullName
+			}
+
+			result = append(result, models.HotData{
+				ID:        item.RID,
+				Title:     name,
+				Desc:      strings.TrimSpace(item.Description),
+				Cover:     item.ImageURL,
+				Author:    author,
+				Hot:       item.Stars,
+				URL:       url,
+				MobileURL: fmt.Sprintf("https://hellogithub.com/repository/%s", item.RID),
+				Extra: map[string]interface{}{
+					"volume":   num,
+					"category": category.CategoryName,
+					"language": item.PrimaryLang,
+					"stars":    item.Stars,
+					"forks":    item.Forks,
+				},
+			})
+		}
+	}
+
+	return result
+}
+
 // fetchHelloGitHubHot 从HelloGitHub API 获取数据
 func (h *HelloGitHubHandler) fetchHelloGitHubHot(ctx context.Context, sortType string) ([]models.HotData, error) {
 	apiURL := fmt.Sprintf("https://abroad.hellogithub.com/v1/?sort_by=%s&tid=&page=1", sortType)
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求HelloGitHub API 失败: %w", err)
 	}
@@ -75,17 +247,7 @@ func (h *HelloGitHubHandler) transformData(items []HelloGitHubItem) []models.Hot
 
 	for _, item := range items {
 		// 处理UpdatedAt字段(可能是int64或string)
-		var timestamp string
-		switch v := item.UpdatedAt.(type) {
-		case float64:
-			timestamp = strconv.FormatInt(int64(v), 10)
-		case int64:
-			timestamp = strconv.FormatInt(v, 10)
-		case string:
-			timestamp = v
-		default:
-			timestamp = ""
-		}
+		timestamp := models.ToString(item.UpdatedAt)
 
 		hotData := models.HotData{
 			ID:        item.ItemID,
@@ -118,3 +280,36 @@ type HelloGitHubItem struct {
 	ClicksTotal int64       `json:"clicks_total"`
 	UpdatedAt   interface{} `json:"updated_at"` // 可能是int64或string
 }
+
+// HelloGitHubPeriodicalResponse 月刊列表响应
+type HelloGitHubPeriodicalResponse struct {
+	Success bool `json:"success"`
+	Volumes []struct {
+		Num int `json:"num"`
+	} `json:"volumes"`
+}
+
+// HelloGitHubVolumeResponse 月刊内容响应
+type HelloGitHubVolumeResponse struct {
+	Success bool                        `json:"success"`
+	Data    []HelloGitHubVolumeCategory `json:"data"`
+}
+
+// HelloGitHubVolumeCategory 月刊中的分类
+type HelloGitHubVolumeCategory struct {
+	CategoryName string                  `json:"category_name"`
+	Items        []HelloGitHubVolumeItem `json:"items"`
+}
+
+// HelloGitHubVolumeItem 月刊收录的项目
+type HelloGitHubVolumeItem struct {
+	RID         string `json:"rid"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	GithubURL   string `json:"github_url"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+	PrimaryLang string `json:"primary_lang"`
+	Stars       int64  `json:"stars"`
+	Forks       int64  `json:"forks"`
+}
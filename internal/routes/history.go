@@ -37,10 +37,19 @@ func (h *HistoryHandler) Handle(c *fiber.Ctx) error {
 	month := c.Query("month", fmt.Sprintf("%d", int(now.Month())))
 	day := c.Query("day", fmt.Sprintf("%d", now.Day()))
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("month", month, intRange(1, 12)); err != nil {
+		return paramError(c, err)
+	}
+	if err := validateParam("day", day, intRange(1, 31)); err != nil {
+		return paramError(c, err)
+	}
 
-	data, err := h.fetchHistory(c.Context(), month, day)
+	cacheKey := fmt.Sprintf("history_%s_%s", month, day)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchHistory(ctx, month, day)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -51,7 +60,7 @@ func (h *HistoryHandler) Handle(c *fiber.Ctx) error {
 		"https://baike.baidu.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -69,7 +78,7 @@ func (h *HistoryHandler) fetchHistory(ctx context.Context, month, day string) ([
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求历史数据 API 失败: %w", err)
 	}
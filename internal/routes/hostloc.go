@@ -4,13 +4,27 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
+	"github.com/dailyhot/api/internal/logger"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
+const hostlocUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36"
+
+// hostlocGuideURL 导读页面地址,后接导读类型
+var hostlocGuideURL = "https://hostloc.com/forum.php?mod=guide&view="
+
+// hostlocProxyURL 公开内容代理,后接原始页面地址
+var hostlocProxyURL = "https://r.jina.ai/"
+
 // HostlocHandler 全球主机交流处理器
 type HostlocHandler struct {
 	fetcher *service.Fetcher
@@ -32,10 +46,16 @@ func (h *HostlocHandler) GetPath() string {
 func (h *HostlocHandler) Handle(c *fiber.Ctx) error {
 	hostlocType := c.Query("type", "hot") // 默认最新热门
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", hostlocType, isSlug); err != nil {
+		return paramError(c, err)
+	}
 
-	data, err := h.fetchHostloc(c.Context(), hostlocType)
+	cacheKey := fmt.Sprintf("hostloc_%s", hostlocType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchHostloc(ctx, hostlocType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -46,7 +66,7 @@ func (h *HostlocHandler) Handle(c *fiber.Ctx) error {
 		"https://hostloc.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -64,18 +84,31 @@ func (h *HostlocHandler) getTypeName(typeID string) string {
 	return "最新热门"
 }
 
-// fetchHostloc 从全球主机交流 RSS 获取数据
+// fetchHostloc 获取导读列表
+// 配置了 platforms.hostloc.cookie 时直接带登录态抓取原始页面(可见登录才能看的板块);
+// 未配置或登录抓取失败时,回退到通过 r.jina.ai 抓取的公开内容
 func (h *HostlocHandler) fetchHostloc(ctx context.Context, hostlocType string) ([]models.HotData, error) {
-	apiURL := fmt.Sprintf("https://r.jina.ai/https://hostloc.com/forum.php?mod=guide&view=%s", hostlocType)
+	if cookie := h.fetcher.PlatformCookie("hostloc"); cookie != "" {
+		data, err := h.fetchWithCookie(ctx, hostlocType, cookie)
+		if err == nil && len(data) > 0 {
+			return data, nil
+		}
+		logger.Warn("全球主机交流登录态抓取失败,回退到公开内容",
+			zap.String("type", hostlocType),
+			zap.Error(err),
+		)
+	}
+
+	apiURL := hostlocProxyURL + hostlocGuideURL + hostlocType
 
 	httpClient := h.fetcher.GetHTTPClient()
 	headers := map[string]string{
-		"User-Agent":      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"User-Agent":      hostlocUserAgent,
 		"Accept":          "text/plain; charset=utf-8",
 		"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求全球主机交流页面失败: %w", err)
 	}
@@ -83,46 +116,168 @@ func (h *HostlocHandler) fetchHostloc(ctx context.Context, hostlocType string) (
 	return h.parseHostlocMarkdown(string(body)), nil
 }
 
-var hostlocThreadPattern = regexp.MustCompile(`\[(?P<title>[^\]]+)\]\((https://hostloc\.com/thread-\d+-\d+-\d+\.html)\)`)
+// fetchWithCookie 带登录态 Cookie 抓取导读页面
+// Cookie 只发往 hostloc.com,不经过第三方代理
+func (h *HostlocHandler) fetchWithCookie(ctx context.Context, hostlocType, cookie string) ([]models.HotData, error) {
+	apiURL := hostlocGuideURL + hostlocType
 
-func (h *HostlocHandler) parseHostlocMarkdown(markdown string) []models.HotData {
-	matches := hostlocThreadPattern.FindAllStringSubmatch(markdown, -1)
-	seen := make(map[string]struct{})
-	result := make([]models.HotData, 0, len(matches))
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
+		"User-Agent":      hostlocUserAgent,
+		"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8",
+		"Referer":         "https://hostloc.com/",
+		"Cookie":          cookie,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求全球主机交流页面失败: %w", err)
+	}
 
-	for _, m := range matches {
-		if len(m) < 3 {
-			continue
-		}
-		title := strings.TrimSpace(m[1])
-		link := m[2]
-
-		// 过滤分页或辅助链接
-		if title == "" || len(title) <= 2 && strings.IndexFunc(title, func(r rune) bool {
-			return r > '9' || r < '0'
-		}) == -1 {
-			continue
+	html := string(body)
+	// 防 CC 验证页(需要执行 JS 计算 Cookie),说明 Cookie 已失效或不完整
+	if strings.Contains(html, "slowAES") {
+		return nil, fmt.Errorf("全球主机交流返回了防护验证页,请更新 Cookie")
+	}
+
+	return h.parseHostlocHTML(html)
+}
+
+// parseHostlocHTML 解析导读页面的帖子列表(Discuz 导读表格)
+func (h *HostlocHandler) parseHostlocHTML(html string) ([]models.HotData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("解析全球主机交流页面失败: %w", err)
+	}
+
+	result := make([]models.HotData, 0, 50)
+	doc.Find("tbody[id^=normalthread_]").Each(func(_ int, s *goquery.Selection) {
+		link := s.Find("a.xst").First()
+		title := strings.TrimSpace(link.Text())
+		href := link.AttrOr("href", "")
+		if title == "" || href == "" {
+			return
 		}
-		if strings.EqualFold(title, "new") || strings.HasPrefix(title, "阅读权限") {
-			continue
+		if !strings.HasPrefix(href, "http") {
+			href = "https://hostloc.com/" + strings.TrimPrefix(href, "/")
 		}
-		if _, ok := seen[link]; ok {
-			continue
+
+		// 发帖时间: 最近的帖子显示为"3 天前",完整时间在 span 的 title 中
+		timeText := s.Find("td.by em span[title]").First().AttrOr("title", "")
+		if timeText == "" {
+			timeText = s.Find("td.by em").First().Text()
 		}
-		seen[link] = struct{}{}
 
-		hotData := models.HotData{
-			ID:        link,
+		result = append(result, models.HotData{
+			ID:        href,
 			Title:     title,
-			URL:       link,
-			MobileURL: link,
-		}
-		result = append(result, hotData)
+			Author:    strings.TrimSpace(s.Find("td.by cite a").First().Text()),
+			Hot:       h.parseCount(s.Find("td.num a").First().Text()),
+			Timestamp: h.parseTime(timeText),
+			URL:       href,
+			MobileURL: href,
+			Extra: map[string]interface{}{
+				"views": h.parseCount(s.Find("td.num em").First().Text()),
+				"forum": strings.TrimSpace(s.Find(`td.by a[href^="forum-"]`).First().Text()),
+			},
+		})
+	})
 
-		if len(result) >= 30 {
-			break
+	return result, nil
+}
+
+var hostlocThreadPattern = regexp.MustCompile(`\[(?P<title>[^\]]+)\]\((https://hostloc\.com/thread-\d+-\d+-\d+\.html)\)`)
+
+// hostlocAuthorPattern 公开内容(Markdown 表格)中的作者链接
+var hostlocAuthorPattern = regexp.MustCompile(`\[([^\]]+)\]\(https://hostloc\.com/space-uid-\d+\.html\)`)
+
+// hostlocCountPattern 公开内容中的"回复 / 查看"
+var hostlocCountPattern = regexp.MustCompile(`(\d+)\s*/\s*(\d+)`)
+
+// hostlocDatePattern 公开内容中的发帖时间,如 2024-1-2 或 2024-1-2 10:30
+// 要求以单词边界开头,避免匹配到分页链接 thread-1234567-2-1 中的数字
+var hostlocDatePattern = regexp.MustCompile(`\b\d{4}-\d{1,2}-\d{1,2}(?: \d{1,2}:\d{2})?`)
+
+// parseHostlocMarkdown 解析 r.jina.ai 返回的 Markdown
+// 导读表格每行一个帖子,同一行中标题链接之后依次为版块、作者与发帖时间、回复/查看
+func (h *HostlocHandler) parseHostlocMarkdown(markdown string) []models.HotData {
+	seen := make(map[string]struct{})
+	result := make([]models.HotData, 0, 30)
+
+	for _, line := range strings.Split(markdown, "\n") {
+		for _, m := range hostlocThreadPattern.FindAllStringSubmatchIndex(line, -1) {
+			title := strings.TrimSpace(line[m[2]:m[3]])
+			link := line[m[4]:m[5]]
+
+			// 过滤分页或辅助链接
+			if title == "" || len(title) <= 2 && strings.IndexFunc(title, func(r rune) bool {
+				return r > '9' || r < '0'
+			}) == -1 {
+				continue
+			}
+			if strings.EqualFold(title, "new") || strings.HasPrefix(title, "阅读权限") {
+				continue
+			}
+			if _, ok := seen[link]; ok {
+				continue
+			}
+			seen[link] = struct{}{}
+
+			hotData := models.HotData{
+				ID:        link,
+				Title:     title,
+				URL:       link,
+				MobileURL: link,
+			}
+
+			// 标题之后的内容为该帖子的元信息
+			rest := line[m[1]:]
+			if author := hostlocAuthorPattern.FindStringSubmatch(rest); author != nil {
+				hotData.Author = strings.TrimSpace(author[1])
+			}
+			if date := hostlocDatePattern.FindString(rest); date != "" {
+				hotData.Timestamp = h.parseTime(date)
+			}
+			if counts := hostlocCountPattern.FindStringSubmatch(rest); counts != nil {
+				hotData.Hot = h.parseCount(counts[1])
+				hotData.Extra = map[string]interface{}{"views": h.parseCount(counts[2])}
+			}
+			result = append(result, hotData)
+
+			if len(result) >= 30 {
+				return result
+			}
 		}
 	}
 
 	return result
 }
+
+// parseCount 解析回复数、查看数
+func (h *HostlocHandler) parseCount(text string) int64 {
+	count, _ := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	return count
+}
+
+// parseTime 解析发帖时间(北京时间),Discuz 的日期不补零,如 2024-1-2 10:30
+func (h *HostlocHandler) parseTime(text string) interface{} {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		loc = time.Local
+	}
+	for _, layout := range []string{"2006-1-2 15:04:05", "2006-1-2 15:04", "2006-1-2"} {
+		if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return t.UnixMilli()
+		}
+	}
+
+	// "昨天 10:30"、"3 小时前" 等相对时间
+	if ts := timeutil.ParseTime(strings.ReplaceAll(text, "\u00a0", " ")); ts > 0 {
+		return ts
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dailyhot/api/internal/config"
+)
+
+// hostlocGuideFixture 登录后的导读页面(节选)
+const hostlocGuideFixture = `<html><body><div id="threadlist"><table>
+<tbody id="normalthread_1234567"><tr>
+  <th class="common"><a href="thread-1234567-1-1.html" target="_blank" class="xst">甲骨文 ARM 免费机又能开了</a></th>
+  <td class="by"><a href="forum-45-1.html" target="_blank">美国VPS综合讨论</a></td>
+  <td class="by"><cite><a href="space-uid-10001.html" c="1">loc_user</a></cite><em><span><span title="2024-5-1 10:30">3&nbsp;天前</span></span></em></td>
+  <td class="num"><a href="thread-1234567-1-1.html" class="xi2">128</a><em>4567</em></td>
+</tr></tbody>
+<tbody id="normalthread_1234568"><tr>
+  <th class="common"><a href="https://hostloc.com/thread-1234568-1-1.html" class="xst">出一台独服</a></th>
+  <td class="by"><a href="forum-49-1.html">交易</a></td>
+  <td class="by"><cite><a href="space-uid-10002.html">seller</a></cite><em><span>2024-4-30</span></em></td>
+  <td class="num"><a class="xi2">3</a><em>99</em></td>
+</tr></tbody>
+<tbody id="normalthread_1234569"><tr><th class="common"><a class="xst"></a></th></tr></tbody>
+</table></div></body></html>`
+
+// hostlocMarkdownFixture r.jina.ai 返回的公开导读内容(节选)
+const hostlocMarkdownFixture = `Title: 全球主机交流论坛 - 导读
+
+Markdown Content:
+| 标题 | 版块 | 作者 | 回复/查看 | 最后发表 |
+| --- | --- | --- | --- | --- |
+| [甲骨文 ARM 免费机又能开了](https://hostloc.com/thread-1234567-1-1.html) [2](https://hostloc.com/thread-1234567-2-1.html) | [美国VPS综合讨论](https://hostloc.com/forum-45-1.html) | [loc_user](https://hostloc.com/space-uid-10001.html) 2024-5-1 10:30 | 128 / 4567 | [replier](https://hostloc.com/space-uid-3.html) 2024-5-2 08:00 |
+| [new](https://hostloc.com/thread-1234568-1-1.html) [出一台独服](https://hostloc.com/thread-1234568-1-1.html) | [交易](https://hostloc.com/forum-49-1.html) | [seller](https://hostloc.com/space-uid-10002.html) 2024-4-30 | 3 / 99 | |
+| [甲骨文 ARM 免费机又能开了](https://hostloc.com/thread-1234567-1-1.html) | 重复行 | | | |
+`
+
+func TestHostlocParseHTML(t *testing.T) {
+	h := &HostlocHandler{}
+	data, err := h.parseHostlocHTML(hostlocGuideFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.URL != "https://hostloc.com/thread-1234567-1-1.html" || first.Title != "甲骨文 ARM 免费机又能开了" || first.Author != "loc_user" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	// 相对时间取 span title 中的完整时间
+	if first.Hot != int64(128) || first.Timestamp != int64(1714530600000) {
+		t.Errorf("Hot = %v, Timestamp = %v", first.Hot, first.Timestamp)
+	}
+	if first.Extra["views"] != int64(4567) || first.Extra["forum"] != "美国VPS综合讨论" {
+		t.Errorf("Extra = %v", first.Extra)
+	}
+
+	if data[1].URL != "https://hostloc.com/thread-1234568-1-1.html" || data[1].Timestamp != int64(1714406400000) {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+}
+
+func TestHostlocParseMarkdown(t *testing.T) {
+	h := &HostlocHandler{}
+	data := h.parseHostlocMarkdown(hostlocMarkdownFixture)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2: %+v", len(data), data)
+	}
+
+	first := data[0]
+	if first.Title != "甲骨文 ARM 免费机又能开了" || first.Author != "loc_user" || first.Timestamp != int64(1714530600000) {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Hot != int64(128) || first.Extra["views"] != int64(4567) {
+		t.Errorf("Hot = %v, Extra = %v", first.Hot, first.Extra)
+	}
+
+	// "new" 标记与分页链接不作为标题
+	if data[1].Title != "出一台独服" || data[1].Author != "seller" || data[1].Hot != int64(3) {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+}
+
+func TestHostlocFetchWithCookie(t *testing.T) {
+	tests := []struct {
+		name      string
+		cookie    string
+		direct    string // 直接抓取返回的页面
+		wantProxy bool
+	}{
+		{"登录态抓取", "cdb_auth=abc", hostlocGuideFixture, false},
+		{"未配置 Cookie", "", hostlocGuideFixture, true},
+		{"防护验证页", "cdb_auth=expired", `<script>var a=slowAES.decrypt(c,2,a,b);</script>`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var proxied bool
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/proxy/") {
+					proxied = true
+					// Cookie 不能发往第三方代理
+					if r.Header.Get("Cookie") != "" {
+						t.Errorf("proxy got Cookie %q", r.Header.Get("Cookie"))
+					}
+					fmt.Fprint(w, hostlocMarkdownFixture)
+					return
+				}
+				if r.Header.Get("Cookie") != tt.cookie || r.URL.Query().Get("view") != "hot" {
+					t.Errorf("direct request: Cookie = %q, view = %q", r.Header.Get("Cookie"), r.URL.Query().Get("view"))
+				}
+				fmt.Fprint(w, tt.direct)
+			}))
+			defer upstream.Close()
+
+			originalGuide, originalProxy := hostlocGuideURL, hostlocProxyURL
+			hostlocGuideURL = upstream.URL + "/forum.php?mod=guide&view="
+			hostlocProxyURL = upstream.URL + "/proxy/"
+			defer func() { hostlocGuideURL, hostlocProxyURL = originalGuide, originalProxy }()
+
+			platforms := map[string]config.PlatformConfig{"hostloc": {Cookie: tt.cookie}}
+			h := &HostlocHandler{fetcher: newPlatformTestFetcher(t, platforms)}
+			data, err := h.fetchHostloc(context.Background(), "hot")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if proxied != tt.wantProxy {
+				t.Errorf("proxied = %v, want %v", proxied, tt.wantProxy)
+			}
+			if len(data) != 2 {
+				t.Errorf("len(data) = %d, want 2", len(data))
+			}
+		})
+	}
+}
@@ -4,13 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
+// hupuBoardURLs 板块热帖页,type 为板块名时使用
+var hupuBoardURLs = map[string]string{
+	"bbs":    "https://bbs.hupu.com/all-gambia",
+	"nba":    "https://bbs.hupu.com/all-nba",
+	"soccer": "https://bbs.hupu.com/all-soccer",
+}
+
+// hupuDatumPattern 帖子的 "回复 / 浏览" 计数,如 "123 / 4.5万"
+var hupuDatumPattern = regexp.MustCompile(`^\s*(\d+)`)
+
+// hupuMonthDayTimePattern 帖子时间 "MM-DD HH:mm"(当年)
+var hupuMonthDayTimePattern = regexp.MustCompile(`^\d{1,2}-\d{1,2} \d{1,2}:\d{2}$`)
+
 // HupuHandler 虎扑处理器
 type HupuHandler struct {
 	fetcher *service.Fetcher
@@ -31,8 +49,12 @@ func (h *HupuHandler) GetPath() string {
 // Handle 处理请求
 func (h *HupuHandler) Handle(c *fiber.Ctx) error {
 	// 获取查询参数: 支持不同主题分区 (1-主干道, 6-恋爱区, 11-校园区, 12-历史区, 612-摄影区)
+	// 以及板块热帖 (bbs-步行街, nba-NBA, soccer-足球)
 	topicType := c.Query("type", "1")
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", topicType, isNumeric); err != nil {
+		return paramError(c, err)
+	}
 
 	// 主题分区映射
 	typeMap := map[string]string{
@@ -41,6 +63,10 @@ func (h *HupuHandler) Handle(c *fiber.Ctx) error {
 		"11":  "校园区",
 		"12":  "历史区",
 		"612": "摄影区",
+
+		"bbs":    "步行街热帖",
+		"nba":    "NBA热帖",
+		"soccer": "足球热帖",
 	}
 
 	typeName := typeMap[topicType]
@@ -49,9 +75,15 @@ func (h *HupuHandler) Handle(c *fiber.Ctx) error {
 	}
 
 	// 获取数据
-	data, err := h.fetchHupuHot(c.Context(), topicType)
+	cacheKey := fmt.Sprintf("hupu_%s", topicType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if boardURL, ok := hupuBoardURLs[topicType]; ok {
+			return h.fetchHupuBoard(ctx, boardURL)
+		}
+		return h.fetchHupuHot(ctx, topicType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -63,7 +95,7 @@ func (h *HupuHandler) Handle(c *fiber.Ctx) error {
 		"https://bbs.hupu.com/",                 // link: 官方链接
 		map[string]interface{}{"type": typeMap}, // params: 主题分区映射
 		data,                                    // data: 热榜数据
-		!noCache,                                // fromCache: 是否来自缓存
+		fromCache,                               // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -74,7 +106,7 @@ func (h *HupuHandler) fetchHupuHot(ctx context.Context, topicType string) ([]mod
 	apiURL := fmt.Sprintf("https://m.hupu.com/api/v2/bbs/topicThreads?topicId=%s&page=1", topicType)
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求虎扑 API 失败: %w", err)
 	}
@@ -87,6 +119,83 @@ func (h *HupuHandler) fetchHupuHot(ctx context.Context, topicType string) ([]mod
 	return h.transformData(apiResp.Data.TopicThreads), nil
 }
 
+// fetchHupuBoard 抓取板块热帖页
+func (h *HupuHandler) fetchHupuBoard(ctx context.Context, boardURL string) ([]models.HotData, error) {
+	body, err := h.fetcher.Get(ctx, boardURL, map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Referer":    "https://bbs.hupu.com/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求虎扑板块失败: %w", err)
+	}
+
+	data := h.parseBoardHTML(string(body))
+	if len(data) == 0 {
+		return nil, fmt.Errorf("虎扑板块页面%w", service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// parseBoardHTML 解析板块热帖列表
+// 每条帖子包含标题、"回复 / 浏览" 计数、作者和发帖时间
+func (h *HupuHandler) parseBoardHTML(html string) []models.HotData {
+	result := make([]models.HotData, 0)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return result
+	}
+
+	doc.Find(".bbs-sl-web-post-body").Each(func(i int, s *goquery.Selection) {
+		link := s.Find(".post-title a").First()
+		title := strings.TrimSpace(link.Text())
+		href := link.AttrOr("href", "")
+		if title == "" || href == "" {
+			return
+		}
+		if strings.HasPrefix(href, "/") {
+			href = "https://bbs.hupu.com" + href
+		}
+
+		// 帖子 ID: /123456.html
+		id := strings.TrimSuffix(href[strings.LastIndex(href, "/")+1:], ".html")
+
+		var replies int64
+		if matches := hupuDatumPattern.FindStringSubmatch(s.Find(".post-datum").Text()); len(matches) > 1 {
+			replies, _ = strconv.ParseInt(matches[1], 10, 64)
+		}
+
+		var timestamp interface{}
+		if ts := h.parseTime(s.Find(".post-time").Text()); ts > 0 {
+			timestamp = ts
+		}
+
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     title,
+			Author:    strings.TrimSpace(s.Find(".post-auth").Text()),
+			Hot:       replies,
+			Timestamp: timestamp,
+			URL:       href,
+			MobileURL: fmt.Sprintf("https://m.hupu.com/bbs/%s.html", id),
+		})
+	})
+
+	return result
+}
+
+// parseTime 解析帖子时间,补全 "MM-DD HH:mm" 缺少的年份
+func (h *HupuHandler) parseTime(text string) int64 {
+	text = strings.TrimSpace(text)
+	if hupuMonthDayTimePattern.MatchString(text) {
+		text = fmt.Sprintf("%d-%s", time.Now().Year(), text)
+		if t, err := time.ParseInLocation("2006-1-2 15:04", text, time.Local); err == nil {
+			return t.UnixMilli()
+		}
+	}
+	return timeutil.ParseTime(text)
+}
+
 // transformData 转换数据格式
 func (h *HupuHandler) transformData(items []HupuItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -37,9 +36,12 @@ func (h *HuxiuHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchHuxiuHot(c.Context())
+	cacheKey := "huxiu"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchHuxiuHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -51,7 +53,7 @@ func (h *HuxiuHandler) Handle(c *fiber.Ctx) error {
 		"https://www.huxiu.com/", // link: 官方链接
 		nil,                      // params: 无参数映射
 		data,                     // data: 热榜数据
-		!noCache,                 // fromCache: 是否来自缓存
+		fromCache,                // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -62,7 +64,7 @@ func (h *HuxiuHandler) fetchHuxiuHot(ctx context.Context) ([]models.HotData, err
 	apiURL := "https://www.huxiu.com/moment/"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求虎嗅失败: %w", err)
 	}
@@ -92,10 +94,10 @@ func (h *HuxiuHandler) parseHTML(html string) []models.HotData {
 
 	postNodes := extractHuxiuPosts(payload)
 	for _, post := range postNodes {
-		objectID := toString(post["object_id"])
-		content := toString(post["content"])
+		objectID := models.ToString(post["object_id"])
+		content := models.ToString(post["content"])
 		publishTime := post["publish_time"]
-		url := toString(post["url"])
+		url := models.ToString(post["url"])
 
 		if url == "" && objectID != "" {
 			url = fmt.Sprintf("https://www.huxiu.com/moment/%s.html", objectID)
@@ -107,7 +109,7 @@ func (h *HuxiuHandler) parseHTML(html string) []models.HotData {
 
 		author := ""
 		if userMap := toMap(post["user_info"]); userMap != nil {
-			author = toString(userMap["username"])
+			author = models.ToString(userMap["username"])
 		}
 
 		// 处理标题和描述
@@ -212,26 +214,6 @@ func toSlice(value interface{}) []interface{} {
 	return nil
 }
 
-func toString(value interface{}) string {
-	switch v := value.(type) {
-	case string:
-		return v
-	case float64:
-		return strconv.FormatFloat(v, 'f', -1, 64)
-	case json.Number:
-		return v.String()
-	case bool:
-		if v {
-			return "true"
-		}
-		return ""
-	case nil:
-		return ""
-	default:
-		return fmt.Sprintf("%v", v)
-	}
-}
-
 func toInt(value interface{}) int {
 	switch v := value.(type) {
 	case float64:
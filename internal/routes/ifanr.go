@@ -34,9 +34,12 @@ func (h *IfanrHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchIfanr(c.Context())
+	cacheKey := "ifanr"
+	data, fromCache, err := h.fetcher.FetchWithFallback(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, h.sources(), func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchIfanr(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -48,7 +51,7 @@ func (h *IfanrHandler) Handle(c *fiber.Ctx) error {
 		"https://www.ifanr.com/", // link: 官方链接
 		nil,                      // params: 无参数映射
 		data,                     // data: 热榜数据
-		!noCache,                 // fromCache: 是否来自缓存
+		fromCache,                // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -60,11 +63,24 @@ func (h *IfanrHandler) fetchIfanr(ctx context.Context) ([]models.HotData, error)
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求爱范儿 API 失败: %w", err)
 	}
 
+	return h.parseIfanr(body)
+}
+
+// sources 备用源解析策略: 爱范儿接口按主源格式解析,其余地址按订阅源解析(如 RSSHub)
+func (h *IfanrHandler) sources() service.SourceParsers {
+	return service.SourceParsers{
+		"sso.ifanr.com": h.parseIfanr,
+		"*":             parseFeedSource,
+	}
+}
+
+// parseIfanr 解析爱范儿快讯接口的响应
+func (h *IfanrHandler) parseIfanr(body []byte) ([]models.HotData, error) {
 	// 解析 JSON 响应
 	var apiResp IfanrAPIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
@@ -3,9 +3,11 @@ package routes
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/dailyhot/api/internal/models"
@@ -14,6 +16,26 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// ithomeTypeMap 榜单类型映射: type 参数 -> 榜单名称
+// 其他取值视为标签名,抓取 https://www.ithome.com/tag/<标签>/
+var ithomeTypeMap = map[string]string{
+	"hot":    "热榜",
+	"latest": "最新",
+	"lapin":  "辣品",
+}
+
+// ithomePageURLs 非热榜类型对应的页面
+var ithomePageURLs = map[string]string{
+	"latest": "https://www.ithome.com/list/",
+	"lapin":  "https://lapin.ithome.com/",
+}
+
+// ithomeArticlePattern 桌面端文章链接,如 https://www.ithome.com/0/760/123.htm
+var ithomeArticlePattern = regexp.MustCompile(`ithome\.com/0/(\d+)/(\d+)\.htm`)
+
+// ithomeLapinPattern 辣品商品链接,如 https://lapin.ithome.com/html/123456.htm 或 /html/123456.htm
+var ithomeLapinPattern = regexp.MustCompile(`^(?:https?://lapin\.ithome\.com)?/html/(\d+)\.htm`)
+
 // IthomeHandler IT之家处理器
 type IthomeHandler struct {
 	fetcher *service.Fetcher
@@ -33,25 +55,46 @@ func (h *IthomeHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *IthomeHandler) Handle(c *fiber.Ctx) error {
+	// 获取查询参数: 榜单类型,或任意标签名
+	listType := strings.TrimSpace(c.Query("type", "hot"))
+	if err := validateParam("type", listType, shortText(20)); err != nil {
+		return paramError(c, err)
+	}
 	// 获取缓存标志
 	noCache := c.Query("cache") == "false"
 
-	// 获取热榜数据
-	data, err := h.fetchIthomeHot(c.Context())
+	label, ok := ithomeTypeMap[listType]
+	if !ok {
+		label = "标签 · " + listType
+	}
+
+	// 获取数据(热榜沿用原缓存键)
+	cacheKey := "ithome"
+	if listType != "hot" {
+		cacheKey = fmt.Sprintf("ithome_%s", listType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if listType == "hot" {
+			return h.fetchIthomeHot(ctx)
+		}
+		return h.fetchIthomeList(ctx, listType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
 		"ithome",                  // name: 平台调用名称
 		"IT之家",                    // title: 平台显示名称
-		"热榜",                      // type: 榜单类型
+		label,                     // type: 榜单类型
 		"发现IT之家热门资讯",              // description: 平台描述
 		"https://www.ithome.com/", // link: 官方链接
-		nil,                       // params: 无参数映射
-		data,                      // data: 热榜数据
-		!noCache,                  // fromCache: 是否来自缓存
+		map[string]interface{}{ // params: 参数说明
+			"type": ithomeTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -62,7 +105,7 @@ func (h *IthomeHandler) fetchIthomeHot(ctx context.Context) ([]models.HotData, e
 	apiURL := "https://m.ithome.com/rankm/"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求IT之家失败: %w", err)
 	}
@@ -71,6 +114,158 @@ func (h *IthomeHandler) fetchIthomeHot(ctx context.Context) ([]models.HotData, e
 	return h.parseHTML(string(body)), nil
 }
 
+// fetchIthomeList 获取最新资讯或标签页文章列表
+func (h *IthomeHandler) fetchIthomeList(ctx context.Context, listType string) ([]models.HotData, error) {
+	pageURL, ok := ithomePageURLs[listType]
+	if !ok {
+		pageURL = fmt.Sprintf("https://www.ithome.com/tag/%s/", url.PathEscape(listType))
+	}
+
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, pageURL, map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Referer":    "https://www.ithome.com/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求IT之家页面失败: %w", err)
+	}
+
+	var data []models.HotData
+	if listType == "lapin" {
+		data, err = h.parseLapin(string(body), time.Now())
+	} else {
+		data, err = h.parseList(string(body))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("IT之家页面没有文章: %w", service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// parseList 解析桌面端文章列表
+// 最新页为 ul.datel 下的 a.t 标题与 i 时间;标签页为 ul.bl 下的 h2 标题、div.m 摘要与封面图
+func (h *IthomeHandler) parseList(html string) ([]models.HotData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("解析IT之家页面失败: %w", err)
+	}
+
+	result := make([]models.HotData, 0)
+	seen := make(map[string]bool)
+	doc.Find("ul.datel li, ul.bl li").Each(func(i int, s *goquery.Selection) {
+		link := s.Find("a.t, h2 a").First()
+		title := strings.TrimSpace(link.Text())
+		href, _ := link.Attr("href")
+		if title == "" || href == "" {
+			return
+		}
+
+		if seen[href] {
+			return
+		}
+		seen[href] = true
+		id := h.extractArticleID(href)
+
+		cover, _ := s.Find("img").Attr("data-original")
+		if cover == "" {
+			cover, _ = s.Find("img").Attr("src")
+		}
+
+		timeText := strings.TrimSpace(s.Find("i, .state").First().Text())
+
+		mobileURL := href
+		if id != "" {
+			mobileURL = fmt.Sprintf("https://m.ithome.com/html/%s.htm", id)
+		}
+
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     title,
+			Desc:      strings.TrimSpace(s.Find("div.m").Text()),
+			Cover:     cover,
+			URL:       href,
+			MobileURL: mobileURL,
+			Timestamp: timeutil.ParseTime(timeText),
+		})
+	})
+
+	if len(result) > 50 {
+		result = result[:50]
+	}
+	return result, nil
+}
+
+// parseLapin 解析辣品首页的商品列表
+// 每个商品为 ul.bx 下的 li: a.title 标题、.price 价格、.mall 商城、.time 时间与商品图
+// 价格与商城放入 Extra;时间只有 "HH:mm" 或 "MM-DD" 时按北京时间以 now 为基准补全日期
+func (h *IthomeHandler) parseLapin(html string, now time.Time) ([]models.HotData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("解析IT之家辣品页面失败: %w", err)
+	}
+
+	result := make([]models.HotData, 0)
+	seen := make(map[string]bool)
+	doc.Find("ul.bx li").Each(func(i int, s *goquery.Selection) {
+		link := s.Find("a.title").First()
+		title := strings.TrimSpace(link.Text())
+		href, _ := link.Attr("href")
+		matches := ithomeLapinPattern.FindStringSubmatch(href)
+		if title == "" || len(matches) < 2 {
+			return
+		}
+
+		id := matches[1]
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+
+		cover, _ := s.Find("img").Attr("data-original")
+		if cover == "" {
+			cover, _ = s.Find("img").Attr("src")
+		}
+
+		extra := make(map[string]interface{})
+		if price := strings.Join(strings.Fields(s.Find(".price").First().Text()), " "); price != "" {
+			extra["price"] = price
+		}
+		if mall := strings.TrimSpace(s.Find(".mall").First().Text()); mall != "" {
+			extra["mall"] = mall
+		}
+		if len(extra) == 0 {
+			extra = nil
+		}
+
+		itemURL := fmt.Sprintf("https://lapin.ithome.com/html/%s.htm", id)
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     title,
+			Cover:     cover,
+			URL:       itemURL,
+			MobileURL: itemURL,
+			Timestamp: timeutil.ParseTimeIn(strings.TrimSpace(s.Find(".time").First().Text()), timeutil.Beijing, now),
+			Extra:     extra,
+		})
+	})
+
+	if len(result) > 50 {
+		result = result[:50]
+	}
+	return result, nil
+}
+
+// extractArticleID 从桌面端文章链接提取 ID: /0/760/123.htm -> 760123
+func (h *IthomeHandler) extractArticleID(href string) string {
+	if matches := ithomeArticlePattern.FindStringSubmatch(href); len(matches) > 2 {
+		return matches[1] + matches[2]
+	}
+	return ""
+}
+
 // parseHTML 解析 HTML
 func (h *IthomeHandler) parseHTML(html string) []models.HotData {
 	result := make([]models.HotData, 0)
@@ -0,0 +1,123 @@
+package routes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+)
+
+// ithomeRankFixture 移动端热榜页(节选)
+const ithomeRankFixture = `<div class="rank-box">
+  <div class="placeholder one-img-plc">
+    <a href="https://m.ithome.com/html/760123.htm">
+      <div class="plc-image"><img data-original="https://img.ithome.com/newsuploadfiles/thumbnail/2024/5/760123.jpg"></div>
+      <div class="plc-con">
+        <p class="plc-title">小米发布新款旗舰手机</p>
+        <div class="plc-footer"><span class="post-time">2024-05-01 10:30</span><span class="review-num">1234评</span></div>
+      </div>
+    </a>
+  </div>
+  <div class="placeholder"><a href="https://m.ithome.com/html/760124.htm"><p class="plc-title"> </p></a></div>
+  <div class="placeholder"><p class="plc-title">没有链接</p></div>
+</div>`
+
+// ithomeListFixture 桌面端最新页与标签页(节选)
+const ithomeListFixture = `
+<ul class="datel">
+  <li><i>2024-05-01 10:30</i><a class="t" href="https://www.ithome.com/0/760/123.htm">微软推送 Windows 11 更新</a></li>
+  <li><i>2024-05-01 10:20</i><a class="t" href="https://www.ithome.com/0/760/123.htm">重复的文章</a></li>
+</ul>
+<ul class="bl">
+  <li>
+    <a class="img" href="https://www.ithome.com/0/760/125.htm"><img data-original="https://img.ithome.com/125.jpg" src="//img.ithome.com/images/v2.3/noimg.png"></a>
+    <div class="c"><h2><a href="https://www.ithome.com/0/760/125.htm">苹果新款 iPad 评测</a></h2><div class="m">续航与屏幕全面升级</div><div class="state">2024-05-01 09:00</div></div>
+  </li>
+</ul>`
+
+// ithomeLapinFixture 辣品首页商品列表(节选)
+const ithomeLapinFixture = `<ul class="bx" id="ulLapin">
+  <li>
+    <a class="img" href="https://lapin.ithome.com/html/812345.htm"><img data-original="https://img.lapin.ithome.com/812345.jpg"></a>
+    <div class="info">
+      <a class="title" href="https://lapin.ithome.com/html/812345.htm">  AirPods Pro 2 降噪耳机 </a>
+      <div class="price">到手价
+        1499元</div>
+      <span class="mall">京东</span>
+      <span class="time">10:25</span>
+    </div>
+  </li>
+  <li>
+    <a class="title" href="/html/812346.htm">机械键盘</a>
+    <span class="time">04-30 22:10</span>
+  </li>
+  <li><a class="title" href="/html/812345.htm">重复商品</a></li>
+  <li><a class="title" href="https://www.ithome.com/0/760/123.htm">非商品链接</a></li>
+</ul>`
+
+func TestIthomeParseHTML(t *testing.T) {
+	h := &IthomeHandler{}
+	data := h.parseHTML(ithomeRankFixture)
+	if len(data) != 1 {
+		t.Fatalf("len(data) = %d, want 1", len(data))
+	}
+	item := data[0]
+	if item.ID != "760123" || item.Title != "小米发布新款旗舰手机" || item.Hot != int64(1234) {
+		t.Errorf("item = %+v", item)
+	}
+	if item.URL != "https://www.ithome.com/0/760/123.htm" || item.Cover == "" || item.Timestamp == int64(0) {
+		t.Errorf("item = %+v", item)
+	}
+}
+
+func TestIthomeParseList(t *testing.T) {
+	h := &IthomeHandler{}
+	data, err := h.parseList(ithomeListFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2 (duplicates removed)", len(data))
+	}
+	if data[0].ID != "760123" || data[0].Title != "微软推送 Windows 11 更新" || data[0].MobileURL != "https://m.ithome.com/html/760123.htm" {
+		t.Errorf("data[0] = %+v", data[0])
+	}
+	if data[1].Desc != "续航与屏幕全面升级" || data[1].Cover != "https://img.ithome.com/125.jpg" || data[1].Timestamp == int64(0) {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+}
+
+func TestIthomeParseLapin(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, timeutil.Beijing)
+	h := &IthomeHandler{}
+	data, err := h.parseLapin(ithomeLapinFixture, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "812345" || first.Title != "AirPods Pro 2 降噪耳机" || first.URL != "https://lapin.ithome.com/html/812345.htm" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://img.lapin.ithome.com/812345.jpg" {
+		t.Errorf("Cover = %q", first.Cover)
+	}
+	if first.Extra["price"] != "到手价 1499元" || first.Extra["mall"] != "京东" {
+		t.Errorf("Extra = %v", first.Extra)
+	}
+	if want := time.Date(2024, 5, 1, 10, 25, 0, 0, timeutil.Beijing).UnixMilli(); first.Timestamp != want {
+		t.Errorf("Timestamp = %v, want %d", first.Timestamp, want)
+	}
+
+	// 相对链接补全为完整地址,没有价格与商城时不返回 Extra
+	second := data[1]
+	if second.URL != "https://lapin.ithome.com/html/812346.htm" || second.Extra != nil {
+		t.Errorf("data[1] = %+v", second)
+	}
+	if want := time.Date(2024, 4, 30, 22, 10, 0, 0, timeutil.Beijing).UnixMilli(); second.Timestamp != want {
+		t.Errorf("Timestamp = %v, want %d", second.Timestamp, want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
@@ -33,9 +34,12 @@ func (h *IthomeXijiayiHandler) GetPath() string {
 // Handle 处理请求
 func (h *IthomeXijiayiHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchIthomeXijiayiHot(c.Context())
+	cacheKey := "ithome-xijiayi"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchIthomeXijiayiHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -46,7 +50,7 @@ func (h *IthomeXijiayiHandler) Handle(c *fiber.Ctx) error {
 		"https://www.ithome.com/zt/xijiayi",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -55,7 +59,7 @@ func (h *IthomeXijiayiHandler) fetchIthomeXijiayiHot(ctx context.Context) ([]mod
 	apiURL := "https://www.ithome.com/zt/xijiayi"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
 	})
 	if err != nil {
@@ -67,71 +71,42 @@ func (h *IthomeXijiayiHandler) fetchIthomeXijiayiHot(ctx context.Context) ([]mod
 }
 
 // parseHTML 解析HTML
+// 使用 goquery 选择器定位 .newslist li,比逐段正则匹配更能适应页面结构的细微变化
 func (h *IthomeXijiayiHandler) parseHTML(html string) []models.HotData {
 	result := make([]models.HotData, 0)
 
-	// 匹配 .newslist li 项
-	liPattern := regexp.MustCompile(`(?s)<li[^>]*>(.*?)</li>`)
-	liMatches := liPattern.FindAllStringSubmatch(html, -1)
-
-	for _, liMatch := range liMatches {
-		if len(liMatch) < 2 {
-			continue
-		}
-
-		liHTML := liMatch[1]
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return result
+	}
 
-		// 必须包含 a 标签才是有效项
-		if !strings.Contains(liHTML, "<a") {
-			continue
-		}
+	digitPattern := regexp.MustCompile(`\d+`)
 
-		// 提取链接
-		hrefPattern := regexp.MustCompile(`<a[^>]+href="([^"]+)"`)
-		hrefMatches := hrefPattern.FindStringSubmatch(liHTML)
-		href := ""
-		if len(hrefMatches) > 1 {
-			href = hrefMatches[1]
+	doc.Find(".newslist li").Each(func(i int, s *goquery.Selection) {
+		// 必须包含链接才是有效项
+		href, exists := s.Find("a").First().Attr("href")
+		if !exists || href == "" {
+			return
 		}
 
-		// 提取标题
-		titlePattern := regexp.MustCompile(`<h2[^>]*>([^<]+)</h2>`)
-		titleMatches := titlePattern.FindStringSubmatch(liHTML)
-		title := ""
-		if len(titleMatches) > 1 {
-			title = strings.TrimSpace(titleMatches[1])
+		title := strings.TrimSpace(s.Find("h2").First().Text())
+		if title == "" {
+			return
 		}
 
-		// 提取描述
-		descPattern := regexp.MustCompile(`<p[^>]*>([^<]+)</p>`)
-		descMatches := descPattern.FindStringSubmatch(liHTML)
-		desc := ""
-		if len(descMatches) > 1 {
-			desc = strings.TrimSpace(descMatches[1])
-		}
+		desc := strings.TrimSpace(s.Find("p").First().Text())
 
-		// 提取封面
-		coverPattern := regexp.MustCompile(`<img[^>]+data-original="([^"]+)"`)
-		coverMatches := coverPattern.FindStringSubmatch(liHTML)
-		cover := ""
-		if len(coverMatches) > 1 {
-			cover = coverMatches[1]
-		}
+		// 封面图片懒加载,真实地址在 data-original 中
+		cover, _ := s.Find("img").First().Attr("data-original")
 
-		// 提取时间
-		timePattern := regexp.MustCompile(`<span[^>]+class="time"[^>]*>'([^']+)'`)
-		timeMatches := timePattern.FindStringSubmatch(liHTML)
-		timestamp := ""
-		if len(timeMatches) > 1 {
-			timestamp = h.parseTime(timeMatches[1])
-		}
+		// 时间文本形如 '2024-01-15 12:30:00',需要去掉两侧的引号
+		timeText := strings.Trim(strings.TrimSpace(s.Find("span.time").First().Text()), "'")
+		timestamp := h.parseTime(timeText)
 
 		// 提取评论数（热度）
-		commentPattern := regexp.MustCompile(`<span[^>]+class="comment"[^>]*>(\d+)`)
-		commentMatches := commentPattern.FindStringSubmatch(liHTML)
 		hot := int64(0)
-		if len(commentMatches) > 1 {
-			hot, _ = strconv.ParseInt(commentMatches[1], 10, 64)
+		if match := digitPattern.FindString(s.Find("span.comment").First().Text()); match != "" {
+			hot, _ = strconv.ParseInt(match, 10, 64)
 		}
 
 		// 转换链接和ID
@@ -149,7 +124,7 @@ func (h *IthomeXijiayiHandler) parseHTML(html string) []models.HotData {
 		}
 
 		result = append(result, hotData)
-	}
+	})
 
 	return result
 }
@@ -0,0 +1,66 @@
+package routes
+
+import "testing"
+
+// ithomeXijiayiFixture 喜加一专题页列表(节选)
+const ithomeXijiayiFixture = `<div class="newslist"><ul>
+  <li>
+    <a href="https://www.ithome.com/0/741/963.htm" target="_blank"><img data-original="https://img.ithome.com/newsuploadfiles/thumbnail/2024/1/741963.jpg" src="//img.ithome.com/images/v2.3/noimg.png"></a>
+    <div class="block">
+      <h2><a href="https://www.ithome.com/0/741/963.htm">喜加一：Epic 免费领取《控制》</a></h2>
+      <p>Epic 游戏商城本周免费领取《控制》终极版。</p>
+      <div class="c"><span class="time">'2024-01-15 12:30:00'</span><span class="comment">128评</span></div>
+    </div>
+  </li>
+  <li>
+    <a href="https://www.ithome.com/zt/xijiayi"><img src="x.png"></a>
+    <div class="block"><h2>专题页链接</h2><span class="time">bad</span></div>
+  </li>
+  <li><div class="block"><h2>没有链接</h2></div></li>
+  <li><a href="https://www.ithome.com/0/741/964.htm"></a><h2> </h2></li>
+</ul></div>`
+
+func TestIthomeXijiayiParseHTML(t *testing.T) {
+	h := &IthomeXijiayiHandler{}
+	data := h.parseHTML(ithomeXijiayiFixture)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "741963" || first.Title != "喜加一：Epic 免费领取《控制》" || first.Hot != int64(128) {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Desc != "Epic 游戏商城本周免费领取《控制》终极版。" || first.Cover != "https://img.ithome.com/newsuploadfiles/thumbnail/2024/1/741963.jpg" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.URL != "https://www.ithome.com/0/741/963.htm" || first.MobileURL != "https://m.ithome.com/html/741963.htm" {
+		t.Errorf("data[0] URL = %q, MobileURL = %q", first.URL, first.MobileURL)
+	}
+	if first.Timestamp == "" {
+		t.Error("Timestamp is empty, want parsed time")
+	}
+
+	// 非文章链接保留原地址,时间无法解析时为空
+	second := data[1]
+	if second.ID != "100000" || second.MobileURL != "https://www.ithome.com/zt/xijiayi" || second.Timestamp != "" {
+		t.Errorf("data[1] = %+v", second)
+	}
+}
+
+func TestIthomeXijiayiReplaceLink(t *testing.T) {
+	h := &IthomeXijiayiHandler{}
+	tests := []struct {
+		href, id, mobile string
+	}{
+		{"https://www.ithome.com/0/741/963.htm", "741963", "https://m.ithome.com/html/741963.htm"},
+		{"https://www.ithome.com/0/7/1.htm", "71", "https://m.ithome.com/html/71.htm"},
+		{"https://m.ithome.com/html/741963.htm", "100000", "https://m.ithome.com/html/741963.htm"},
+	}
+	for _, tt := range tests {
+		id, mobile := h.replaceLink(tt.href)
+		if id != tt.id || mobile != tt.mobile {
+			t.Errorf("replaceLink(%q) = %q, %q, want %q, %q", tt.href, id, mobile, tt.id, tt.mobile)
+		}
+	}
+}
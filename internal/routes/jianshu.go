@@ -3,9 +3,12 @@ package routes
 import (
 	"context"
 	"fmt"
+	"path"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
@@ -31,9 +34,12 @@ func (h *JianshuHandler) GetPath() string {
 // Handle 处理请求
 func (h *JianshuHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchJianshuHot(c.Context())
+	cacheKey := "jianshu"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchJianshuHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -44,7 +50,7 @@ func (h *JianshuHandler) Handle(c *fiber.Ctx) error {
 		"https://www.jianshu.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -53,7 +59,7 @@ func (h *JianshuHandler) fetchJianshuHot(ctx context.Context) ([]models.HotData,
 	apiURL := "https://www.jianshu.com/"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"Referer":    "https://www.jianshu.com",
 		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
 	})
@@ -66,92 +72,43 @@ func (h *JianshuHandler) fetchJianshuHot(ctx context.Context) ([]models.HotData,
 }
 
 // parseHTML 解析HTML
+// 使用 goquery 遍历 ul.note-list 下的文章,并从 .meta 中提取阅读/评论/点赞数
 func (h *JianshuHandler) parseHTML(html string) []models.HotData {
 	result := make([]models.HotData, 0)
 
-	// 先找到 ul.note-list
-	ulPattern := regexp.MustCompile(`(?s)<ul[^>]+class="[^"]*note-list[^"]*"[^>]*>(.*?)</ul>`)
-	ulMatches := ulPattern.FindStringSubmatch(html)
-
-	if len(ulMatches) < 2 {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
 		return result
 	}
 
-	ulContent := ulMatches[1]
-
-	// 匹配所有 li 项（不要求data-note-id属性）
-	liPattern := regexp.MustCompile(`(?s)<li[^>]*>(.*?)</li>`)
-	liMatches := liPattern.FindAllStringSubmatch(ulContent, -1)
-
-	for _, liMatch := range liMatches {
-		if len(liMatch) < 2 {
-			continue
-		}
-
-		liHTML := liMatch[1]
-		noteID := "" // 稍后从href或data-note-id中提取
+	doc.Find("ul.note-list > li").Each(func(i int, s *goquery.Selection) {
+		titleLink := s.Find("a.title").First()
+		title := strings.TrimSpace(titleLink.Text())
+		href, _ := titleLink.Attr("href")
 
-		// 提取链接 href
-		hrefPattern := regexp.MustCompile(`<a[^>]+href="(/p/[^"]+)"`)
-		hrefMatches := hrefPattern.FindStringSubmatch(liHTML)
-		href := ""
-		if len(hrefMatches) > 1 {
-			href = hrefMatches[1]
-		}
-
-		// 提取标题
-		titlePattern := regexp.MustCompile(`<a[^>]+class="title"[^>]*>([^<]+)</a>`)
-		titleMatches := titlePattern.FindStringSubmatch(liHTML)
-		title := ""
-		if len(titleMatches) > 1 {
-			title = strings.TrimSpace(titleMatches[1])
-		}
-
-		// 提取封面
-		coverPattern := regexp.MustCompile(`<img[^>]+src="([^"]+)"`)
-		coverMatches := coverPattern.FindStringSubmatch(liHTML)
-		cover := ""
-		if len(coverMatches) > 1 {
-			cover = coverMatches[1]
-		}
-
-		// 提取描述
-		descPattern := regexp.MustCompile(`<p[^>]+class="abstract"[^>]*>([^<]+)</p>`)
-		descMatches := descPattern.FindStringSubmatch(liHTML)
-		desc := ""
-		if len(descMatches) > 1 {
-			desc = strings.TrimSpace(descMatches[1])
-		}
-
-		// 提取作者
-		authorPattern := regexp.MustCompile(`<a[^>]+class="nickname"[^>]*>([^<]+)</a>`)
-		authorMatches := authorPattern.FindStringSubmatch(liHTML)
-		author := ""
-		if len(authorMatches) > 1 {
-			author = strings.TrimSpace(authorMatches[1])
-		}
-
-		// 从href中提取ID
-		if href != "" {
-			idPattern := regexp.MustCompile(`([^/]+)$`)
-			idMatches := idPattern.FindStringSubmatch(href)
-			if len(idMatches) > 1 {
-				noteID = idMatches[1]
-			}
+		// 如果没有标题或链接，跳过这个项目
+		if title == "" || href == "" {
+			return
 		}
 
-		// 如果还没有ID，尝试从data-note-id属性提取
-		if noteID == "" {
-			noteIDPattern := regexp.MustCompile(`data-note-id="([^"]+)"`)
-			noteIDMatches := noteIDPattern.FindStringSubmatch(liHTML)
-			if len(noteIDMatches) > 1 {
-				noteID = noteIDMatches[1]
-			}
+		// 优先从 href 中提取 ID,没有再取 data-note-id 属性
+		noteID := path.Base(href)
+		if noteID == "" || noteID == "." || noteID == "/" {
+			noteID, _ = s.Attr("data-note-id")
 		}
 
-		// 如果没有标题或链接，跳过这个项目
-		if title == "" || href == "" {
-			continue
+		// 解析统计数据
+		meta := s.Find(".meta")
+		views := h.parseMetaCount(meta, "ic-list-read")
+		comments := h.parseMetaCount(meta, "ic-list-comments")
+		likes := h.parseMetaCount(meta, "ic-list-like")
+
+		// 热度优先取阅读量,首页列表没有阅读量时退回点赞数
+		var hot int64
+		if views > 0 {
+			hot = views
+		} else {
+			hot = likes
 		}
 
 		url := "https://www.jianshu.com" + href
@@ -159,15 +116,63 @@ func (h *JianshuHandler) parseHTML(html string) []models.HotData {
 		hotData := models.HotData{
 			ID:        noteID,
 			Title:     title,
-			Cover:     cover,
-			Desc:      desc,
-			Author:    author,
+			Cover:     h.parseCover(s.Find("img").First()),
+			Desc:      strings.TrimSpace(s.Find("p.abstract").First().Text()),
+			Author:    strings.TrimSpace(meta.Find("a.nickname").First().Text()),
+			Hot:       hot,
 			URL:       url,
 			MobileURL: url,
+			Extra: map[string]interface{}{
+				"views":    views,
+				"comments": comments,
+				"likes":    likes,
+			},
 		}
 
 		result = append(result, hotData)
-	}
+	})
 
 	return result
 }
+
+// parseMetaCount 从 .meta 中提取指定图标后面的数字
+// 简书的统计项形如 <span><i class="iconfont ic-list-like"></i> 50</span>
+func (h *JianshuHandler) parseMetaCount(meta *goquery.Selection, iconClass string) int64 {
+	icon := meta.Find("i." + iconClass).First()
+	if icon.Length() == 0 {
+		return 0
+	}
+
+	match := jianshuDigitPattern.FindString(icon.Parent().Text())
+	if match == "" {
+		return 0
+	}
+
+	count, _ := strconv.ParseInt(match, 10, 64)
+	return count
+}
+
+// parseCover 提取封面图片地址
+// 简书图片使用懒加载,真实地址可能在 data-echo/data-src/data-original 中,src 只是占位图
+func (h *JianshuHandler) parseCover(img *goquery.Selection) string {
+	if img.Length() == 0 {
+		return ""
+	}
+
+	cover := ""
+	for _, attr := range []string{"data-echo", "data-src", "data-original", "src"} {
+		if v, ok := img.Attr(attr); ok && strings.TrimSpace(v) != "" {
+			cover = strings.TrimSpace(v)
+			break
+		}
+	}
+
+	// 补全协议相对地址,如 //upload-images.jianshu.io/...
+	if strings.HasPrefix(cover, "//") {
+		cover = "https:" + cover
+	}
+	return cover
+}
+
+// jianshuDigitPattern 匹配统计数字
+var jianshuDigitPattern = regexp.MustCompile(`\d+`)
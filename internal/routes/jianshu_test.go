@@ -0,0 +1,68 @@
+package routes
+
+import "testing"
+
+// jianshuFixture 简书首页文章列表(节选)
+const jianshuFixture = `<ul class="note-list" infinite-scroll-url="/">
+  <li id="note-110001" data-note-id="110001" class="have-img">
+    <a class="wrap-img" href="/p/0a1b2c3d4e5f" target="_blank">
+      <img class="img-blur-done" data-echo="//upload-images.jianshu.io/upload_images/1.jpg?imageMogr2/auto-orient" src="//cdn2.jianshu.io/assets/default.png" alt="120">
+    </a>
+    <div class="content">
+      <a class="title" target="_blank" href="/p/0a1b2c3d4e5f">  那些年我们追过的技术  </a>
+      <p class="abstract">
+        从入门到放弃,再到重新入门。
+      </p>
+      <div class="meta">
+        <span class="jsd-meta"><i class="iconfont ic-paid1"></i> 8.8</span>
+        <a class="nickname" target="_blank" href="/u/abc">程序员小明</a>
+        <a target="_blank" href="/p/0a1b2c3d4e5f#comments"><i class="iconfont ic-list-comments"></i> 36</a>
+        <span><i class="iconfont ic-list-like"></i> 512</span>
+      </div>
+    </div>
+  </li>
+  <li id="note-110002" data-note-id="110002">
+    <div class="content">
+      <a class="title" target="_blank" href="/p/9f8e7d6c5b4a">读书笔记</a>
+      <div class="meta">
+        <a class="nickname" href="/u/def">书虫</a>
+        <span><i class="iconfont ic-list-read"></i> 10240</span>
+        <span><i class="iconfont ic-list-like"></i> 20</span>
+      </div>
+      <img data-src="https://upload-images.jianshu.io/upload_images/2.jpg">
+    </div>
+  </li>
+  <li><div class="content"><a class="title">没有链接</a></div></li>
+</ul>`
+
+func TestJianshuParseHTML(t *testing.T) {
+	h := &JianshuHandler{}
+	data := h.parseHTML(jianshuFixture)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	// 没有阅读量时热度取点赞数
+	first := data[0]
+	if first.ID != "0a1b2c3d4e5f" || first.Title != "那些年我们追过的技术" || first.Author != "程序员小明" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Desc != "从入门到放弃,再到重新入门。" || first.URL != "https://www.jianshu.com/p/0a1b2c3d4e5f" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://upload-images.jianshu.io/upload_images/1.jpg?imageMogr2/auto-orient" {
+		t.Errorf("Cover = %q, want data-echo with https", first.Cover)
+	}
+	if first.Hot != int64(512) || first.Extra["comments"] != int64(36) || first.Extra["views"] != int64(0) {
+		t.Errorf("Hot = %v, Extra = %v", first.Hot, first.Extra)
+	}
+
+	// 有阅读量时热度取阅读量,封面取 data-src
+	second := data[1]
+	if second.Hot != int64(10240) || second.Extra["likes"] != int64(20) {
+		t.Errorf("Hot = %v, Extra = %v", second.Hot, second.Extra)
+	}
+	if second.Cover != "https://upload-images.jianshu.io/upload_images/2.jpg" {
+		t.Errorf("Cover = %q", second.Cover)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -32,11 +33,17 @@ func (h *JuejinHandler) Handle(c *fiber.Ctx) error {
 	// 支持不同分类: 1-综合, 6809637767543259144-后端, 等
 	categoryID := c.Query("type", "1")
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", categoryID, isNumeric); err != nil {
+		return paramError(c, err)
+	}
 
 	// 获取热榜数据
-	data, err := h.fetchJuejinHot(c.Context(), categoryID)
+	cacheKey := fmt.Sprintf("juejin_%s", categoryID)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchJuejinHot(ctx, categoryID)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 准备类型映射表 - 用于前端显示支持的分类
@@ -67,8 +74,8 @@ func (h *JuejinHandler) Handle(c *fiber.Ctx) error {
 		map[string]interface{}{ // params: 参数说明
 			"type": typeMap,
 		},
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -79,7 +86,7 @@ func (h *JuejinHandler) fetchJuejinHot(ctx context.Context, categoryID string) (
 	apiURL := fmt.Sprintf("https://api.juejin.cn/content_api/v1/content/article_rank?category_id=%s&type=hot", categoryID)
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
 	})
 	if err != nil {
@@ -106,10 +113,23 @@ func (h *JuejinHandler) transformData(items []JuejinItem) []models.HotData {
 		hotData := models.HotData{
 			ID:        content.ContentID,
 			Title:     content.Title,
+			Desc:      content.Brief,
+			Cover:     content.CoverImage,
 			Author:    author.Name,
 			Hot:       counter.HotRank,
 			URL:       fmt.Sprintf("https://juejin.cn/post/%s", content.ContentID),
 			MobileURL: fmt.Sprintf("https://juejin.cn/post/%s", content.ContentID),
+
+			// 扩展字段: 阅读/点赞/评论/收藏数
+			Extra: map[string]interface{}{
+				"views":     counter.View,
+				"likes":     counter.Like,
+				"comments":  counter.CommentCount,
+				"favorites": counter.Collect,
+			},
+		}
+		if ts := timeutil.ParseTime(content.Ctime); ts > 0 {
+			hotData.Timestamp = ts
 		}
 
 		result = append(result, hotData)
@@ -132,8 +152,11 @@ type JuejinItem struct {
 
 // JuejinContent 文章内容
 type JuejinContent struct {
-	ContentID string `json:"content_id"`
-	Title     string `json:"title"`
+	ContentID  string      `json:"content_id"`
+	Title      string      `json:"title"`
+	Brief      string      `json:"brief"`       // 摘要
+	CoverImage string      `json:"cover_image"` // 封面图
+	Ctime      interface{} `json:"ctime"`       // 发布时间(秒级,可能为字符串)
 }
 
 // JuejinAuthor 作者信息
@@ -143,5 +166,9 @@ type JuejinAuthor struct {
 
 // JuejinContentCounter 统计信息
 type JuejinContentCounter struct {
-	HotRank int64 `json:"hot_rank"`
+	HotRank      int64 `json:"hot_rank"`
+	View         int64 `json:"view"`          // 阅读数
+	Like         int64 `json:"like"`          // 点赞数
+	CommentCount int64 `json:"comment_count"` // 评论数
+	Collect      int64 `json:"collect"`       // 收藏数
 }
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// juejinFixture 文章热榜接口响应(节选)
+const juejinFixture = `{"err_no":0,"err_msg":"success","data":[
+  {
+    "content":{"content_id":"7363538212356472847","item_type":2,"format":"html","status":2,"title":"Go 1.22 新特性全解析","brief":"for 循环变量语义变更与 range over int","cover_image":"https://p3-juejin.byteimg.com/tos-cn-i-k3u1fbpfcp/abc~tplv-k3u1fbpfcp-jj-mark:0:0:0:0:q75.image","ctime":"1714550400"},
+    "content_counter":{"view":12034,"like":356,"collect":789,"hot_rank":9821,"comment_count":45,"interact_count":1190},
+    "author":{"user_id":"1234567890","name":"掘金小册","avatar":"https://p3-passport.byteacctimg.com/img/user-avatar/abc~100x100.awebp"}
+  },
+  {
+    "content":{"content_id":"7363538212356472848","title":"没有封面的文章","ctime":1714464000},
+    "content_counter":{"hot_rank":500},
+    "author":{"name":"作者B"}
+  }
+]}`
+
+func TestJuejinTransformData(t *testing.T) {
+	var resp JuejinAPIResponse
+	if err := json.Unmarshal([]byte(juejinFixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &JuejinHandler{}
+	data := h.transformData(resp.Data)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "7363538212356472847" || first.Title != "Go 1.22 新特性全解析" || first.Author != "掘金小册" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Desc != "for 循环变量语义变更与 range over int" || first.Cover == "" {
+		t.Errorf("Desc = %q, Cover = %q", first.Desc, first.Cover)
+	}
+	// 热度仍为 hot_rank,统计数放入 Extra
+	if first.Hot != int64(9821) {
+		t.Errorf("Hot = %v, want hot_rank", first.Hot)
+	}
+	if first.Extra["views"] != int64(12034) || first.Extra["likes"] != int64(356) ||
+		first.Extra["comments"] != int64(45) || first.Extra["favorites"] != int64(789) {
+		t.Errorf("Extra = %v", first.Extra)
+	}
+	if first.Timestamp != int64(1714550400000) || first.URL != "https://juejin.cn/post/7363538212356472847" {
+		t.Errorf("Timestamp = %v, URL = %q", first.Timestamp, first.URL)
+	}
+
+	// ctime 为数字时同样解析,缺少的统计数为 0
+	second := data[1]
+	if second.Timestamp != int64(1714464000000) || second.Cover != "" || second.Extra["views"] != int64(0) {
+		t.Errorf("data[1] = %+v", second)
+	}
+}
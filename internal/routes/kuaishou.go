@@ -32,9 +32,12 @@ func (h *KuaishouHandler) GetPath() string {
 // Handle 处理请求
 func (h *KuaishouHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchKuaishouHot(c.Context())
+	cacheKey := "kuaishou"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchKuaishouHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -45,7 +48,7 @@ func (h *KuaishouHandler) Handle(c *fiber.Ctx) error {
 		"https://www.kuaishou.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -54,7 +57,7 @@ func (h *KuaishouHandler) fetchKuaishouHot(ctx context.Context) ([]models.HotDat
 	apiURL := "https://www.kuaishou.com/?isHome=1"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
 		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
 	})
 	if err != nil {
@@ -33,9 +33,12 @@ func (h *LinuxdoHandler) GetPath() string {
 // Handle 处理请求
 func (h *LinuxdoHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchLinuxdo(c.Context())
+	cacheKey := "linuxdo"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchLinuxdo(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -46,7 +49,7 @@ func (h *LinuxdoHandler) Handle(c *fiber.Ctx) error {
 		"https://linux.do",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -60,7 +63,7 @@ func (h *LinuxdoHandler) fetchLinuxdo(ctx context.Context) ([]models.HotData, er
 		"Accept":     "text/plain; charset=utf-8",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求 Linux.do API 失败: %w", err)
 	}
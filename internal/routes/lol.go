@@ -12,6 +12,27 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// lolTypeMap 资讯分类映射: type 参数 -> 分类名称
+var lolTypeMap = map[string]string{
+	"notice":    "公告",
+	"latest":    "综合",
+	"match":     "赛事",
+	"guide":     "攻略",
+	"community": "社区",
+}
+
+// lolTargetMap 资讯分类对应官网资讯接口的 target 参数(与 lol.qq.com 首页资讯标签一致)
+var lolTargetMap = map[string]int{
+	"latest":    23,
+	"notice":    24,
+	"match":     25,
+	"guide":     27,
+	"community": 28,
+}
+
+// lolNewsURL 官网资讯列表接口
+var lolNewsURL = "https://apps.game.qq.com/cmc/zmMcnTargetContentList"
+
 // LolHandler 英雄联盟处理器
 type LolHandler struct {
 	fetcher *service.Fetcher
@@ -31,31 +52,46 @@ func (h *LolHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *LolHandler) Handle(c *fiber.Ctx) error {
+	// 获取资讯分类,默认公告(兼容原接口)
+	newsType := c.Query("type", "notice")
+	if _, ok := lolTypeMap[newsType]; !ok {
+		newsType = "notice"
+	}
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchLol(c.Context())
+
+	// 公告沿用原缓存键
+	cacheKey := "lol"
+	if newsType != "notice" {
+		cacheKey = fmt.Sprintf("lol_%s", newsType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchLol(ctx, lolTargetMap[newsType])
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
 		"lol_news",
 		"英雄联盟",
-		"更新公告",
-		"英雄联盟更新公告列表",
+		lolTypeMap[newsType],
+		fmt.Sprintf("英雄联盟%s资讯列表", lolTypeMap[newsType]),
 		"https://lol.qq.com",
-		nil,
+		map[string]interface{}{
+			"type": lolTypeMap,
+		},
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
-// fetchLol 从英雄联盟官网 API 获取数据
-func (h *LolHandler) fetchLol(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://apps.game.qq.com/cmc/zmMcnTargetContentList?r0=json&page=1&num=30&target=24&source=web_pc"
+// fetchLol 从英雄联盟官网资讯接口获取指定分类的数据
+func (h *LolHandler) fetchLol(ctx context.Context, target int) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("%s?r0=json&page=1&num=30&target=%d&source=web_pc", lolNewsURL, target)
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求英雄联盟 API 失败: %w", err)
 	}
@@ -66,6 +102,10 @@ func (h *LolHandler) fetchLol(ctx context.Context) ([]models.HotData, error) {
 		return nil, fmt.Errorf("解析英雄联盟响应失败: %w", err)
 	}
 
+	if len(apiResp.Data.Result) == 0 {
+		return nil, fmt.Errorf("英雄联盟资讯(target=%d)%w", target, service.ErrEmptyData)
+	}
+
 	// 转换为统一格式
 	return h.transformData(apiResp.Data.Result), nil
 }
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// lolNewsFixture 官网资讯列表接口响应(节选)
+const lolNewsFixture = `{"status":0,"msg":"OK","data":{"resultTotal":2,"result":[
+  {"iDocID":"12345678","sTitle":"2024年5月10日版本更新公告","sIMG":"//ossweb-img.qq.com/upload/webplat/info/lol/20240510/cover.jpg","sAuthor":"英雄联盟官方","iTotalPlay":"105620","sCreated":"2024-05-10 10:00:00"},
+  {"iDocID":"12345679&x","sTitle":"MSI 季中冠军赛赛程公布","sIMG":"https://game.gtimg.cn/images/lol/cover.png","sAuthor":"赛事","iTotalPlay":"","sCreated":"2024-05-09 18:30:00"}
+]}}`
+
+func TestLolTransformData(t *testing.T) {
+	h := &LolHandler{}
+	data := h.transformData([]LolItem{
+		{IDocID: "12345678", STitle: "版本更新公告", SIMG: "//ossweb-img.qq.com/cover.jpg", ITotalPlay: "105620", SCreated: "2024-05-10 10:00:00"},
+		{IDocID: "12345679&x", STitle: "赛程公布", SIMG: "https://game.gtimg.cn/cover.png"},
+	})
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+	if data[0].Cover != "https://ossweb-img.qq.com/cover.jpg" || data[0].Hot != int64(105620) || data[0].Timestamp != "2024-05-10 10:00:00" {
+		t.Errorf("data[0] = %+v", data[0])
+	}
+	// docid 需要转义
+	if data[1].URL != "https://lol.qq.com/news/detail.shtml?docid=12345679%26x" || data[1].Cover != "https://game.gtimg.cn/cover.png" {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+}
+
+func TestLolNewsType(t *testing.T) {
+	var target string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.URL.Query().Get("target")
+		if target == "28" {
+			fmt.Fprint(w, `{"status":0,"data":{"resultTotal":0,"result":[]}}`)
+			return
+		}
+		fmt.Fprint(w, lolNewsFixture)
+	}))
+	defer upstream.Close()
+
+	original := lolNewsURL
+	lolNewsURL = upstream.URL
+	defer func() { lolNewsURL = original }()
+
+	h := &LolHandler{fetcher: newTestFetcher(t)}
+	app := fiber.New()
+	app.Get(h.GetPath(), h.Handle)
+
+	tests := []struct {
+		query      string
+		wantTarget string
+		wantType   string
+	}{
+		{"", "24", "公告"},
+		{"&type=match", "25", "赛事"},
+		{"&type=guide", "27", "攻略"},
+		// 未知分类回退到公告
+		{"&type=unknown", "24", "公告"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			status, result := getJSON(t, app, "/lol?cache=false"+tt.query)
+			if status != fiber.StatusOK {
+				t.Fatalf("status = %d", status)
+			}
+			if target != tt.wantTarget || result["type"] != tt.wantType {
+				t.Errorf("target = %q, type = %v, want %q, %q", target, result["type"], tt.wantTarget, tt.wantType)
+			}
+			params, _ := result["params"].(map[string]interface{})
+			if types, _ := params["type"].(map[string]interface{}); len(types) != len(lolTypeMap) {
+				t.Errorf("params = %v", result["params"])
+			}
+			if items, _ := result["data"].([]interface{}); len(items) != 2 {
+				t.Errorf("len(data) = %d, want 2", len(items))
+			}
+		})
+	}
+
+	// 分类没有资讯时返回错误
+	if status, _ := getJSON(t, app, "/lol?cache=false&type=community"); status == fiber.StatusOK {
+		t.Errorf("community: status = %d, want error", status)
+	}
+}
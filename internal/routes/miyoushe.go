@@ -11,6 +11,9 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// miyousheNewsURL 米游社官方资讯列表接口
+var miyousheNewsURL = "https://bbs-api-static.miyoushe.com/painter/wapi/getNewsList"
+
 // MiyousheHandler 米游社处理器
 type MiyousheHandler struct {
 	fetcher *service.Fetcher
@@ -33,11 +36,20 @@ func (h *MiyousheHandler) Handle(c *fiber.Ctx) error {
 	game := c.Query("game", "1")     // 默认崩坏3
 	newsType := c.Query("type", "1") // 默认公告
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("game", game, isNumeric); err != nil {
+		return paramError(c, err)
+	}
+	if err := validateParam("type", newsType, isNumeric); err != nil {
+		return paramError(c, err)
+	}
 
 	gameName := h.getGameName(game)
-	data, err := h.fetchMiyoushe(c.Context(), game, newsType)
+	cacheKey := fmt.Sprintf("miyoushe_%s_%s", game, newsType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchMiyoushe(ctx, game, newsType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -48,7 +60,7 @@ func (h *MiyousheHandler) Handle(c *fiber.Ctx) error {
 		"https://www.miyoushe.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -101,27 +113,36 @@ func (h *MiyousheHandler) getGameCode(gameID string) string {
 
 // fetchMiyoushe 从米游社 API 获取数据
 func (h *MiyousheHandler) fetchMiyoushe(ctx context.Context, game, newsType string) ([]models.HotData, error) {
-	apiURL := fmt.Sprintf("https://bbs-api-static.miyoushe.com/painter/wapi/getNewsList?client_type=4&gids=%s&last_id=&page_size=30&type=%s", game, newsType)
+	return fetchMiyousheNews(ctx, h.fetcher, game, newsType, 30, h.getGameCode(game))
+}
+
+// fetchMiyousheNews 获取米游社指定游戏的官方资讯列表
+// 米游社、原神、崩坏3、星穹铁道等处理器共用
+//   - gids: 游戏 ID,如 1 崩坏3、2 原神、6 星穹铁道
+//   - newsType: 资讯类型,1 公告、2 活动、3 资讯
+//   - gameCode: 游戏代号,用于拼接文章链接,如 bh3、ys、sr
+func fetchMiyousheNews(ctx context.Context, fetcher *service.Fetcher, gids, newsType string, pageSize int, gameCode string) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("%s?client_type=4&gids=%s&last_id=&page_size=%d&type=%s", miyousheNewsURL, gids, pageSize, newsType)
 
 	// 发起 HTTP 请求
-	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	httpClient := fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求米游社 API 失败: %w", err)
 	}
 
-	// 解析 JSON 响应(使用 genshin.go 中定义的结构体)
+	// 解析 JSON 响应
 	var apiResp MiyousheAPIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return nil, fmt.Errorf("解析米游社响应失败: %w", err)
 	}
 
 	// 转换为统一格式
-	return h.transformData(apiResp.Data.List, h.getGameCode(game)), nil
+	return transformMiyousheNews(apiResp.Data.List, gameCode), nil
 }
 
-// transformData 将米游社原始数据转换为统一格式
-func (h *MiyousheHandler) transformData(items []MiyousheListItem, gameCode string) []models.HotData {
+// transformMiyousheNews 将米游社原始数据转换为统一格式
+func transformMiyousheNews(items []MiyousheListItem, gameCode string) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
@@ -159,3 +180,37 @@ func (h *MiyousheHandler) transformData(items []MiyousheListItem, gameCode strin
 
 	return result
 }
+
+// 以下是米游社 API 的响应结构体定义(米游社系列处理器共用)
+
+// MiyousheAPIResponse 米游社 API 响应
+type MiyousheAPIResponse struct {
+	Data MiyousheData `json:"data"`
+}
+
+// MiyousheData 数据部分
+type MiyousheData struct {
+	List []MiyousheListItem `json:"list"`
+}
+
+// MiyousheListItem 列表项
+type MiyousheListItem struct {
+	Post MiyoushePost  `json:"post"`
+	User *MiyousheUser `json:"user"`
+}
+
+// MiyoushePost 帖子信息
+type MiyoushePost struct {
+	PostID     string   `json:"post_id"`     // 帖子 ID
+	Subject    string   `json:"subject"`     // 标题
+	Content    string   `json:"content"`     // 内容
+	Cover      string   `json:"cover"`       // 封面图
+	Images     []string `json:"images"`      // 图片列表
+	ViewStatus int64    `json:"view_status"` // 浏览量
+	CreatedAt  int64    `json:"created_at"`  // 创建时间
+}
+
+// MiyousheUser 用户信息
+type MiyousheUser struct {
+	Nickname string `json:"nickname"` // 昵称
+}
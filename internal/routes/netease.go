@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dailyhot/api/internal/models"
@@ -12,6 +14,15 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// neteaseTypeMap 榜单类型映射
+var neteaseTypeMap = map[string]string{
+	"news":    "热点榜",
+	"comment": "跟帖榜",
+}
+
+// neteaseFlowURL 热点新闻流接口,热点榜与跟帖榜共用
+var neteaseFlowURL = "https://m.163.com/fe/api/hot/news/flow"
+
 // NeteaseHandler 网易新闻处理器
 type NeteaseHandler struct {
 	fetcher *service.Fetcher
@@ -31,36 +42,47 @@ func (h *NeteaseHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *NeteaseHandler) Handle(c *fiber.Ctx) error {
-	// 获取缓存标志
+	// 获取查询参数
+	listType := c.Query("type", "news")
+	if _, ok := neteaseTypeMap[listType]; !ok {
+		listType = "news"
+	}
 	noCache := c.Query("cache") == "false"
 
-	// 获取数据
-	data, err := h.fetchNeteaseHot(c.Context())
+	// 获取数据(热点榜沿用原缓存键)
+	cacheKey := "netease-news"
+	if listType != "news" {
+		cacheKey = fmt.Sprintf("netease-news_%s", listType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchNeteaseHot(ctx, listType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
-		"netease-news",          // name: 平台调用名称
-		"网易新闻",                  // title: 平台显示名称
-		"热点榜",                   // type: 榜单类型
-		"发现网易新闻热门资讯",            // description: 平台描述
-		"https://news.163.com/", // link: 官方链接
-		nil,                     // params: 无参数映射
-		data,                    // data: 热榜数据
-		!noCache,                // fromCache: 是否来自缓存
+		"netease-news",           // name: 平台调用名称
+		"网易新闻",                   // title: 平台显示名称
+		neteaseTypeMap[listType], // type: 榜单类型
+		"发现网易新闻热门资讯",             // description: 平台描述
+		"https://news.163.com/",  // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": neteaseTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
 // fetchNeteaseHot 从网易新闻 API 获取数据
-func (h *NeteaseHandler) fetchNeteaseHot(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://m.163.com/fe/api/hot/news/flow"
-
+// 跟帖榜使用同一数据源,按跟帖数重新排序
+func (h *NeteaseHandler) fetchNeteaseHot(ctx context.Context, listType string) ([]models.HotData, error) {
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, neteaseFlowURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求网易新闻 API 失败: %w", err)
 	}
@@ -70,7 +92,14 @@ func (h *NeteaseHandler) fetchNeteaseHot(ctx context.Context) ([]models.HotData,
 		return nil, fmt.Errorf("解析网易新闻响应失败: %w", err)
 	}
 
-	return h.transformData(apiResp.Data.List), nil
+	items := apiResp.Data.List
+	if listType == "comment" {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].ReplyCount > items[j].ReplyCount
+		})
+	}
+
+	return h.transformData(items), nil
 }
 
 // transformData 转换数据格式
@@ -91,10 +120,36 @@ func (h *NeteaseHandler) transformData(items []NeteaseItem) []models.HotData {
 			ID:        item.DocID,
 			Title:     item.Title,
 			Cover:     item.ImgSrc,
-			Author:    item.Source,
+			Author:    item.Source, // 来源媒体
+			Hot:       item.ReplyCount,
 			URL:       fmt.Sprintf("https://www.163.com/dy/article/%s.html", item.DocID),
 			MobileURL: fmt.Sprintf("https://m.163.com/dy/article/%s.html", item.DocID),
 			Timestamp: timestamp,
+			Extra: map[string]interface{}{
+				"replies": item.ReplyCount,
+			},
+		}
+
+		// 图集: 链接指向图集页,封面缺失时取第一张图
+		if item.SkipType == "photoset" {
+			images := make([]string, 0, len(item.ImgExtra)+1)
+			if item.ImgSrc != "" {
+				images = append(images, item.ImgSrc)
+			}
+			for _, img := range item.ImgExtra {
+				images = append(images, img.ImgSrc)
+			}
+			if hotData.Cover == "" && len(images) > 0 {
+				hotData.Cover = images[0]
+			}
+			hotData.Extra["type"] = "photoset"
+			hotData.Extra["images"] = images
+
+			// skipID 形如 "00AP0001|2345678": 频道 ID|图集 ID
+			if parts := strings.SplitN(item.SkipID, "|", 2); len(parts) == 2 {
+				hotData.URL = fmt.Sprintf("https://www.163.com/photoview/%s/%s.html", parts[0], parts[1])
+				hotData.MobileURL = fmt.Sprintf("https://m.163.com/photoview/%s/%s.html", parts[0], parts[1])
+			}
 		}
 
 		result = append(result, hotData)
@@ -120,4 +175,14 @@ type NeteaseItem struct {
 	ImgSrc string `json:"imgsrc"`
 	Source string `json:"source"`
 	PTime  string `json:"ptime"`
+
+	ReplyCount int64             `json:"replyCount"` // 跟帖数
+	SkipType   string            `json:"skipType"`   // 内容类型,图集为 "photoset"
+	SkipID     string            `json:"skipID"`     // 图集 ID,如 "00AP0001|2345678"
+	ImgExtra   []NeteaseImgExtra `json:"imgextra"`   // 图集附加图片
+}
+
+// NeteaseImgExtra 图集附加图片
+type NeteaseImgExtra struct {
+	ImgSrc string `json:"imgsrc"`
 }
@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// neteaseFixture 热点新闻流接口响应(节选)
+const neteaseFixture = `{"code":200,"message":"成功","data":{"list":[
+  {"docid":"J0A1B2C30514R9P4","title":"多地出台新政支持消费","imgsrc":"https://cms-bucket.ws.126.net/2024/0501/abc.png","source":"新华社","ptime":"2024-05-01 10:30:00","replyCount":1520,"skipType":"","skipID":""},
+  {"docid":"J0A1B2C40001875O","title":"图集｜五一假期各地景区人气旺","imgsrc":"","source":"网易图片","ptime":"2024-05-01 09:00:00","replyCount":8760,"skipType":"photoset","skipID":"00AP0001|2345678","imgextra":[{"imgsrc":"https://cms-bucket.ws.126.net/2024/0501/p1.jpg"},{"imgsrc":"https://cms-bucket.ws.126.net/2024/0501/p2.jpg"}]},
+  {"docid":"J0A1B2C50519DBM7","title":"没有发布时间的新闻","source":"澎湃新闻","ptime":"","replyCount":300}
+]}}`
+
+func TestNeteaseTransformData(t *testing.T) {
+	var resp NeteaseAPIResponse
+	if err := json.Unmarshal([]byte(neteaseFixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &NeteaseHandler{}
+	data := h.transformData(resp.Data.List)
+	if len(data) != 3 {
+		t.Fatalf("len(data) = %d, want 3", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "J0A1B2C30514R9P4" || first.Author != "新华社" || first.Hot != int64(1520) || first.Extra["replies"] != int64(1520) {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.URL != "https://www.163.com/dy/article/J0A1B2C30514R9P4.html" || first.MobileURL != "https://m.163.com/dy/article/J0A1B2C30514R9P4.html" {
+		t.Errorf("URL = %q, MobileURL = %q", first.URL, first.MobileURL)
+	}
+	if first.Timestamp == "" || first.Extra["type"] != nil {
+		t.Errorf("data[0] = %+v", first)
+	}
+
+	// 图集: 封面取第一张图,链接指向图集页
+	photoset := data[1]
+	if photoset.Cover != "https://cms-bucket.ws.126.net/2024/0501/p1.jpg" || photoset.Extra["type"] != "photoset" {
+		t.Errorf("photoset = %+v", photoset)
+	}
+	wantImages := []string{"https://cms-bucket.ws.126.net/2024/0501/p1.jpg", "https://cms-bucket.ws.126.net/2024/0501/p2.jpg"}
+	if !reflect.DeepEqual(photoset.Extra["images"], wantImages) {
+		t.Errorf("images = %v, want %v", photoset.Extra["images"], wantImages)
+	}
+	if photoset.URL != "https://www.163.com/photoview/00AP0001/2345678.html" || photoset.MobileURL != "https://m.163.com/photoview/00AP0001/2345678.html" {
+		t.Errorf("URL = %q, MobileURL = %q", photoset.URL, photoset.MobileURL)
+	}
+
+	if data[2].Timestamp != "" {
+		t.Errorf("Timestamp = %v, want empty", data[2].Timestamp)
+	}
+}
+
+func TestNeteaseFetchCommentList(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, neteaseFixture)
+	}))
+	defer upstream.Close()
+
+	original := neteaseFlowURL
+	neteaseFlowURL = upstream.URL
+	defer func() { neteaseFlowURL = original }()
+
+	h := &NeteaseHandler{fetcher: newTestFetcher(t)}
+	tests := []struct {
+		listType string
+		want     []string
+	}{
+		{"news", []string{"J0A1B2C30514R9P4", "J0A1B2C40001875O", "J0A1B2C50519DBM7"}},
+		// 跟帖榜按跟帖数降序
+		{"comment", []string{"J0A1B2C40001875O", "J0A1B2C30514R9P4", "J0A1B2C50519DBM7"}},
+	}
+	for _, tt := range tests {
+		data, err := h.fetchNeteaseHot(context.Background(), tt.listType)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.listType, err)
+		}
+		got := make([]string, len(data))
+		for i, item := range data {
+			got[i] = item.ID
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: order = %v, want %v", tt.listType, got, tt.want)
+		}
+	}
+}
@@ -34,9 +34,12 @@ func (h *NewsmthHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchNewsmth(c.Context())
+	cacheKey := "newsmth"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchNewsmth(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -48,7 +51,7 @@ func (h *NewsmthHandler) Handle(c *fiber.Ctx) error {
 		"https://www.newsmth.net/", // link: 官方链接
 		nil,                        // params: 无参数映射
 		data,                       // data: 热榜数据
-		!noCache,                   // fromCache: 是否来自缓存
+		fromCache,                  // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -60,7 +63,7 @@ func (h *NewsmthHandler) fetchNewsmth(ctx context.Context) ([]models.HotData, er
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求水木社区 API 失败: %w", err)
 	}
@@ -97,19 +100,8 @@ func (h *NewsmthHandler) transformData(items []NewsmthTopic) []models.HotData {
 			author = post.Account.Name
 		}
 
-		// 处理PostTime字段(可能是number或string)
-		var timestamp string
-		switch v := post.PostTime.(type) {
-		case float64:
-			// API返回的是Unix时间戳(秒)
-			timestamp = fmt.Sprintf("%d", int64(v))
-		case int64:
-			timestamp = fmt.Sprintf("%d", v)
-		case string:
-			timestamp = v
-		default:
-			timestamp = ""
-		}
+		// 处理PostTime字段(可能是number或string,数字为Unix时间戳(秒))
+		timestamp := models.ToString(post.PostTime)
 
 		hotData := models.HotData{
 			ID:        item.FirstArticleID,
@@ -33,9 +33,12 @@ func (h *NgabbsHandler) GetPath() string {
 // Handle 处理请求
 func (h *NgabbsHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
-	data, err := h.fetchNgabbs(c.Context())
+	cacheKey := "ngabbs"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchNgabbs(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	return c.JSON(models.SuccessResponse(
@@ -46,7 +49,7 @@ func (h *NgabbsHandler) Handle(c *fiber.Ctx) error {
 		"https://bbs.nga.cn",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	))
 }
 
@@ -58,7 +61,7 @@ func (h *NgabbsHandler) fetchNgabbs(ctx context.Context) ([]models.HotData, erro
 	formData := url.Values{}
 	formData.Set("__output", "14")
 
-	// 发起 HTTP POST 请求
+	// 发起 HTTP POST 请求(声明了 br,压缩的响应体由 HTTP 客户端统一解码)
 	httpClient := h.fetcher.GetHTTPClient()
 	headers := map[string]string{
 		"Accept":          "*/*",
@@ -72,7 +75,7 @@ func (h *NgabbsHandler) fetchNgabbs(ctx context.Context) ([]models.HotData, erro
 		"Accept-Language": "zh-Hans-CN;q=1",
 	}
 
-	body, err := httpClient.Post(apiURL, []byte(formData.Encode()), headers)
+	body, err := httpClient.PostContext(ctx, apiURL, []byte(formData.Encode()), headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求 NGA API 失败: %w", err)
 	}
@@ -85,7 +88,7 @@ func (h *NgabbsHandler) fetchNgabbs(ctx context.Context) ([]models.HotData, erro
 
 	// 检查数据
 	if len(apiResp.Result) == 0 {
-		return nil, fmt.Errorf("NGA 数据为空")
+		return nil, fmt.Errorf("NGA %w", service.ErrEmptyData)
 	}
 
 	items, err := decodeNgabbsItems(apiResp.Result[0])
@@ -108,7 +111,7 @@ func (h *NgabbsHandler) transformData(items []NgabbsItem) []models.HotData {
 
 		hotData := models.HotData{
 			ID:        strconv.FormatInt(tid, 10),
-			Title:     normalizeNgabbsSubject(item.Subject),
+			Title:     models.ToString(item.Subject),
 			Author:    item.Author,
 			Hot:       replies,
 			Timestamp: postdate * 1000, // 时间戳转换为毫秒(秒级×1000)
@@ -122,26 +125,6 @@ func (h *NgabbsHandler) transformData(items []NgabbsItem) []models.HotData {
 	return result
 }
 
-func normalizeNgabbsSubject(subject interface{}) string {
-	switch v := subject.(type) {
-	case string:
-		return v
-	case bool:
-		if v {
-			return "true"
-		}
-		return ""
-	case json.Number:
-		return v.String()
-	case float64:
-		return strconv.FormatFloat(v, 'f', -1, 64)
-	case nil:
-		return ""
-	default:
-		return fmt.Sprintf("%v", v)
-	}
-}
-
 // 以下是 NGA API 的响应结构体定义
 
 type NgabbsAPIResponse struct {
@@ -34,9 +34,12 @@ func (h *NodeseekHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 直接调用fetch函数获取数据
-	data, err := h.fetchNodeseek(c.Context())
+	cacheKey := "nodeseek"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchNodeseek(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建响应
@@ -48,7 +51,7 @@ func (h *NodeseekHandler) Handle(c *fiber.Ctx) error {
 		"https://www.nodeseek.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -64,7 +67,7 @@ func (h *NodeseekHandler) fetchNodeseek(ctx context.Context) ([]models.HotData,
 		"Accept":     "application/json",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求 NodeSeek feed 失败: %w", err)
 	}
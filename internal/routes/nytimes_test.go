@@ -0,0 +1,104 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// nytimesChinaFixture 纽约时报中文网 RSS(节选)
+const nytimesChinaFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+  <title>纽约时报中文网 国际纵览</title>
+  <link>https://cn.nytimes.com</link>
+  <item>
+    <title><![CDATA[全球气候峰会达成新协议]]></title>
+    <link>https://cn.nytimes.com/world/20240501/climate-summit/</link>
+    <guid isPermaLink="false">https://cn.nytimes.com/world/20240501/climate-summit/</guid>
+    <description><![CDATA[<p><img src="https://static01.nyt.com/images/2024/05/01/climate.jpg"/></p><p>各国代表在会议上承诺<strong>加速</strong>减排。</p>]]></description>
+    <dc:creator>SOMINI SENGUPTA</dc:creator>
+    <pubDate>Wed, 01 May 2024 02:30:00 +0800</pubDate>
+    <media:content url="https://static01.nyt.com/images/2024/05/01/climate-thumb.jpg" medium="image"/>
+  </item>
+  <item>
+    <title>   </title>
+    <link>https://cn.nytimes.com/empty/</link>
+  </item>
+  <item>
+    <title>没有发布时间的文章</title>
+    <link>https://cn.nytimes.com/world/20240430/no-date/</link>
+  </item>
+</channel>
+</rss>`
+
+func TestNYTimesParseFeed(t *testing.T) {
+	parser := NewFeedParser(nil)
+	feed, err := parser.Parse(nytimesChinaFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &NYTimesHandler{}
+	data := h.transformData(parser.ToHotData(feed.Items))
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "https://cn.nytimes.com/world/20240501/climate-summit/" || first.Title != "全球气候峰会达成新协议" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Desc != "各国代表在会议上承诺加速减排。" {
+		t.Errorf("Desc = %q, want plain text", first.Desc)
+	}
+	if first.Author != "SOMINI SENGUPTA" || first.Cover != "https://static01.nyt.com/images/2024/05/01/climate-thumb.jpg" {
+		t.Errorf("Author = %q, Cover = %q", first.Author, first.Cover)
+	}
+	if want := time.Date(2024, 4, 30, 18, 30, 0, 0, time.UTC).UnixMilli(); first.Timestamp != want {
+		t.Errorf("Timestamp = %v, want %d", first.Timestamp, want)
+	}
+
+	if data[1].Timestamp != nil || data[1].ID != "https://cn.nytimes.com/world/20240430/no-date/" {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+}
+
+func TestNYTimesFetchArea(t *testing.T) {
+	var requested string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, nytimesChinaFixture)
+	}))
+	defer upstream.Close()
+
+	original := nytimesFeedURLs
+	nytimesFeedURLs = map[string]string{
+		"china":  upstream.URL + "/china",
+		"global": upstream.URL + "/global",
+	}
+	defer func() { nytimesFeedURLs = original }()
+
+	h := &NYTimesHandler{fetcher: newTestFetcher(t)}
+	tests := []struct {
+		area string
+		path string
+	}{
+		{"global", "/global"},
+		{"china", "/china"},
+		{"unknown", "/china"},
+	}
+	for _, tt := range tests {
+		data, err := h.fetchNYTimes(context.Background(), tt.area)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.area, err)
+		}
+		if requested != tt.path || len(data) != 2 {
+			t.Errorf("%s: requested %q with %d items, want %q", tt.area, requested, len(data), tt.path)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+// oschinaTypeMap 榜单类型映射
+var oschinaTypeMap = map[string]string{
+	"news": "综合资讯",
+	"blog": "热门博客",
+}
+
+// oschinaSources 各类型的列表页与 RSS 地址
+// 列表页包含阅读数,RSS 作为列表页结构变化时的备用来源
+var oschinaSources = map[string]struct {
+	listURL string
+	rssURL  string
+}{
+	"news": {listURL: "https://www.oschina.net/news/industry", rssURL: "https://www.oschina.net/news/rss"},
+	"blog": {listURL: "https://www.oschina.net/blog?sort=hot", rssURL: "https://www.oschina.net/blog/rss"},
+}
+
+// oschinaViewsPattern 从 "阅读 1.2K"、"1234 阅读" 中提取阅读数
+var oschinaViewsPattern = regexp.MustCompile(`(?:阅读\s*([\d.]+[kKwW万]?))|(?:([\d.]+[kKwW万]?)\s*阅读)`)
+
+// oschinaIDPattern 从链接中提取文章 ID,如 /news/123456 或 /blog/123456
+var oschinaIDPattern = regexp.MustCompile(`/(?:news|blog)/(\d+)`)
+
+// OschinaHandler 开源中国处理器
+type OschinaHandler struct {
+	fetcher *service.Fetcher
+}
+
+// NewOschinaHandler 创建开源中国处理器
+func NewOschinaHandler(fetcher *service.Fetcher) *OschinaHandler {
+	return &OschinaHandler{
+		fetcher: fetcher,
+	}
+}
+
+// GetPath 获取路由路径
+func (h *OschinaHandler) GetPath() string {
+	return "/oschina"
+}
+
+// Handle 处理请求
+func (h *OschinaHandler) Handle(c *fiber.Ctx) error {
+	listType := c.Query("type", "news")
+	if _, ok := oschinaTypeMap[listType]; !ok {
+		listType = "news"
+	}
+	noCache := c.Query("cache") == "false"
+
+	cacheKey := fmt.Sprintf("oschina_%s", listType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchOschina(ctx, listType)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	resp := models.SuccessResponse(
+		"oschina",                  // name: 平台调用名称
+		"开源中国",                     // title: 平台显示名称
+		oschinaTypeMap[listType],   // type: 榜单类型
+		"中文开源技术交流社区",               // description: 平台描述
+		"https://www.oschina.net/", // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": oschinaTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
+	)
+
+	return c.JSON(resp)
+}
+
+// fetchOschina 获取开源中国数据(列表页优先,失败或无数据时回退 RSS)
+func (h *OschinaHandler) fetchOschina(ctx context.Context, listType string) ([]models.HotData, error) {
+	source := oschinaSources[listType]
+
+	// 策略1: 列表页(包含阅读数)
+	body, err := h.fetcher.Get(ctx, source.listURL, map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Referer":    "https://www.oschina.net/",
+	})
+	if err == nil {
+		if data := h.parseHTML(string(body)); len(data) > 0 {
+			return data, nil
+		}
+	}
+
+	// 策略2: RSS(没有阅读数)
+	parser := NewFeedParser(h.fetcher.GetHTTPClient())
+	feed, err := parser.Fetch(ctx, source.rssURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取开源中国数据失败: %w", err)
+	}
+
+	data := parser.ToHotData(feed.Items)
+	for i := range data {
+		data[i].Desc = stripHTMLTags(data[i].Desc)
+		if matches := oschinaIDPattern.FindStringSubmatch(data[i].URL); len(matches) > 1 {
+			data[i].ID = matches[1]
+		}
+	}
+	return data, nil
+}
+
+// parseHTML 解析资讯/博客列表页
+func (h *OschinaHandler) parseHTML(html string) []models.HotData {
+	result := make([]models.HotData, 0)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return result
+	}
+
+	doc.Find(".news-item, .blog-item").Each(func(i int, s *goquery.Selection) {
+		link := s.Find(".header a, a.header, .title a").First()
+		title := strings.TrimSpace(link.AttrOr("title", link.Text()))
+		href := link.AttrOr("href", "")
+		if title == "" || href == "" {
+			return
+		}
+		if strings.HasPrefix(href, "/") {
+			href = "https://www.oschina.net" + href
+		}
+
+		id := href
+		if matches := oschinaIDPattern.FindStringSubmatch(href); len(matches) > 1 {
+			id = matches[1]
+		}
+
+		// 底部信息栏: 作者、发布时间、阅读数
+		extra := s.Find(".extra, .footer")
+		author := strings.TrimSpace(extra.Find(".mr, .author, a").First().Text())
+
+		var views int64
+		if matches := oschinaViewsPattern.FindStringSubmatch(extra.Text()); matches != nil {
+			views = parseAbbrevCount(strings.ReplaceAll(matches[1]+matches[2], "万", "w"))
+		}
+
+		var timestamp interface{}
+		if ts := timeutil.ParseTime(strings.TrimSpace(extra.Find("[title]").AttrOr("title", ""))); ts > 0 {
+			timestamp = ts
+		}
+
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     title,
+			Desc:      strings.TrimSpace(s.Find(".description, .summary").First().Text()),
+			Author:    author,
+			Hot:       views,
+			Timestamp: timestamp,
+			URL:       href,
+			MobileURL: href,
+		})
+	})
+
+	return result
+}
@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// errInvalidParam 查询参数不合法
+var errInvalidParam = errors.New("参数不合法")
+
+// paramRule 查询参数校验规则,返回参数是否合法
+type paramRule func(value string) bool
+
+var (
+	// numericParamPattern 纯数字 ID(允许负号,如 acfun 综合频道 -1)
+	numericParamPattern = regexp.MustCompile(`^-?[0-9]{1,20}$`)
+	// slugParamPattern 标识符类参数: 字母、数字、下划线与连字符
+	// 不含 / ? & # % 等字符,拼进上游 URL 时不会改变路径或追加参数
+	slugParamPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+)
+
+// isNumeric 纯数字参数,如分区 ID、频道 ID
+func isNumeric(value string) bool {
+	return numericParamPattern.MatchString(value)
+}
+
+// isSlug 标识符类参数,如 daily、digest
+// 用于取值较多、不便穷举的类型参数
+func isSlug(value string) bool {
+	return slugParamPattern.MatchString(value)
+}
+
+// oneOf 枚举参数,只允许 allowed 中的键
+func oneOf[V any](allowed map[string]V) paramRule {
+	return func(value string) bool {
+		_, ok := allowed[value]
+		return ok
+	}
+}
+
+// intRange 整数参数,取值在 [min, max] 之间
+func intRange(min, max int) paramRule {
+	return func(value string) bool {
+		n, err := strconv.Atoi(value)
+		return err == nil && n >= min && n <= max
+	}
+}
+
+// positiveInt 正整数参数,如天数
+func positiveInt(value string) bool {
+	n, err := strconv.Atoi(value)
+	return err == nil && n > 0
+}
+
+// nonNegativeFloat 非负数字参数,如震级
+func nonNegativeFloat(value string) bool {
+	f, err := strconv.ParseFloat(value, 64)
+	return err == nil && f >= 0
+}
+
+// shortText 自由文本参数(如标签名),非空、不超过 max 个字符且不含控制字符
+// 拼进上游 URL 前仍需转义
+func shortText(max int) paramRule {
+	return func(value string) bool {
+		if value == "" || utf8.RuneCountInString(value) > max {
+			return false
+		}
+		return !strings.ContainsFunc(value, unicode.IsControl)
+	}
+}
+
+// validateParam 校验查询参数
+// 会拼进上游 URL 或缓存键的参数都应先校验,避免把任意内容透传给上游
+func validateParam(name, value string, rule paramRule) error {
+	if rule(value) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s=%q", errInvalidParam, name, value)
+}
+
+// paramError 返回参数不合法的 400 错误响应
+func paramError(c *fiber.Ctx, err error) error {
+	platform, _ := c.Locals(service.PlatformContextKey).(string)
+	return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, err.Error()).
+		WithType(models.ErrorTypeInvalidParam).
+		WithPlatform(platform).
+		WithTraceID(requestTraceID(c)))
+}
@@ -2,16 +2,43 @@ package routes
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/dailyhot/api/internal/logger"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
+// productHuntAPIURL Product Hunt GraphQL API(v2),需要开发者令牌
+const productHuntAPIURL = "https://api.producthunt.com/v2/api/graphql"
+
+// productHuntPostsQuery 按排名查询当天发布的产品
+const productHuntPostsQuery = `query($postedAfter: DateTime) {
+  posts(first: 30, order: RANKING, postedAfter: $postedAfter) {
+    edges {
+      node {
+        id
+        name
+        tagline
+        slug
+        url
+        votesCount
+        commentsCount
+        createdAt
+        thumbnail { url }
+        user { name }
+      }
+    }
+  }
+}`
+
 // ProductHuntHandler Product Hunt处理器
 type ProductHuntHandler struct {
 	fetcher *service.Fetcher
@@ -35,9 +62,12 @@ func (h *ProductHuntHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchProductHuntHot(c.Context())
+	cacheKey := "producthunt"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchProductHuntHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -49,18 +79,108 @@ func (h *ProductHuntHandler) Handle(c *fiber.Ctx) error {
 		"https://www.producthunt.com/", // link: 官方链接
 		nil,                            // params: 无参数映射
 		data,                           // data: 热榜数据
-		!noCache,                       // fromCache: 是否来自缓存
+		fromCache,                      // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
 // fetchProductHuntHot 从Product Hunt获取数据
+// 配置了 platforms.producthunt.token 时使用 GraphQL API(带票数),未配置、API 失败或当天暂无产品时回退到 Atom feed(无票数)
 func (h *ProductHuntHandler) fetchProductHuntHot(ctx context.Context) ([]models.HotData, error) {
+	if token := h.fetcher.PlatformToken("producthunt"); token != "" {
+		data, err := h.fetchProductHuntAPI(ctx, token)
+		if err == nil && len(data) > 0 {
+			return data, nil
+		}
+		// 太平洋时间刚过零点时当天还没有产品,同样回退
+		logger.Warn("Product Hunt API 没有返回数据,回退到 feed", zap.Error(err))
+	}
+
+	return h.fetchProductHuntFeed(ctx)
+}
+
+// fetchProductHuntAPI 通过 GraphQL API 获取当天按排名排序的产品
+// Product Hunt 按太平洋时间划分"今天"
+func (h *ProductHuntHandler) fetchProductHuntAPI(ctx context.Context, token string) ([]models.HotData, error) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		loc = time.FixedZone("PST", -8*3600)
+	}
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.PostContext(ctx, productHuntAPIURL, map[string]interface{}{
+		"query": productHuntPostsQuery,
+		"variables": map[string]interface{}{
+			"postedAfter": today.Format(time.RFC3339),
+		},
+	}, map[string]string{
+		"Authorization": "Bearer " + token,
+		"Content-Type":  "application/json",
+		"Accept":        "application/json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求 Product Hunt API 失败: %w", err)
+	}
+
+	data, err := h.parseAPI(body)
+	if err != nil {
+		return nil, fmt.Errorf("解析 Product Hunt API 响应失败: %w", err)
+	}
+	return data, nil
+}
+
+// parseAPI 解析 GraphQL API 响应,票数填入 Hot
+func (h *ProductHuntHandler) parseAPI(body []byte) ([]models.HotData, error) {
+	var apiResp productHuntAPIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, err
+	}
+	if len(apiResp.Errors) > 0 {
+		return nil, fmt.Errorf("%s", apiResp.Errors[0].Message)
+	}
+
+	edges := apiResp.Data.Posts.Edges
+	result := make([]models.HotData, 0, len(edges))
+	for _, edge := range edges {
+		node := edge.Node
+		title := strings.TrimSpace(node.Name)
+		if title == "" {
+			continue
+		}
+
+		url := node.URL
+		if url == "" {
+			url = fmt.Sprintf("https://www.producthunt.com/posts/%s", node.Slug)
+		}
+
+		result = append(result, models.HotData{
+			ID:        node.ID,
+			Title:     title,
+			Desc:      strings.TrimSpace(node.Tagline),
+			Cover:     node.Thumbnail.URL,
+			Author:    node.User.Name,
+			Hot:       node.VotesCount,
+			Timestamp: timeutil.ParseTime(node.CreatedAt),
+			URL:       url,
+			MobileURL: url,
+			Extra: map[string]interface{}{
+				"comments": node.CommentsCount,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// fetchProductHuntFeed 从 Atom feed 获取数据(不含票数)
+func (h *ProductHuntHandler) fetchProductHuntFeed(ctx context.Context) ([]models.HotData, error) {
 	feedURL := "https://www.producthunt.com/feed"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(feedURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, feedURL, map[string]string{
 		"Accept":          "application/atom+xml, application/xml;q=0.9, */*;q=0.8",
 		"Accept-Language": "en-US,en;q=0.9",
 	})
@@ -116,6 +236,36 @@ func (h *ProductHuntHandler) parseFeed(data []byte) ([]models.HotData, error) {
 	return result, nil
 }
 
+type productHuntAPIResponse struct {
+	Data struct {
+		Posts struct {
+			Edges []struct {
+				Node productHuntPost `json:"node"`
+			} `json:"edges"`
+		} `json:"posts"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type productHuntPost struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Tagline       string `json:"tagline"`
+	Slug          string `json:"slug"`
+	URL           string `json:"url"`
+	VotesCount    int64  `json:"votesCount"`
+	CommentsCount int64  `json:"commentsCount"`
+	CreatedAt     string `json:"createdAt"`
+	Thumbnail     struct {
+		URL string `json:"url"`
+	} `json:"thumbnail"`
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
 type productHuntFeed struct {
 	Entries []productHuntEntry `xml:"entry"`
 }
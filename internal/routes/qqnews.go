@@ -4,13 +4,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
+// qqnewsTypeMap 榜单类型映射: type 参数 -> 榜单名称
+// hot 为综合热点榜,其余为频道热门(type 即频道 ID)
+var qqnewsTypeMap = map[string]string{
+	"hot":     "热点榜",
+	"tech":    "科技",
+	"ent":     "娱乐",
+	"sports":  "体育",
+	"finance": "财经",
+	"milite":  "军事",
+	"game":    "游戏",
+}
+
+// qqnewsHotAPI 综合热点榜接口
+var qqnewsHotAPI = "https://r.inews.qq.com/gw/event/hot_ranking_list?page_size=50"
+
+// qqnewsChannelAPI 频道热门列表接口,sub_srv_id 为频道 ID
+var qqnewsChannelAPI = "https://i.news.qq.com/trpc.qqnews_web.kv_srv.kv_srv_http_proxy/list"
+
+// qqnewsAdFilter 广告识别规则: 过滤榜单置顶卡片
+var qqnewsAdFilter = models.ItemFilter{
+	Flags: []string{"pinned"},
+}
+
 // QQNewsHandler 腾讯新闻处理器
 type QQNewsHandler struct {
 	fetcher *service.Fetcher
@@ -30,25 +57,40 @@ func (h *QQNewsHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *QQNewsHandler) Handle(c *fiber.Ctx) error {
-	// 获取缓存标志
+	// 获取查询参数
+	newsType := c.Query("type", "hot")
+	if err := validateParam("type", newsType, oneOf(qqnewsTypeMap)); err != nil {
+		return paramError(c, err)
+	}
 	noCache := c.Query("cache") == "false"
 
-	// 获取数据
-	data, err := h.fetchQQNews(c.Context())
+	// 获取数据(综合热点榜沿用原缓存键)
+	cacheKey := "qq-news"
+	if newsType != "hot" {
+		cacheKey = fmt.Sprintf("qq-news_%s", newsType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if newsType == "hot" {
+			return h.fetchQQNews(ctx)
+		}
+		return h.fetchChannel(ctx, newsType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
-		"qq-news",              // name: 平台调用名称
-		"腾讯新闻",                 // title: 平台显示名称
-		"热点榜",                  // type: 榜单类型
-		"发现腾讯新闻热门资讯",           // description: 平台描述
-		"https://news.qq.com/", // link: 官方链接
-		nil,                    // params: 无参数映射
-		data,                   // data: 热榜数据
-		!noCache,               // fromCache: 是否来自缓存
+		"qq-news",               // name: 平台调用名称
+		"腾讯新闻",                  // title: 平台显示名称
+		qqnewsTypeMap[newsType], // type: 榜单类型
+		"发现腾讯新闻热门资讯",            // description: 平台描述
+		"https://news.qq.com/",  // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": qqnewsTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -56,11 +98,9 @@ func (h *QQNewsHandler) Handle(c *fiber.Ctx) error {
 
 // fetchQQNews 从腾讯新闻 API 获取数据
 func (h *QQNewsHandler) fetchQQNews(ctx context.Context) ([]models.HotData, error) {
-	apiURL := "https://r.inews.qq.com/gw/event/hot_ranking_list?page_size=50"
-
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, qqnewsHotAPI, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求腾讯新闻 API 失败: %w", err)
 	}
@@ -73,24 +113,108 @@ func (h *QQNewsHandler) fetchQQNews(ctx context.Context) ([]models.HotData, erro
 
 	// 检查数据
 	if len(apiResp.IDList) == 0 || len(apiResp.IDList[0].NewsList) == 0 {
-		return nil, fmt.Errorf("腾讯新闻数据为空")
+		return nil, fmt.Errorf("腾讯新闻%w", service.ErrEmptyData)
 	}
 
-	// 跳过第一个(通常是广告或置顶)
-	newsList := apiResp.IDList[0].NewsList
-	if len(newsList) > 1 {
-		newsList = newsList[1:]
+	// 转换为统一格式并过滤置顶项
+	data := h.transformData(apiResp.IDList[0].NewsList)
+	return h.fetcher.FilterItems("qqnews", qqnewsAdFilter, data), nil
+}
+
+// fetchChannel 获取频道热门列表
+func (h *QQNewsHandler) fetchChannel(ctx context.Context, channel string) ([]models.HotData, error) {
+	query := url.Values{}
+	query.Set("sub_srv_id", channel)
+	query.Set("srv_id", "pc")
+	query.Set("offset", "0")
+	query.Set("limit", "50")
+	query.Set("strategy", "1")
+	query.Set("ext", `{"pool":["top","hot"],"is_filter":7,"check_type":true}`)
+
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, qqnewsChannelAPI+"?"+query.Encode(), map[string]string{
+		"Referer": "https://news.qq.com/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求腾讯新闻%s频道失败: %w", qqnewsTypeMap[channel], err)
 	}
 
-	// 转换为统一格式
-	return h.transformData(newsList), nil
+	return h.parseChannel(channel, body)
+}
+
+// parseChannel 解析频道列表响应
+// 频道列表与综合热点榜结构不同: ID 为 cms_id、时间为日期字符串、没有热度分数,
+// 部分频道的条目只有 url 没有 cms_id,封面字段也因频道而异
+func (h *QQNewsHandler) parseChannel(channel string, body []byte) ([]models.HotData, error) {
+	var apiResp QQNewsChannelResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析腾讯新闻%s频道响应失败: %w", qqnewsTypeMap[channel], err)
+	}
+	if apiResp.Ret != 0 {
+		return nil, fmt.Errorf("腾讯新闻%s频道返回错误: %s", qqnewsTypeMap[channel], apiResp.Msg)
+	}
+
+	result := make([]models.HotData, 0, len(apiResp.Data.List))
+	for _, item := range apiResp.Data.List {
+		title := strings.TrimSpace(item.Title)
+		id := item.CmsID
+		if id == "" {
+			id = extractQQNewsID(item.URL)
+		}
+		if title == "" || id == "" {
+			continue
+		}
+
+		cover := item.Img
+		if cover == "" {
+			cover = item.ThumbNail2x
+		}
+		if cover == "" {
+			cover = item.ThumbNail
+		}
+
+		hotData := models.HotData{
+			ID:        id,
+			Title:     title,
+			Desc:      strings.TrimSpace(item.Intro),
+			Cover:     cover,
+			Author:    item.MediaName,
+			Timestamp: timeutil.ParseTimeIn(item.PublishTime, timeutil.Beijing, time.Now()),
+			URL:       fmt.Sprintf("https://new.qq.com/rain/a/%s", id),
+			MobileURL: fmt.Sprintf("https://view.inews.qq.com/k/%s", id),
+		}
+		if item.CommentNum > 0 {
+			hotData.Extra = map[string]interface{}{"comments": item.CommentNum}
+		}
+
+		result = append(result, hotData)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("腾讯新闻%s频道%w", qqnewsTypeMap[channel], service.ErrEmptyData)
+	}
+	return h.fetcher.FilterItems("qqnews", qqnewsAdFilter, result), nil
+}
+
+// extractQQNewsID 从文章链接中提取 ID,如 https://new.qq.com/rain/a/20240101A01ABC00
+func extractQQNewsID(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	path = strings.TrimSuffix(path, ".html")
+	if idx := strings.LastIndex(path, "/"); idx != -1 {
+		return path[idx+1:]
+	}
+	return ""
 }
 
 // transformData 将腾讯新闻原始数据转换为统一格式
 func (h *QQNewsHandler) transformData(items []QQNewsItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
-	for _, item := range items {
+	for i, item := range items {
 		// 转换时间戳(秒)
 		timestamp := strconv.FormatInt(item.Timestamp, 10)
 
@@ -105,6 +229,10 @@ func (h *QQNewsHandler) transformData(items []QQNewsItem) []models.HotData {
 			URL:       fmt.Sprintf("https://new.qq.com/rain/a/%s", item.ID),
 			MobileURL: fmt.Sprintf("https://view.inews.qq.com/k/%s", item.ID),
 		}
+		// 列表第一项是榜单的置顶卡片(通常是广告或专题),不是热点新闻
+		if i == 0 && len(items) > 1 {
+			hotData.Extra = map[string]interface{}{"pinned": true}
+		}
 
 		result = append(result, hotData)
 	}
@@ -139,3 +267,26 @@ type QQNewsItem struct {
 type QQNewsHotEvent struct {
 	HotScore int64 `json:"hotScore"` // 热度分数
 }
+
+// QQNewsChannelResponse 频道列表 API 响应
+type QQNewsChannelResponse struct {
+	Ret  int    `json:"ret"`
+	Msg  string `json:"msg"`
+	Data struct {
+		List []QQNewsChannelItem `json:"list"`
+	} `json:"data"`
+}
+
+// QQNewsChannelItem 频道列表中的新闻项
+type QQNewsChannelItem struct {
+	CmsID       string `json:"cms_id"`        // 新闻 ID
+	Title       string `json:"title"`         // 标题
+	Intro       string `json:"intro"`         // 摘要
+	URL         string `json:"url"`           // 文章链接
+	MediaName   string `json:"media_name"`    // 来源媒体
+	PublishTime string `json:"publish_time"`  // 发布时间(北京时间),如 "2024-01-01 12:00:00"
+	Img         string `json:"img"`           // 封面图
+	ThumbNail   string `json:"thumb_nail"`    // 缩略图
+	ThumbNail2x string `json:"thumb_nail_2x"` // 高清缩略图
+	CommentNum  int64  `json:"comment_num"`   // 评论数
+}
@@ -0,0 +1,165 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+// qqnewsHotFixture 综合热点榜接口响应(节选),第一项为置顶卡片
+const qqnewsHotFixture = `{"ret":0,"idlist":[{"newslist":[
+  {"id":"TWF2024051000000000","title":"腾讯新闻热点榜","abstract":"","miniProShareImage":"","source":"","timestamp":0,"hotEvent":{"hotScore":0}},
+  {"id":"20240510A01ABC00","title":"多地迎来降雨降温","abstract":"中央气象台发布预报","miniProShareImage":"https://inews.gtimg.com/news_bt/a.jpg","source":"央视新闻","timestamp":1715313600,"hotEvent":{"hotScore":4980000}},
+  {"id":"20240510A02DEF00","title":"新能源汽车销量同比增长","abstract":"","miniProShareImage":"","source":"第一财经","timestamp":1715310000,"hotEvent":{"hotScore":3720000}}
+]}]}`
+
+// qqnewsChannelFixture 科技频道列表接口响应(节选)
+// 第二项只有 url 没有 cms_id,第三项只有缩略图,第四项没有标题
+const qqnewsChannelFixture = `{"ret":0,"msg":"","data":{"list":[
+  {"cms_id":"20240510A03GHI00","title":"国产大模型发布新版本","intro":" 支持更长上下文 ","url":"https://new.qq.com/rain/a/20240510A03GHI00","media_name":"腾讯科技","publish_time":"2024-05-10 12:00:00","img":"https://inews.gtimg.com/news_bt/img.jpg","thumb_nail":"https://inews.gtimg.com/news_bt/t.jpg","comment_num":356},
+  {"cms_id":"","title":"手机厂商公布新品发布会时间","url":"https://new.qq.com/rain/a/20240510A04JKL00.html","media_name":"IT之家","publish_time":"2024-05-10 11:30:00","img":"","thumb_nail_2x":"https://inews.gtimg.com/news_bt/t2x.jpg","thumb_nail":"https://inews.gtimg.com/news_bt/t1x.jpg"},
+  {"cms_id":"20240510A05MNO00","title":"芯片产业链调查","media_name":"财经","publish_time":"2024-05-10 10:00:00","thumb_nail":"https://inews.gtimg.com/news_bt/only.jpg"},
+  {"cms_id":"20240510A06PQR00","title":"  ","publish_time":"2024-05-10 09:00:00"}
+]}}`
+
+func TestQQNewsTransformData(t *testing.T) {
+	var resp QQNewsAPIResponse
+	if err := json.Unmarshal([]byte(qqnewsHotFixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &QQNewsHandler{}
+	data := h.transformData(resp.IDList[0].NewsList)
+	if len(data) != 3 {
+		t.Fatalf("len(data) = %d, want 3", len(data))
+	}
+	if data[0].Extra["pinned"] != true {
+		t.Errorf("data[0].Extra = %v, want pinned", data[0].Extra)
+	}
+	second := data[1]
+	if second.ID != "20240510A01ABC00" || second.Hot != int64(4980000) || second.Timestamp != "1715313600" || second.Extra != nil {
+		t.Errorf("data[1] = %+v", second)
+	}
+	if second.URL != "https://new.qq.com/rain/a/20240510A01ABC00" || second.MobileURL != "https://view.inews.qq.com/k/20240510A01ABC00" {
+		t.Errorf("URL = %q, MobileURL = %q", second.URL, second.MobileURL)
+	}
+}
+
+func TestQQNewsParseChannel(t *testing.T) {
+	h := &QQNewsHandler{fetcher: newTestFetcher(t)}
+	data, err := h.parseChannel("tech", []byte(qqnewsChannelFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 3 {
+		t.Fatalf("len(data) = %d, want 3", len(data))
+	}
+
+	first := data[0]
+	want := time.Date(2024, 5, 10, 12, 0, 0, 0, timeutil.Beijing).UnixMilli()
+	if first.ID != "20240510A03GHI00" || first.Desc != "支持更长上下文" || first.Author != "腾讯科技" || first.Timestamp != want {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://inews.gtimg.com/news_bt/img.jpg" || first.Extra["comments"] != int64(356) {
+		t.Errorf("Cover = %q, Extra = %v", first.Cover, first.Extra)
+	}
+
+	// 没有 cms_id 时从链接中提取,封面依次回退到高清缩略图、缩略图
+	if data[1].ID != "20240510A04JKL00" || data[1].Cover != "https://inews.gtimg.com/news_bt/t2x.jpg" || data[1].Extra != nil {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+	if data[2].Cover != "https://inews.gtimg.com/news_bt/only.jpg" {
+		t.Errorf("data[2].Cover = %q", data[2].Cover)
+	}
+}
+
+func TestQQNewsParseChannelErrors(t *testing.T) {
+	h := &QQNewsHandler{fetcher: newTestFetcher(t)}
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"接口返回错误", `{"ret":-1,"msg":"invalid sub_srv_id","data":{"list":[]}}`},
+		{"空列表", `{"ret":0,"data":{"list":[]}}`},
+		{"非 JSON", `<html>502 Bad Gateway</html>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := h.parseChannel("tech", []byte(tt.body)); err == nil {
+				t.Error("err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestExtractQQNewsID(t *testing.T) {
+	tests := map[string]string{
+		"https://new.qq.com/rain/a/20240101A01ABC00":            "20240101A01ABC00",
+		"https://new.qq.com/rain/a/20240101A01ABC00/":           "20240101A01ABC00",
+		"https://new.qq.com/omn/20240101/20240101A01ABC00.html": "20240101A01ABC00",
+		"": "",
+	}
+	for rawURL, want := range tests {
+		if got := extractQQNewsID(rawURL); got != want {
+			t.Errorf("extractQQNewsID(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestQQNewsType(t *testing.T) {
+	var requested string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hot" {
+			requested = "hot"
+			fmt.Fprint(w, qqnewsHotFixture)
+			return
+		}
+		requested = r.URL.Query().Get("sub_srv_id")
+		fmt.Fprint(w, qqnewsChannelFixture)
+	}))
+	defer upstream.Close()
+
+	originalHot, originalChannel := qqnewsHotAPI, qqnewsChannelAPI
+	qqnewsHotAPI, qqnewsChannelAPI = upstream.URL+"/hot", upstream.URL+"/list"
+	defer func() { qqnewsHotAPI, qqnewsChannelAPI = originalHot, originalChannel }()
+
+	h := &QQNewsHandler{fetcher: newTestFetcher(t)}
+	app := fiber.New()
+	app.Get(h.GetPath(), h.Handle)
+
+	tests := []struct {
+		query    string
+		want     string
+		wantType string
+		wantLen  int
+	}{
+		// 综合热点榜去掉置顶卡片
+		{"", "hot", "热点榜", 2},
+		{"&type=tech", "tech", "科技", 3},
+		{"&type=sports", "sports", "体育", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			status, result := getJSON(t, app, "/qq-news?cache=false"+tt.query)
+			if status != fiber.StatusOK {
+				t.Fatalf("status = %d", status)
+			}
+			if requested != tt.want || result["type"] != tt.wantType {
+				t.Errorf("requested = %q, type = %v", requested, result["type"])
+			}
+			if items, _ := result["data"].([]interface{}); len(items) != tt.wantLen {
+				t.Errorf("len(data) = %d, want %d", len(items), tt.wantLen)
+			}
+		})
+	}
+
+	if status, _ := getJSON(t, app, "/qq-news?type=unknown"); status != fiber.StatusBadRequest {
+		t.Errorf("type=unknown: status = %d, want 400", status)
+	}
+}
@@ -0,0 +1,335 @@
+package routes
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	httpclient "github.com/dailyhot/api/internal/http"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/go-shiori/go-readability"
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/transform"
+)
+
+const (
+	readabilityMaxBodySize = 2 << 20          // 网页最大 2MB,超出部分不解析
+	readabilityTimeout     = 10 * time.Second // 抓取超时时间
+	readabilityMaxRedirect = 5                // 最多跟随的重定向次数
+	readabilityDescLength  = 200              // 摘要长度(字符)
+
+	readabilityMaxContentLength = 5000  // 正文最多保留的字符数,超出部分截断
+	readabilityMaxElems         = 20000 // 最多解析的节点数,超出时放弃提取,避免超大页面占用 CPU
+	readabilityUserAgent        = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36"
+)
+
+// readabilityCharsetPattern 从 Content-Type 或 <meta charset> 中识别 GBK 系编码
+var readabilityCharsetPattern = regexp.MustCompile(`(?i)charset\s*=\s*["']?\s*(gbk|gb2312|gb18030)`)
+
+// ReadabilityHandler 网页正文提取处理器
+// 抓取任意网页,提取标题、正文、配图与发布时间,返回单条热榜格式的数据
+// 不属于热榜平台,不参与 /all 列表与平台自检
+type ReadabilityHandler struct {
+	fetcher *service.Fetcher
+	client  *http.Client // 只能访问公网地址的客户端
+}
+
+// NewReadabilityHandler 创建网页正文提取处理器
+func NewReadabilityHandler(fetcher *service.Fetcher) *ReadabilityHandler {
+	return &ReadabilityHandler{
+		fetcher: fetcher,
+		client:  httpclient.NewPublicOnlyClient(readabilityTimeout, readabilityMaxRedirect),
+	}
+}
+
+// GetPath 获取路由路径
+func (h *ReadabilityHandler) GetPath() string {
+	return "/readability"
+}
+
+// Handle 处理请求
+func (h *ReadabilityHandler) Handle(c *fiber.Ctx) error {
+	rawURL := strings.TrimSpace(c.Query("url"))
+	if rawURL == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, "缺少 url 参数"))
+	}
+
+	// 先校验地址,拒绝内网、回环等目标
+	target, err := httpclient.ValidatePublicURL(c.UserContext(), rawURL)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, err.Error()))
+	}
+	noCache := c.Query("cache") == "false"
+
+	sum := sha1.Sum([]byte(target.String()))
+	cacheKey := "readability_" + hex.EncodeToString(sum[:])
+	// 缓存键由用户提交的地址决定,不保存陈旧数据
+	data, fromCache, err := h.fetcher.FetchTransient(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchReadability(ctx, target)
+	})
+	if errors.Is(err, httpclient.ErrUnsafeTarget) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, err.Error()))
+	}
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	resp := models.SuccessResponse(
+		"readability", // name: 调用名称
+		"网页正文",        // title: 显示名称
+		"正文提取",        // type: 类型
+		"提取任意网页的标题、正文、配图与发布时间", // description: 描述
+		target.String(), // link: 原始链接
+		map[string]interface{}{ // params: 参数说明
+			"url": "需要提取正文的网页地址(仅支持公网 http/https)",
+		},
+		data,      // data: 单条数据
+		fromCache, // fromCache: 是否来自缓存
+	)
+
+	return c.JSON(resp)
+}
+
+// fetchReadability 抓取网页并提取正文
+func (h *ReadabilityHandler) fetchReadability(ctx context.Context, target *url.URL) ([]models.HotData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("User-Agent", readabilityUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求网页失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求网页失败: %w", &httpclient.StatusError{StatusCode: resp.StatusCode})
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if contentType != "" && !strings.Contains(contentType, "html") {
+		return nil, fmt.Errorf("不支持的内容类型: %s", contentType)
+	}
+
+	// 只读取前 readabilityMaxBodySize 字节,避免超大页面占用内存
+	body, err := io.ReadAll(io.LimitReader(resp.Body, readabilityMaxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("读取网页失败: %w", err)
+	}
+
+	html := h.decode(body, contentType)
+	item, err := h.extract(html, resp.Request.URL)
+	if err != nil {
+		return nil, err
+	}
+	return []models.HotData{item}, nil
+}
+
+// decode 将 GBK 系编码的网页转为 UTF-8
+func (h *ReadabilityHandler) decode(body []byte, contentType string) string {
+	head := body
+	if len(head) > 2048 {
+		head = head[:2048]
+	}
+	if readabilityCharsetPattern.MatchString(contentType) || readabilityCharsetPattern.Match(head) {
+		reader := transform.NewReader(strings.NewReader(string(body)), simplifiedchinese.GB18030.NewDecoder())
+		if decoded, err := io.ReadAll(reader); err == nil {
+			return string(decoded)
+		}
+	}
+	return string(body)
+}
+
+// extract 从网页中提取正文信息
+// 正文、标题、作者、摘要与配图由 go-readability 提取,缺失时再取 Open Graph 等元信息;
+// 发布时间取 article 元信息或 JSON-LD。正文最多保留 readabilityMaxContentLength 个字符
+func (h *ReadabilityHandler) extract(html string, pageURL *url.URL) (models.HotData, error) {
+	parser := readability.NewParser()
+	parser.MaxElemsToParse = readabilityMaxElems
+	article, err := parser.Parse(strings.NewReader(html), pageURL)
+	if err != nil {
+		return models.HotData{}, fmt.Errorf("提取网页正文失败: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return models.HotData{}, fmt.Errorf("解析网页失败: %w", err)
+	}
+
+	title := firstNonEmpty(
+		article.Title,
+		h.meta(doc, "og:title"),
+		h.meta(doc, "twitter:title"),
+		strings.TrimSpace(doc.Find("title").First().Text()),
+	)
+	content := readabilityText(article.TextContent)
+	if title == "" && content == "" {
+		return models.HotData{}, fmt.Errorf("网页正文%w", service.ErrEmptyData)
+	}
+
+	cover := firstNonEmpty(
+		article.Image,
+		h.meta(doc, "og:image"),
+		h.meta(doc, "twitter:image"),
+	)
+	if cover != "" {
+		cover = resolveURL(pageURL, cover)
+	}
+
+	ld := h.jsonLD(doc)
+	published := firstNonEmpty(
+		h.meta(doc, "article:published_time"),
+		h.meta(doc, "og:article:published_time"),
+		h.meta(doc, "pubdate"),
+		h.meta(doc, "publishdate"),
+		ld.DatePublished,
+		doc.Find("time[datetime]").First().AttrOr("datetime", ""),
+	)
+	author := firstNonEmpty(
+		article.Byline,
+		h.meta(doc, "author"),
+		h.meta(doc, "article:author"),
+		ld.authorName(),
+	)
+
+	desc := firstNonEmpty(article.Excerpt, h.meta(doc, "og:description"), h.meta(doc, "description"))
+	if desc == "" {
+		desc = strings.ReplaceAll(content, "\n", " ")
+	}
+
+	// 缓存键随用户提交的地址变化,正文截断后再缓存,限制单个条目的大小
+	length := utf8.RuneCountInString(content)
+	canonical := pageURL.String()
+	sum := sha1.Sum([]byte(canonical))
+	item := models.HotData{
+		ID:        hex.EncodeToString(sum[:8]),
+		Title:     title,
+		Desc:      models.TruncateRunes(desc, readabilityDescLength),
+		Cover:     cover,
+		Author:    author,
+		URL:       canonical,
+		MobileURL: canonical,
+		Extra: map[string]interface{}{
+			"site_name": firstNonEmpty(article.SiteName, h.meta(doc, "og:site_name"), pageURL.Hostname()),
+			"content":   models.TruncateRunes(content, readabilityMaxContentLength),
+			"length":    length,
+			"truncated": length > readabilityMaxContentLength,
+		},
+	}
+	if published != "" {
+		item.Timestamp = published
+	}
+	return item, nil
+}
+
+// readabilityText 整理正文纯文本: 每行去掉首尾空白并合并连续空白,丢弃空行
+func readabilityText(text string) string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// meta 读取 <meta property|name="key" content="...">
+func (h *ReadabilityHandler) meta(doc *goquery.Document, key string) string {
+	selector := fmt.Sprintf(`meta[property=%q], meta[name=%q], meta[itemprop=%q]`, key, key, key)
+	return strings.TrimSpace(doc.Find(selector).First().AttrOr("content", ""))
+}
+
+// jsonLD 读取页面中的 JSON-LD 文章元信息(取第一个带发布时间的对象)
+func (h *ReadabilityHandler) jsonLD(doc *goquery.Document) readabilityLD {
+	var result readabilityLD
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		raw := []byte(strings.TrimSpace(s.Text()))
+
+		var items []readabilityLD
+		if err := json.Unmarshal(raw, &items); err != nil {
+			var item readabilityLD
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return true
+			}
+			items = append(items, item)
+			items = append(items, item.Graph...)
+		}
+		for _, item := range items {
+			if item.DatePublished != "" {
+				result = item
+				return false
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// readabilityLD JSON-LD 中用到的字段
+type readabilityLD struct {
+	DatePublished string          `json:"datePublished"`
+	Author        json.RawMessage `json:"author"` // 可能是字符串、对象或数组
+	Graph         []readabilityLD `json:"@graph"`
+}
+
+// authorName 解析作者名
+func (ld readabilityLD) authorName() string {
+	if len(ld.Author) == 0 {
+		return ""
+	}
+
+	var name string
+	if err := json.Unmarshal(ld.Author, &name); err == nil {
+		return name
+	}
+
+	var author struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(ld.Author, &author); err == nil {
+		return author.Name
+	}
+
+	var authors []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(ld.Author, &authors); err == nil && len(authors) > 0 {
+		return authors[0].Name
+	}
+	return ""
+}
+
+// resolveURL 将相对地址转为绝对地址
+func resolveURL(base *url.URL, ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+const readabilityTestPage = `<!DOCTYPE html>
+<html><head>
+<title>测试文章 - 示例站</title>
+<meta property="og:title" content="测试文章">
+<meta property="og:image" content="/images/cover.jpg">
+<meta property="og:site_name" content="示例站">
+<meta property="article:published_time" content="2024-05-01T08:00:00+08:00">
+<meta name="author" content="张三">
+</head><body>
+<nav><a href="/">首页</a><a href="/news">新闻</a></nav>
+<article>
+<h1>测试文章</h1>
+<p>这是正文的第一段,介绍了文章的背景信息,内容足够长以便被正文提取算法识别为主要内容区域。</p>
+<p>这是正文的第二段,继续展开论述,并且同样包含足够多的文字,让段落得分超过导航和页脚等区域。</p>
+<p>这是正文的第三段,总结全文观点,提取结果应当包含这三段文字而不包含导航与评论区的内容。</p>
+</article>
+<div class="comments"><p>评论: 写得不错</p></div>
+<footer>版权所有</footer>
+</body></html>`
+
+func TestReadabilityExtract(t *testing.T) {
+	pageURL, _ := url.Parse("https://example.com/posts/1")
+	item, err := (&ReadabilityHandler{}).extract(readabilityTestPage, pageURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.Title != "测试文章" {
+		t.Errorf("Title = %q", item.Title)
+	}
+	if item.Cover != "https://example.com/images/cover.jpg" {
+		t.Errorf("Cover = %q", item.Cover)
+	}
+	if item.Author != "张三" {
+		t.Errorf("Author = %q", item.Author)
+	}
+	if item.Timestamp != "2024-05-01T08:00:00+08:00" {
+		t.Errorf("Timestamp = %v", item.Timestamp)
+	}
+	if item.URL != "https://example.com/posts/1" {
+		t.Errorf("URL = %q", item.URL)
+	}
+
+	content, _ := item.Extra["content"].(string)
+	if !strings.Contains(content, "第一段") || !strings.Contains(content, "第三段") {
+		t.Errorf("content 缺少正文段落: %q", content)
+	}
+	if strings.Contains(content, "首页") {
+		t.Errorf("content 不应包含导航: %q", content)
+	}
+	if item.Extra["site_name"] != "示例站" {
+		t.Errorf("site_name = %v", item.Extra["site_name"])
+	}
+	if item.Extra["truncated"] != false {
+		t.Errorf("truncated = %v, want false", item.Extra["truncated"])
+	}
+}
+
+func TestReadabilityExtractTruncatesContent(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("很长的正文内容。", 100) + "</p>"
+	page := "<html><head><title>长文</title></head><body><article>" +
+		strings.Repeat(paragraph, 20) + "</article></body></html>"
+
+	pageURL, _ := url.Parse("https://example.com/long")
+	item, err := (&ReadabilityHandler{}).extract(page, pageURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, _ := item.Extra["content"].(string)
+	// 截断后追加一个省略号
+	if n := utf8.RuneCountInString(content); n > readabilityMaxContentLength+1 {
+		t.Errorf("content 长度 = %d, 应截断到 %d", n, readabilityMaxContentLength)
+	}
+	if item.Extra["truncated"] != true {
+		t.Errorf("truncated = %v, want true", item.Extra["truncated"])
+	}
+	if length, _ := item.Extra["length"].(int); length <= readabilityMaxContentLength {
+		t.Errorf("length = %d, 应为截断前的长度", length)
+	}
+}
@@ -0,0 +1,221 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+// responseProcessor 响应后处理器
+// 平台处理器生成统一响应后,按请求参数对响应做二次加工
+// 只有至少一个处理器启用时才会重新解析响应体,不影响普通请求的性能
+//
+// 缓存策略: 缓存中只保存抓取得到的规范 HotData 列表,缓存键只包含决定数据来源的参数(如 type、name);
+// 后处理器读取的参数(params)属于展示层变体,每次请求即时计算,既不进入缓存键也不写入缓存,
+// 因此参数组合再多也不会让缓存膨胀。新增的格式转换(如字段筛选、其他输出格式)也应放在这一层
+type responseProcessor struct {
+	// params 该处理器读取的查询参数,不影响缓存键
+	params []string
+
+	// enabled 判断当前请求是否需要该处理,platform 为路由名
+	enabled func(c *fiber.Ctx, platform string) bool
+
+	// apply 对统一响应进行加工,platform 为路由名(如 "bilibili")
+	apply func(c *fiber.Ctx, platform string, resp *models.Response)
+}
+
+// responseProcessors 响应后处理器列表,按顺序执行
+var responseProcessors = []responseProcessor{
+	// 去重: ?dedup=true 启用,容易重复的平台默认启用(可用 ?dedup=false 关闭)
+	{
+		params: []string{"dedup"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			if dedup := c.Query("dedup"); dedup != "" {
+				return dedup == "true"
+			}
+			return dedupDefaultPlatforms[platform]
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			resp.Data = models.Dedup(resp.Data)
+			resp.Total = len(resp.Data)
+		},
+	},
+
+	// 安全模式: ?strip=true 清洗标题与描述中的 HTML,避免客户端直接渲染时的 XSS 风险
+	{
+		params: []string{"strip"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			return c.Query("strip") == "true"
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			models.SanitizeHotData(resp.Data)
+		},
+	},
+
+	// 描述截断: ?descLen=200 将描述按字符截断到指定长度并追加省略号,默认不截断
+	// 放在安全模式之后执行,按清洗后的纯文本计算长度
+	{
+		params: []string{"descLen"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			return c.QueryInt("descLen") > 0
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			models.TruncateDesc(resp.Data, c.QueryInt("descLen"))
+		},
+	},
+
+	// 多语言文案: ?lang=en 或 Accept-Language: en
+	{
+		params: []string{"lang"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			return requestLang(c) != models.LangZH
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			models.Localize(resp, platform, requestLang(c))
+		},
+	},
+
+	// 相对时间文案: ?humanize=true 为每条数据附加 time_text
+	{
+		params: []string{"humanize"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			return c.Query("humanize") == "true"
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			// 相对时间文案统一按北京时间展示
+			for i := range resp.Data {
+				resp.Data[i].TimeText = timeutil.Humanize(timeutil.ParseTime(resp.Data[i].Timestamp), timeutil.Beijing)
+			}
+		},
+	},
+
+	// 热度归一化: ?normalizeHot=true 按平台内最大值换算为 0~100 的 extra.hot_score,便于跨平台比较
+	{
+		params: []string{"normalizeHot"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			return c.Query("normalizeHot") == "true"
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			models.NormalizeHot(resp.Data)
+		},
+	},
+
+	// 缓存剩余时间: ?ttl=true 返回 cacheRemaining(秒),剩余时间由 Fetch 在读取缓存时记录
+	// 刚回源的数据为完整缓存时长,陈旧数据为 0;剩余时间未知(如仅 L1 且为重启前写入)时不返回
+	{
+		params: []string{"ttl"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			return c.Query("ttl") == "true"
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			recorder, ok := c.Locals(service.CacheRemainingContextKey).(*service.CacheRemaining)
+			if !ok {
+				return
+			}
+			if remaining, ok := recorder.Remaining(); ok {
+				seconds := int64(remaining.Round(time.Second) / time.Second)
+				resp.CacheRemaining = &seconds
+			}
+		},
+	},
+
+	// 排序: ?sort=hot|time 按热度或发布时间排序,?order=asc|desc(默认 desc),默认保持上游原始顺序
+	// 放在去重之后执行,去重时保留的仍是上游排在前面的条目
+	{
+		params: []string{"sort", "order"},
+		enabled: func(c *fiber.Ctx, platform string) bool {
+			sortBy := c.Query("sort")
+			return sortBy == models.SortHot || sortBy == models.SortTime
+		},
+		apply: func(c *fiber.Ctx, platform string, resp *models.Response) {
+			resp.Data = models.SortHotData(resp.Data, c.Query("sort"), c.Query("order") != "asc")
+		},
+	},
+}
+
+// responseParams 所有后处理器读取的查询参数(展示层参数,不进入缓存键)
+func responseParams() []string {
+	params := make([]string, 0, len(responseProcessors))
+	for _, p := range responseProcessors {
+		params = append(params, p.params...)
+	}
+	return params
+}
+
+// snapshotQueryParams 不影响抓取结果的查询参数: 展示层参数与缓存控制参数
+var snapshotQueryParams = func() map[string]bool {
+	params := map[string]bool{"cache": true}
+	for _, param := range responseParams() {
+		params[param] = true
+	}
+	return params
+}()
+
+// isCanonicalRequest 判断请求是否为平台的规范榜单,即没有影响抓取结果的查询参数
+// 只有规范榜单的抓取结果会保存快照,避免任意参数组合写满快照文件
+func isCanonicalRequest(c *fiber.Ctx) bool {
+	canonical := true
+	c.Request().URI().QueryArgs().VisitAll(func(key, _ []byte) {
+		if !snapshotQueryParams[string(key)] {
+			canonical = false
+		}
+	})
+	return canonical
+}
+
+// dedupDefaultPlatforms 默认启用去重的平台
+// RSS 聚合类来源经常在多个栏目中重复收录同一篇文章
+var dedupDefaultPlatforms = map[string]bool{
+	"nytimes":     true,
+	"theguardian": true,
+	"economist":   true,
+	"engadget":    true,
+}
+
+// requestLang 获取请求的响应语言
+func requestLang(c *fiber.Ctx) string {
+	return models.ParseLang(c.Query("lang"), c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// processResponse 对成功的平台响应执行后处理
+// fields 为平台配置的条目字段映射(platforms.<name>.fields),在序列化时展开;
+// 进程内调用需要解析规范字段,这里不展开映射: 后台刷新、SSE、webhook 推送与快照始终使用规范字段,
+// 批量查询与 GraphQL 面向调用方,由各自的序列化(BatchResult.Data、HotItem 的额外字段)再按映射展开
+func processResponse(c *fiber.Ctx, platform string, fields models.FieldMapping) error {
+	if c.Response().StatusCode() != fiber.StatusOK {
+		return nil
+	}
+	if isInternalCall(c) {
+		fields = models.FieldMapping{}
+	}
+
+	active := make([]responseProcessor, 0, len(responseProcessors))
+	for _, p := range responseProcessors {
+		if p.enabled(c, platform) {
+			active = append(active, p)
+		}
+	}
+	if len(active) == 0 && fields.IsZero() {
+		return nil
+	}
+
+	// 使用 UseNumber 保留数字原始精度,避免大整数被转成 float64
+	var resp models.Response
+	decoder := json.NewDecoder(bytes.NewReader(c.Response().Body()))
+	decoder.UseNumber()
+	if err := decoder.Decode(&resp); err != nil {
+		// 非统一响应格式(理论上不会出现),保持原样返回
+		return nil
+	}
+
+	for _, p := range active {
+		p.apply(c, platform, &resp)
+	}
+
+	return c.JSON(resp.WithFieldMapping(fields))
+}
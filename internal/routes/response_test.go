@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIsCanonicalRequest(t *testing.T) {
+	app := fiber.New()
+	app.Get("/weibo", func(c *fiber.Ctx) error {
+		if isCanonicalRequest(c) {
+			return c.SendString("canonical")
+		}
+		return c.SendString("variant")
+	})
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "canonical"},
+		{"?cache=false", "canonical"},
+		{"?lang=en&strip=true&sort=hot&order=asc&ttl=true", "canonical"},
+		{"?type=hour", "variant"},
+		{"?cache=false&forum=golang", "variant"},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", "/weibo"+tt.query, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if got := string(body); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
@@ -60,7 +60,7 @@ func FetchWithRetry(
 	// 重试逻辑
 	for attempt := 0; attempt < config.MaxRetries; attempt++ {
 		// 尝试获取数据
-		body, err := client.Get(url, headers)
+		body, err := client.GetContext(ctx, url, headers)
 		if err == nil && len(body) > 0 {
 			// 成功获取，直接返回
 			return body, nil
@@ -97,7 +97,7 @@ func FetchWithRetry(
 
 // FetchWithDefaultRetry 使用默认重试配置的请求函数
 // 这是 FetchWithRetry 的便捷包装，用于需要更强控制的平台
-// 大多数平台可以直接使用 httpClient.Get()，因为 Resty 已内置重试
+// 大多数平台可以直接使用 httpClient.GetContext()，因为 Resty 已内置重试
 func FetchWithDefaultRetry(
 	ctx context.Context,
 	client *http.Client,
@@ -1,8 +1,22 @@
 package routes
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/buildinfo"
+	"github.com/dailyhot/api/internal/cache"
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
 )
 
 // Handler 路由处理器接口
@@ -18,8 +32,22 @@ type Handler interface {
 // Registry 路由注册表
 // 管理所有路由的注册
 type Registry struct {
-	fetcher  *service.Fetcher   // 数据获取服务
-	handlers map[string]Handler // 路由处理器映射表: path -> handler
+	fetcher     *service.Fetcher      // 数据获取服务
+	handlers    map[string]Handler    // 路由处理器映射表: path -> handler
+	middlewares []fiber.Handler       // 平台路由中间件链,按注册顺序执行
+	aliases     map[string]routeAlias // 平台路由别名: 别名路径 -> 规范路径
+	app         *fiber.App            // 已注册路由的 Fiber 应用,供内部调用使用
+
+	hub          *service.Hub  // SSE 订阅中心,nil 表示未启用
+	sseHeartbeat time.Duration // SSE 心跳间隔
+
+	adminToken string // 管理接口访问令牌,空表示未开启
+
+	graphqlOnce   sync.Once      // GraphQL schema 只构建一次
+	graphqlSchema graphql.Schema // GraphQL schema,见 graphQLSchema
+
+	defaultKeys  sync.Map // 平台规范榜单的缓存键: path -> 缓存键,供 /all 批量读取缓存状态
+	writeBatches sync.Map // 进程内调用的缓存写入批次: 批次 ID -> *service.WriteBatch
 }
 
 // NewRegistry 创建路由注册表
@@ -27,6 +55,7 @@ func NewRegistry(fetcher *service.Fetcher) *Registry {
 	return &Registry{
 		fetcher:  fetcher,
 		handlers: make(map[string]Handler),
+		aliases:  make(map[string]routeAlias),
 	}
 }
 
@@ -37,25 +66,73 @@ func (r *Registry) Register(handler Handler) {
 	r.handlers[path] = handler
 }
 
+// Use 注册平台路由中间件
+// 中间件按注册顺序依次执行,需要调用 c.Next() 继续后续处理
+// 只作用于平台热榜路由,/health 等内置接口不受影响,便于接入鉴权、限流、指标等
+// 必须在 RegisterRoutes 之前调用
+func (r *Registry) Use(middleware fiber.Handler) {
+	r.middlewares = append(r.middlewares, middleware)
+}
+
 // RegisterRoutes 将所有路由注册到 Fiber 应用
 // 这个方法会在服务启动时调用
 func (r *Registry) RegisterRoutes(app *fiber.App) {
-	// 注册所有平台路由
+	r.app = app
+
+	// 注册所有平台路由: 先执行中间件链,最后执行平台处理器
 	for path, handler := range r.handlers {
-		app.Get(path, handler.Handle)
+		chain := make([]fiber.Handler, 0, len(r.middlewares)+1)
+		chain = append(chain, r.middlewares...)
+		chain = append(chain, r.wrap(handler))
+		app.Get(path, chain...)
 	}
 
+	// 注册平台路由别名
+	r.registerAliases(app)
+
 	// 注册根路径,返回 API 信息
 	app.Get("/", r.handleIndex)
 
 	// 注册健康检查接口
 	app.Get("/health", r.handleHealth)
 
+	// 注册版本信息接口
+	app.Get("/version", r.handleVersion)
+
 	// 注册缓存统计接口
 	app.Get("/stats", r.handleStats)
 
 	// 注册所有路由列表接口
 	app.Get("/all", r.handleAll)
+
+	// 注册批量查询接口
+	app.Post("/batch", r.handleBatch)
+
+	// 注册历史快照查询接口
+	app.Get("/history/:platform", r.handleSnapshot)
+
+	// 注册 GraphQL 查询接口
+	app.Get("/graphql", r.handleGraphQL)
+	app.Post("/graphql", r.handleGraphQL)
+
+	// 注册管理接口(需开启 admin.enabled 并携带令牌)
+	app.Get("/admin/selftest", r.requireAdmin, r.handleSelfTest)
+	app.Get("/admin/coverage", r.requireAdmin, r.handleCoverage)
+
+	// 注册 SSE 实时推送接口
+	app.Get("/sse/:platform", r.handleSSE)
+
+	// 注册网页正文提取接口(不属于热榜平台,不参与 /all 与平台自检)
+	app.Get("/readability", NewReadabilityHandler(r.fetcher).Handle)
+}
+
+// handleVersion 版本信息处理器
+// 返回版本号、Git 提交、构建时间与 Go 版本
+func (r *Registry) handleVersion(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"code": 200,
+		"data": buildinfo.Get(),
+	})
 }
 
 // handleIndex 首页处理器
@@ -72,7 +149,7 @@ func (r *Registry) handleIndex(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
 		"code":    200,
 		"message": "DailyHotApi - Go 版本",
-		"version": "1.0.0",
+		"version": buildinfo.Version,
 		"routes":  routes,
 		"docs":    "https://github.com/ShellMonster/DailyHotApi-go",
 	})
@@ -88,21 +165,187 @@ func (r *Registry) handleHealth(c *fiber.Ctx) error {
 	})
 }
 
-// handleStats 缓存统计处理器
-// 返回缓存系统的性能统计数据
+// handleStats 缓存与运行时统计处理器
+// 返回缓存命中率、各平台最近抓取情况、上游请求统计、风控退避状态以及 goroutine、内存等运行时指标
+// 带 ?key=<缓存键>(如 weibo_realtime)时额外返回该键的缓存剩余时间
 func (r *Registry) handleStats(c *fiber.Ctx) error {
 	stats := r.fetcher.GetCacheStats()
+	result := fiber.Map{
+		"code":      200,
+		"stats":     stats,
+		"platforms": r.fetcher.GetPlatformStats(),
+		"upstreams": r.fetcher.GetUpstreamStats(),
+		"throttle":  r.fetcher.GetThrottleStats(),
+		"runtime":   service.RuntimeStats(),
+	}
+
+	if key := c.Query("key"); key != "" {
+		if err := validateParam("key", key, shortText(128)); err != nil {
+			return paramError(c, err)
+		}
+		result["cache_ttl"] = r.cacheTTL(c, key)
+	}
+
 	c.Set("Content-Type", fiber.MIMEApplicationJSONCharsetUTF8)
-	return c.JSON(fiber.Map{
-		"code":  200,
-		"stats": stats,
-	})
+	return c.JSON(result)
+}
+
+// cacheTTL 查询缓存键的剩余时间
+// exists 为 false 表示两层缓存都没有该键;剩余时间未知(仅 L1 且没有写入记录)时 ttl_seconds 为 null
+func (r *Registry) cacheTTL(c *fiber.Ctx, key string) fiber.Map {
+	info := fiber.Map{"key": key, "exists": true, "ttl_seconds": nil}
+
+	ttl, err := r.fetcher.CacheTTL(c.Context(), key)
+	switch {
+	case errors.Is(err, cache.ErrCacheMiss):
+		info["exists"] = false
+	case err != nil:
+		info["error"] = err.Error()
+	case ttl < 0:
+		// 未设置过期时间
+		info["ttl_seconds"] = -1
+	default:
+		info["ttl_seconds"] = int64(ttl.Round(time.Second) / time.Second)
+	}
+	return info
+}
+
+// fromCacheTrue 统一响应中表示数据来自缓存的字段
+var fromCacheTrue = []byte(`"fromCache":true`)
+
+// wrap 包装平台处理器
+// 在调用处理器前后记录抓取耗时与结果,供 /stats 统计使用
+// 同时隔离单个处理器的 panic,避免解析异常冒泡到全局 recover
+func (r *Registry) wrap(handler Handler) fiber.Handler {
+	platform := strings.TrimPrefix(handler.GetPath(), "/")
+
+	return func(c *fiber.Ctx) error {
+		// 写入平台名,service 层据此读取平台级配置(如抓取超时)
+		c.Locals(service.PlatformContextKey, platform)
+
+		// 进程内调用(批量、GraphQL、后台刷新等)在这里校验凭证并标记,后续只读取标记
+		if isInternalRequest(c) {
+			c.Locals(internalCallContextKey, true)
+		}
+
+		// 只有平台的规范榜单保存快照
+		if isCanonicalRequest(c) {
+			c.Locals(service.SnapshotContextKey, true)
+		}
+
+		// ?cache=only: 只读缓存,绝不回源
+		var cacheOnly *service.CacheOnly
+		if c.Query("cache") == "only" {
+			cacheOnly = &service.CacheOnly{}
+			c.Locals(service.CacheOnlyContextKey, cacheOnly)
+		}
+
+		// ?ttl=true: 记录数据在缓存中的剩余时间,由响应后处理器写入 cacheRemaining
+		if c.Query("ttl") == "true" {
+			c.Locals(service.CacheRemainingContextKey, &service.CacheRemaining{})
+		}
+
+		// 进程内调用(如缓存预热)指定了写入批次时,抓取结果加入批次,最后一次写入缓存
+		if isInternalCall(c) {
+			if id := c.Get(internalWriteBatchHeader); id != "" {
+				if batch, ok := r.writeBatches.Load(id); ok {
+					c.Locals(service.WriteBatchContextKey, batch)
+				}
+			}
+		}
+
+		// 记录处理器抓取过的缓存键,panic 时据此降级返回陈旧数据
+		trace := &service.FetchTrace{}
+		c.Locals(service.FetchTraceContextKey, trace)
+
+		start := time.Now()
+		err := r.safeHandle(c, platform, handler)
+
+		// 记录规范榜单的缓存键,/all 据此批量读取各平台的缓存状态
+		if keys := trace.CacheKeys(); len(keys) == 1 && isCanonicalRequest(c) {
+			r.defaultKeys.Store(handler.GetPath(), keys[0])
+		}
+
+		// 只读缓存模式下缓存未命中: 返回 204 空响应
+		if cacheOnly != nil && cacheOnly.Missed() {
+			c.Response().ResetBody()
+			c.Set("X-Cache", "MISS")
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+
+		success := err == nil && c.Response().StatusCode() < fiber.StatusBadRequest
+		r.fetcher.RecordFetch(platform, time.Since(start), success)
+
+		// 记录是否命中缓存,供访问日志使用
+		c.Locals(service.CacheHitContextKey, success && bytes.Contains(c.Response().Body(), fromCacheTrue))
+
+		if err != nil {
+			return err
+		}
+
+		// 按请求参数对统一响应做后处理(多语言等),并展开平台配置的字段映射
+		if err := processResponse(c, platform, r.fetcher.FieldMapping(platform)); err != nil {
+			return err
+		}
+
+		// 基于最终响应体计算 ETag 与 Last-Modified,支持 If-None-Match / If-Modified-Since 条件请求
+		applyETag(c)
+		return nil
+	}
+}
+
+// safeHandle 调用处理器并捕获 panic
+// 捕获后记录平台名与堆栈,有陈旧数据时降级返回陈旧数据,否则返回结构化的错误响应
+func (r *Registry) safeHandle(c *fiber.Ctx, platform string, handler Handler) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			traceID := requestTraceID(c)
+			logger.Error("处理器发生 panic",
+				zap.String("platform", platform),
+				zap.String("path", c.Path()),
+				zap.String("trace_id", traceID),
+				zap.Any("panic", rec),
+				zap.ByteString("stack", debug.Stack()),
+			)
+
+			// 丢弃处理器可能已经写入的部分响应
+			c.Response().ResetBody()
+			if r.fetcher != nil {
+				if data, ok := r.fetcher.StaleFallback(c.Context()); ok {
+					err = c.Status(fiber.StatusOK).JSON(models.SuccessResponse(platform, platform, "", "", "", nil, data, true))
+					return
+				}
+			}
+			err = c.Status(fiber.StatusInternalServerError).JSON(
+				models.ErrorResponseObj(fiber.StatusInternalServerError, fmt.Sprintf("%s 数据处理异常: %v", platform, rec)).
+					WithType(models.ErrorTypeParse).
+					WithPlatform(platform).
+					WithTraceID(traceID),
+			)
+		}
+	}()
+
+	return handler.Handle(c)
 }
 
 // handleAll 返回所有已注册路由的列表
 // 这个接口返回系统中所有可用的 API 端点信息
-// 返回格式: { code: 200, count: <数量>, routes: [ { name: "...", path: "..." }, ... ] }
+// 返回格式: { code: 200, count: <数量>, routes: [ { name: "...", path: "...", cached: true }, ... ], aliases: { "/别名": "/规范路径" }, responseParams: [...] }
+// 已知规范榜单缓存键的平台(预热过或被请求过)带 cached 字段,表示规范榜单当前是否在缓存中;
+// 各平台的缓存通过一次批量读取获得,L2 只需一次往返
 func (r *Registry) handleAll(c *fiber.Ctx) error {
+	// 批量读取各平台规范榜单的缓存
+	defaultKeys := make(map[string]string)
+	r.defaultKeys.Range(func(path, key interface{}) bool {
+		defaultKeys[path.(string)] = key.(string)
+		return true
+	})
+	keys := make([]string, 0, len(defaultKeys))
+	for _, key := range defaultKeys {
+		keys = append(keys, key)
+	}
+	cached := r.fetcher.CachedMany(c.UserContext(), keys)
+
 	// 收集所有已注册的路由信息
 	routes := make([]fiber.Map, 0, len(r.handlers))
 
@@ -112,15 +355,21 @@ func (r *Registry) handleAll(c *fiber.Ctx) error {
 			"name": handler.GetPath()[1:], // 移除路径前的 "/" 符号作为名称,例如 "/bilibili" -> "bilibili"
 			"path": handler.GetPath(),     // 完整的路径,例如 "/bilibili"
 		}
+		if key, ok := defaultKeys[handler.GetPath()]; ok {
+			_, routeInfo["cached"] = cached[key]
+		}
 		routes = append(routes, routeInfo)
 	}
 
 	// 返回路由列表信息
 	c.Set("Content-Type", fiber.MIMEApplicationJSONCharsetUTF8)
 	return c.JSON(fiber.Map{
-		"code":   200,
-		"count":  len(r.handlers),
-		"routes": routes,
+		"code":    200,
+		"count":   len(r.handlers),
+		"routes":  routes,
+		"aliases": r.aliasTable(),
+		// 展示层参数: 对缓存数据即时处理,任意组合都共用同一份缓存
+		"responseParams": responseParams(),
 	})
 }
 
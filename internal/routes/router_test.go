@@ -0,0 +1,138 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/internal/cache"
+	"github.com/dailyhot/api/internal/config"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// panicHandler 通过抓取器读取数据后组装响应
+// panicBefore 为 true 时在抓取前 panic,panicAfter 为 true 时在抓取后、组装响应前 panic
+type panicHandler struct {
+	path        string
+	fetcher     *service.Fetcher
+	panicBefore bool
+	panicAfter  bool
+}
+
+func (h *panicHandler) GetPath() string { return h.path }
+
+func (h *panicHandler) Handle(c *fiber.Ctx) error {
+	if h.panicBefore {
+		panic("解析失败")
+	}
+	items, fromCache, err := h.fetcher.Fetch(c.Context(), h.path[1:]+"_hot", time.Minute, c.Query("cache") == "false", func(ctx context.Context) ([]models.HotData, error) {
+		return []models.HotData{{ID: "1", Title: "旧数据", URL: "https://example.com"}}, nil
+	})
+	if err != nil {
+		return err
+	}
+	if h.panicAfter {
+		var m map[string]int
+		m["boom"]++
+	}
+	return c.JSON(models.SuccessResponse(h.path[1:], "测试", "热榜", "", "", nil, items, fromCache))
+}
+
+// newTestFetcher 创建只使用 L1 缓存的抓取器
+func newTestFetcher(t *testing.T) *service.Fetcher {
+	t.Helper()
+	return newPlatformTestFetcher(t, nil)
+}
+
+// newPlatformTestFetcher 创建只使用 L1 缓存、带平台配置的抓取器
+func newPlatformTestFetcher(t *testing.T, platforms map[string]config.PlatformConfig) *service.Fetcher {
+	t.Helper()
+	cfg := &config.Config{
+		Cache: config.CacheConfig{
+			Enabled:          true,
+			DefaultExpire:    time.Minute,
+			CleanupInterval:  time.Minute,
+			MaxEntries:       100,
+			MaxEntrySize:     1024,
+			HardMaxCacheSize: 8,
+			StaleExpire:      time.Hour,
+			StaleMaxEntries:  10,
+		},
+		Platforms: platforms,
+	}
+	cacheManager, err := cache.NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cacheManager.Close() })
+	return service.NewFetcher(cacheManager, cfg)
+}
+
+func newPanicTestApp(t *testing.T, handlers ...*panicHandler) *fiber.App {
+	t.Helper()
+	fetcher := newTestFetcher(t)
+	r := NewRegistry(fetcher)
+	app := fiber.New()
+	r.app = app
+	for _, handler := range handlers {
+		handler.fetcher = fetcher
+		r.handlers[handler.path] = handler
+		app.Get(handler.path, r.wrap(handler))
+	}
+	return app
+}
+
+func getJSON(t *testing.T, app *fiber.App, target string) (int, map[string]interface{}) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", target, nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func TestSafeHandleIsolatesPanic(t *testing.T) {
+	broken := &panicHandler{path: "/broken", panicBefore: true}
+	app := newPanicTestApp(t, broken, &panicHandler{path: "/healthy"})
+
+	// 没有陈旧数据: 返回结构化的错误响应
+	status, result := getJSON(t, app, "/broken")
+	if status != fiber.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", status)
+	}
+	if result["type"] != string(models.ErrorTypeParse) || result["platform"] != "broken" {
+		t.Errorf("result = %v, want parse error for broken", result)
+	}
+
+	// 其他平台不受影响
+	if status, result := getJSON(t, app, "/healthy"); status != fiber.StatusOK || result["name"] != "healthy" {
+		t.Errorf("healthy: status = %d, result = %v", status, result)
+	}
+}
+
+func TestSafeHandleFallsBackToStale(t *testing.T) {
+	broken := &panicHandler{path: "/broken"}
+	app := newPanicTestApp(t, broken)
+
+	// 先成功抓取一次,留下陈旧数据
+	if status, _ := getJSON(t, app, "/broken"); status != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+
+	broken.panicAfter = true
+	status, result := getJSON(t, app, "/broken?cache=false")
+	if status != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200 with stale data", status)
+	}
+	items, _ := result["data"].([]interface{})
+	if result["fromCache"] != true || len(items) != 1 {
+		t.Errorf("result = %v, want stale data from cache", result)
+	}
+}
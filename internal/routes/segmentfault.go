@@ -0,0 +1,175 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+// segmentfaultTypeMap 榜单类型映射
+var segmentfaultTypeMap = map[string]string{
+	"article":  "热门文章",
+	"question": "热门问答",
+}
+
+// segmentfaultListURLs 各类型的热门列表页
+var segmentfaultListURLs = map[string]string{
+	"article":  "https://segmentfault.com/hottest",
+	"question": "https://segmentfault.com/questions/hottest",
+}
+
+// segmentfault 列表页底部计数,如 "12 赞"、"1.2k 阅读"、"3 回答"
+var (
+	segmentfaultVotesPattern   = regexp.MustCompile(`([\d.]+[kKwW万]?)\s*(?:赞|投票|票)`)
+	segmentfaultViewsPattern   = regexp.MustCompile(`([\d.]+[kKwW万]?)\s*(?:阅读|浏览)`)
+	segmentfaultAnswersPattern = regexp.MustCompile(`([\d.]+[kKwW万]?)\s*(?:回答|解答)`)
+)
+
+// segmentfaultIDPattern 从链接中提取 ID,如 /a/1190000012345678 或 /q/1010000012345678
+var segmentfaultIDPattern = regexp.MustCompile(`/[aq]/(\d+)`)
+
+// SegmentFaultHandler 思否处理器
+type SegmentFaultHandler struct {
+	fetcher *service.Fetcher
+}
+
+// NewSegmentFaultHandler 创建思否处理器
+func NewSegmentFaultHandler(fetcher *service.Fetcher) *SegmentFaultHandler {
+	return &SegmentFaultHandler{
+		fetcher: fetcher,
+	}
+}
+
+// GetPath 获取路由路径
+func (h *SegmentFaultHandler) GetPath() string {
+	return "/segmentfault"
+}
+
+// Handle 处理请求
+func (h *SegmentFaultHandler) Handle(c *fiber.Ctx) error {
+	listType := c.Query("type", "article")
+	if _, ok := segmentfaultTypeMap[listType]; !ok {
+		listType = "article"
+	}
+	noCache := c.Query("cache") == "false"
+
+	cacheKey := fmt.Sprintf("segmentfault_%s", listType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchSegmentFault(ctx, listType)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	resp := models.SuccessResponse(
+		"segmentfault",                // name: 平台调用名称
+		"思否",                          // title: 平台显示名称
+		segmentfaultTypeMap[listType], // type: 榜单类型
+		"技术问答与文章分享社区",                 // description: 平台描述
+		"https://segmentfault.com/",   // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": segmentfaultTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
+	)
+
+	return c.JSON(resp)
+}
+
+// fetchSegmentFault 抓取思否热门列表页
+func (h *SegmentFaultHandler) fetchSegmentFault(ctx context.Context, listType string) ([]models.HotData, error) {
+	body, err := h.fetcher.Get(ctx, segmentfaultListURLs[listType], map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Referer":    "https://segmentfault.com/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求思否失败: %w", err)
+	}
+
+	data := h.parseHTML(string(body), listType)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("思否页面%w", service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// parseHTML 解析热门列表页
+// 文章以阅读数作为热度,问答以浏览数作为热度,没有时退回投票数
+func (h *SegmentFaultHandler) parseHTML(html, listType string) []models.HotData {
+	result := make([]models.HotData, 0)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return result
+	}
+
+	doc.Find(".list-group-item").Each(func(i int, s *goquery.Selection) {
+		link := s.Find("h3 a, h5 a, .title a").First()
+		title := strings.TrimSpace(link.Text())
+		href := link.AttrOr("href", "")
+		if title == "" || href == "" {
+			return
+		}
+		if strings.HasPrefix(href, "/") {
+			href = "https://segmentfault.com" + href
+		}
+
+		id := href
+		if matches := segmentfaultIDPattern.FindStringSubmatch(href); len(matches) > 1 {
+			id = matches[1]
+		}
+
+		text := s.Text()
+		votes := h.matchCount(segmentfaultVotesPattern, text)
+		views := h.matchCount(segmentfaultViewsPattern, text)
+
+		hot := views
+		if hot == 0 {
+			hot = votes
+		}
+
+		extra := map[string]interface{}{
+			"votes": votes,
+			"views": views,
+		}
+		if listType == "question" {
+			extra["answers"] = h.matchCount(segmentfaultAnswersPattern, text)
+		}
+
+		var timestamp interface{}
+		if ts := timeutil.ParseTime(s.Find("time").AttrOr("datetime", "")); ts > 0 {
+			timestamp = ts
+		}
+
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     title,
+			Desc:      strings.TrimSpace(s.Find(".excerpt, .text-secondary.text-truncate").First().Text()),
+			Author:    strings.TrimSpace(s.Find(`a[href^="/u/"]`).First().Text()),
+			Hot:       hot,
+			Timestamp: timestamp,
+			URL:       href,
+			MobileURL: href,
+			Extra:     extra,
+		})
+	})
+
+	return result
+}
+
+// matchCount 用正则从文本中提取计数,支持 "1.2k"、"3w" 等缩写
+func (h *SegmentFaultHandler) matchCount(pattern *regexp.Regexp, text string) int64 {
+	matches := pattern.FindStringSubmatch(text)
+	if len(matches) < 2 {
+		return 0
+	}
+	return parseAbbrevCount(strings.ReplaceAll(matches[1], "万", "w"))
+}
@@ -0,0 +1,110 @@
+package routes
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+)
+
+// SelfTestResult 单个平台的自检结果
+type SelfTestResult struct {
+	Platform string           // 平台路由名
+	Success  bool             // 是否抓取成功且有数据
+	Count    int              // 返回条数
+	Duration time.Duration    // 耗时
+	Error    string           // 失败原因
+	Data     []models.HotData // 抓取到的数据
+}
+
+// SelfTest 逐个探测平台可用性
+// 以受限并发绕过缓存调用每个已注册的平台一次,结果按平台名排序
+// 抓取成功但没有数据的平台同样视为失败;timeout 为单个平台的超时时间
+// 必须在 RegisterRoutes 之后调用
+func (r *Registry) SelfTest(ctx context.Context, concurrency int, timeout time.Duration) []SelfTestResult {
+	return r.probeAll(ctx, concurrency, timeout, true)
+}
+
+// probeAll 以受限并发调用每个已注册的平台一次,noCache 为 true 时绕过缓存
+func (r *Registry) probeAll(ctx context.Context, concurrency int, timeout time.Duration, noCache bool) []SelfTestResult {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	paths := make([]string, 0, len(r.handlers))
+	for path := range r.handlers {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	results := make([]SelfTestResult, len(paths))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for i, path := range paths {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = r.probe(ctx, path, timeout, noCache)
+		}(i, path)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// probe 探测单个平台
+// 内部请求不受 context 取消控制,这里在超时后直接放弃等待,请求会在后台自然结束
+func (r *Registry) probe(ctx context.Context, path string, timeout time.Duration, noCache bool) SelfTestResult {
+	result := SelfTestResult{Platform: path[1:]}
+
+	target := path
+	if noCache {
+		target += "?cache=false"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		data []models.HotData
+		err  error
+	}
+	done := make(chan outcome, 1)
+
+	start := time.Now()
+	go func() {
+		resp, err := r.invoke(ctx, target, "DailyHotApi/SelfTest")
+		if err != nil {
+			done <- outcome{err: err}
+			return
+		}
+		done <- outcome{data: resp.Data}
+	}()
+
+	select {
+	case out := <-done:
+		result.Duration = time.Since(start)
+		result.Data = out.data
+		result.Count = len(out.data)
+		switch {
+		case out.err != nil:
+			result.Error = out.err.Error()
+		case result.Count == 0:
+			result.Error = "没有返回数据"
+		default:
+			result.Success = true
+		}
+	case <-ctx.Done():
+		result.Duration = time.Since(start)
+		result.Error = "抓取超时"
+	}
+
+	return result
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"regexp"
+	"math"
 	"strconv"
+	"strings"
 
+	"github.com/dailyhot/api/internal/logger"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
 // SinaHandler 新浪网处理器
@@ -34,11 +37,17 @@ func (h *SinaHandler) Handle(c *fiber.Ctx) error {
 	// 获取查询参数
 	hotType := c.Query("type", "all") // 默认新浪热榜
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", hotType, isSlug); err != nil {
+		return paramError(c, err)
+	}
 
 	// 获取热榜数据
-	data, err := h.fetchSina(c.Context(), hotType)
+	cacheKey := fmt.Sprintf("sina_%s", hotType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchSina(ctx, hotType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 准备类型映射表 - 用于前端显示支持的参数选项
@@ -65,8 +74,8 @@ func (h *SinaHandler) Handle(c *fiber.Ctx) error {
 		map[string]interface{}{ // params: 参数说明
 			"type": typeMap,
 		},
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -98,7 +107,7 @@ func (h *SinaHandler) fetchSina(ctx context.Context, hotType string) ([]models.H
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求新浪网 API 失败: %w", err)
 	}
@@ -138,40 +147,86 @@ func (h *SinaHandler) transformData(items []SinaHotItem) []models.HotData {
 	return result
 }
 
-// parseChineseNumber 解析中文数字(如 "10万" -> 100000)
-func (h *SinaHandler) parseChineseNumber(numStr string) int64 {
-	// 移除空格
-	numStr = regexp.MustCompile(`\s+`).ReplaceAllString(numStr, "")
+// sinaNumberUnits 中文数量单位
+var sinaNumberUnits = map[rune]float64{
+	'十': 10,
+	'百': 100,
+	'千': 1000,
+	'万': 1e4,
+	'w': 1e4,
+	'W': 1e4,
+	'亿': 1e8,
+}
 
-	// 匹配数字部分
-	re := regexp.MustCompile(`([\d.]+)([万亿千百]?)`)
-	matches := re.FindStringSubmatch(numStr)
+// parseChineseNumber 解析中文热度值
+// 支持 "123"、"1,234"、"10万"、"1.5亿" 以及 "1亿2千万"、"3亿5000万" 这类多单位组合;
+// 非数字、非单位的字符(如 "热度"、"+")会被忽略,无法解析时记录 debug 日志并返回 0
+func (h *SinaHandler) parseChineseNumber(numStr string) int64 {
+	var (
+		total   float64 // 已结算到 "亿" 的部分
+		section float64 // 已结算到 "万" 的部分
+		current float64 // 十/百/千 级别的累加值
+		pending float64 // 尚未遇到单位的数字
+		found   bool
+	)
 
-	if len(matches) < 2 {
-		return 0
-	}
+	runes := []rune(numStr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		// 读取一段数字(允许小数点与千分位逗号)
+		if (r >= '0' && r <= '9') || r == '.' {
+			j := i
+			var digits []rune
+			for j < len(runes) && ((runes[j] >= '0' && runes[j] <= '9') || runes[j] == '.' || runes[j] == ',') {
+				if runes[j] != ',' {
+					digits = append(digits, runes[j])
+				}
+				j++
+			}
+			value, err := strconv.ParseFloat(string(digits), 64)
+			if err != nil {
+				logger.Debug("新浪热度值解析失败", zap.String("value", numStr), zap.Error(err))
+				return 0
+			}
+			pending += value
+			found = true
+			i = j
+			continue
+		}
 
-	// 解析基础数字
-	baseNum, err := strconv.ParseFloat(matches[1], 64)
-	if err != nil {
-		return 0
+		unit, ok := sinaNumberUnits[r]
+		i++
+		if !ok {
+			continue
+		}
+		switch {
+		case unit >= 1e8:
+			total += (section + current + pending) * unit
+			section, current, pending = 0, 0, 0
+		case unit >= 1e4:
+			section += (current + pending) * unit
+			current, pending = 0, 0
+		default:
+			// "十万" 这类省略前导数字的写法按 1 计算
+			if pending == 0 {
+				pending = 1
+				found = true
+			}
+			current += pending * unit
+			pending = 0
+		}
 	}
 
-	// 处理单位
-	if len(matches) >= 3 {
-		switch matches[2] {
-		case "万":
-			baseNum *= 10000
-		case "亿":
-			baseNum *= 100000000
-		case "千":
-			baseNum *= 1000
-		case "百":
-			baseNum *= 100
+	if !found {
+		if strings.TrimSpace(numStr) != "" {
+			logger.Debug("新浪热度值无法解析", zap.String("value", numStr))
 		}
+		return 0
 	}
 
-	return int64(baseNum)
+	// 小数与单位相乘会有浮点误差(如 2.3*1e8 = 229999999.99...),四舍五入而不是截断
+	return int64(math.Round(total + section + current + pending))
 }
 
 // 以下是新浪网 API 的响应结构体定义
@@ -0,0 +1,31 @@
+package routes
+
+import "testing"
+
+func TestSinaParseChineseNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"123", 123},
+		{"1,234", 1234},
+		{"1.5万", 15000},
+		{"10万+", 100000},
+		{"十万", 100000},
+		{"2.3亿", 230000000},
+		{"1.15亿", 115000000},
+		{"0.29亿", 29000000},
+		{"1亿2千万", 120000000},
+		{"3亿5000万", 350000000},
+		{"热度 88万", 880000},
+		{"", 0},
+		{"暂无", 0},
+		{"1.2.3万", 0},
+	}
+	h := &SinaHandler{}
+	for _, tt := range tests {
+		if got := h.parseChineseNumber(tt.in); got != tt.want {
+			t.Errorf("parseChineseNumber(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -37,9 +39,12 @@ func (h *SinaNewsHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchSinaNews(c.Context(), newsType)
+	cacheKey := fmt.Sprintf("sina-news_%s", newsType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchSinaNews(ctx, newsType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -55,8 +60,8 @@ func (h *SinaNewsHandler) Handle(c *fiber.Ctx) error {
 			"7": "体育新闻", "8": "财经新闻", "9": "娱乐新闻",
 			"10": "科技新闻", "11": "军事新闻",
 		}}, // params: 类型映射
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -121,12 +126,12 @@ func (h *SinaNewsHandler) fetchSinaNews(ctx context.Context, typeID string) ([]m
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求新浪新闻 API 失败: %w", err)
 	}
 
-	// 解析 JSONP 响应(格式: var data = {...};)
+	// 解析 JSONP 响应(格式: var data = {...}; 或 callback({...});)
 	jsonData, err := h.parseJSONP(string(body))
 	if err != nil {
 		return nil, fmt.Errorf("解析新浪新闻响应失败: %w", err)
@@ -141,27 +146,32 @@ func (h *SinaNewsHandler) fetchSinaNews(ctx context.Context, typeID string) ([]m
 	return h.transformData(apiResp.Data), nil
 }
 
+// sinaJSONPPrefixPattern 匹配 JSONP 的包装前缀
+// 兼容 `var data = {...};` 与 `callback({...});`、`jQuery123_456({...})` 等回调形式
+var sinaJSONPPrefixPattern = regexp.MustCompile(`^\s*(var\s+\w+\s*=|[\w$.]+\()`)
+
 // parseJSONP 解析 JSONP 格式数据
+// 去掉包装前缀后,截取最外层大括号内的 JSON 体
 func (h *SinaNewsHandler) parseJSONP(data string) (string, error) {
 	data = strings.TrimSpace(data)
 	if data == "" {
-		return "", fmt.Errorf("数据为空")
+		return "", service.ErrEmptyData
 	}
 
-	// 移除 "var data = " 前缀
-	prefix := "var data = "
-	if !strings.HasPrefix(data, prefix) {
-		return "", fmt.Errorf("数据格式错误: 缺少前缀")
+	// 去掉包装前缀(纯 JSON 没有前缀,直接从开头查找)
+	if loc := sinaJSONPPrefixPattern.FindStringIndex(data); loc != nil {
+		data = data[loc[1]:]
 	}
 
-	jsonStr := strings.TrimPrefix(data, prefix)
-	jsonStr = strings.TrimSpace(jsonStr)
-
-	// 移除末尾的分号
-	jsonStr = strings.TrimSuffix(jsonStr, ";")
-	jsonStr = strings.TrimSpace(jsonStr)
+	// 截取第一个 '{' 到最后一个 '}' 之间的内容
+	// 这样末尾的 ");"、";" 等包装字符都会被去掉
+	start := strings.Index(data, "{")
+	end := strings.LastIndex(data, "}")
+	if start == -1 || end < start {
+		return "", fmt.Errorf("数据格式错误: 未找到 JSON 对象")
+	}
 
-	return jsonStr, nil
+	return data[start : end+1], nil
 }
 
 // transformData 将新浪新闻原始数据转换为统一格式
@@ -173,8 +183,8 @@ func (h *SinaNewsHandler) transformData(items []SinaNewsItem) []models.HotData {
 		hotStr := strings.ReplaceAll(item.TopNum, ",", "")
 		hot, _ := strconv.ParseInt(hotStr, 10, 64)
 
-		// 解析时间戳
-		timestamp := item.CreateDate + " " + item.CreateTime
+		// 解析时间戳(毫秒)
+		timestamp := parseSinaNewsTime(item.CreateDate, item.CreateTime, time.Now())
 
 		hotData := models.HotData{
 			ID:        item.ID,
@@ -192,6 +202,21 @@ func (h *SinaNewsHandler) transformData(items []SinaNewsItem) []models.HotData {
 	return result
 }
 
+// parseSinaNewsTime 将 create_date + create_time 解析为毫秒时间戳
+// 新浪返回的时间为北京时间,日期可能缺少年份或不补零(如 "1-5"),时间可能缺少秒;
+// 缺少年份时按当前年份补全,跨年时回退一年(由 timeutil.ParseTimeIn 处理)
+func parseSinaNewsTime(date, clock string, now time.Time) int64 {
+	date = strings.TrimSpace(date)
+	clock = strings.TrimSpace(clock)
+	if date == "" {
+		return 0
+	}
+	if clock == "" {
+		clock = "00:00:00"
+	}
+	return timeutil.ParseTimeIn(date+" "+clock, timeutil.Beijing, now)
+}
+
 // 以下是新浪新闻 API 的响应结构体定义
 
 // SinaNewsAPIResponse 新浪新闻 API 响应
@@ -0,0 +1,96 @@
+package routes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+)
+
+func TestParseSinaNewsTime(t *testing.T) {
+	beijing := func(year int, month time.Month, day, hour, minute, sec int) int64 {
+		return time.Date(year, month, day, hour, minute, sec, 0, timeutil.Beijing).UnixMilli()
+	}
+	// 北京时间 2024-01-01 00:10,UTC 仍是 2023-12-31
+	newYear := time.Date(2023, 12, 31, 16, 10, 0, 0, time.UTC)
+	midJanuary := time.Date(2024, 1, 15, 10, 0, 0, 0, timeutil.Beijing)
+
+	tests := []struct {
+		name  string
+		date  string
+		clock string
+		now   time.Time
+		want  int64
+	}{
+		{"完整日期", "2024-01-02", "08:30:05", midJanuary, beijing(2024, 1, 2, 8, 30, 5)},
+		{"不补零且缺少秒", "2024-1-2", "8:30", midJanuary, beijing(2024, 1, 2, 8, 30, 0)},
+		{"斜杠分隔", "2023/12/31", "23:00:00", midJanuary, beijing(2023, 12, 31, 23, 0, 0)},
+		{"点分隔", "2024.1.2", "08:30:00", midJanuary, beijing(2024, 1, 2, 8, 30, 0)},
+		{"缺少年份", "1-2", "08:30:05", midJanuary, beijing(2024, 1, 2, 8, 30, 5)},
+		{"缺少时间", "2024-01-02", "", midJanuary, beijing(2024, 1, 2, 0, 0, 0)},
+		{"跨年回退一年", "12-31", "23:59:00", newYear, beijing(2023, 12, 31, 23, 59, 0)},
+		{"跨天按北京时间补全年份", "1-1", "00:05:00", newYear, beijing(2024, 1, 1, 0, 5, 0)},
+		{"略晚于当前时间不回退", "1-15", "12:00:00", midJanuary, beijing(2024, 1, 15, 12, 0, 0)},
+		{"空日期", "", "08:30:00", midJanuary, 0},
+		{"无法识别", "abc", "08:30:00", midJanuary, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSinaNewsTime(tt.date, tt.clock, tt.now); got != tt.want {
+				t.Errorf("parseSinaNewsTime(%q, %q) = %s, want %s", tt.date, tt.clock,
+					time.UnixMilli(got).In(timeutil.Beijing), time.UnixMilli(tt.want).In(timeutil.Beijing))
+			}
+		})
+	}
+}
+
+// sinaNewsFixture 排行接口返回的 JSON 体(节选)
+const sinaNewsFixture = `{"data":[{"id":"hknhqzc1234567","title":"国务院常务会议部署经济工作","media":"新华社","top_num":"123,456","create_date":"2024-05-01","create_time":"10:30:00","url":"https://news.sina.com.cn/c/2024-05-01/doc-inaxxx.shtml"}]}`
+
+func TestSinaNewsParseJSONP(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"var 赋值", "var data = " + sinaNewsFixture + ";"},
+		{"var 赋值无空格", "var data=" + sinaNewsFixture},
+		{"回调函数", "callback(" + sinaNewsFixture + ");"},
+		{"jQuery 回调", "jQuery11240123_1714550400000(" + sinaNewsFixture + ")"},
+		{"带命名空间的回调", "window.$sina.cb(" + sinaNewsFixture + ");"},
+		{"前后有空白与换行", "\n  var data = " + sinaNewsFixture + ";\n"},
+		{"纯 JSON", sinaNewsFixture},
+	}
+
+	h := &SinaNewsHandler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.parseJSONP(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != sinaNewsFixture {
+				t.Fatalf("parseJSONP = %q, want JSON body", got)
+			}
+
+			var resp SinaNewsAPIResponse
+			if err := json.Unmarshal([]byte(got), &resp); err != nil {
+				t.Fatal(err)
+			}
+			data := h.transformData(resp.Data)
+			if len(data) != 1 || data[0].Hot != int64(123456) || data[0].Author != "新华社" {
+				t.Errorf("data = %+v", data)
+			}
+		})
+	}
+}
+
+func TestSinaNewsParseJSONPInvalid(t *testing.T) {
+	h := &SinaNewsHandler{}
+	for _, in := range []string{"", "   ", "callback();", "var data = null;"} {
+		if _, err := h.parseJSONP(in); err == nil {
+			t.Errorf("parseJSONP(%q) err = nil, want error", in)
+		}
+	}
+}
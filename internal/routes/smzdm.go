@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
@@ -12,6 +15,21 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// smzdmChannelMap 频道映射: channel 参数 -> 频道名称
+var smzdmChannelMap = map[string]string{
+	"article": "文章榜",
+	"haojia":  "好价榜",
+}
+
+// smzdmRankAPI 文章榜接口,unit 为榜单类型
+var smzdmRankAPI = "https://post.smzdm.com/rank/json_more/"
+
+// smzdmHaojiaAPI 好价榜接口(全品类,近 24 小时)
+var smzdmHaojiaAPI = "https://www.smzdm.com/top/json_more?rank_type=pinlei&rank_id=11&hour=24"
+
+// smzdmPricePattern 好价价格文案中的金额,如 "29.9元包邮"、"¥1,299"
+var smzdmPricePattern = regexp.MustCompile(`(\d+(?:,\d{3})*(?:\.\d+)?)\s*元?`)
+
 // SmzdmHandler 什么值得买处理器
 type SmzdmHandler struct {
 	fetcher *service.Fetcher
@@ -32,27 +50,48 @@ func (h *SmzdmHandler) GetPath() string {
 // Handle 处理请求
 func (h *SmzdmHandler) Handle(c *fiber.Ctx) error {
 	// 获取查询参数
-	rankType := c.Query("type", "1") // 默认今日热门
+	channel := c.Query("channel", "article") // 默认文章榜
+	rankType := c.Query("type", "1")         // 默认今日热门,仅文章榜生效
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("channel", channel, oneOf(smzdmChannelMap)); err != nil {
+		return paramError(c, err)
+	}
+	if err := validateParam("type", rankType, isNumeric); err != nil {
+		return paramError(c, err)
+	}
 
-	// 获取数据
-	data, err := h.fetchSmzdm(c.Context(), rankType)
+	// 获取数据(文章榜沿用原缓存键)
+	cacheKey := fmt.Sprintf("smzdm_%s", rankType)
+	typeName := h.getTypeName(rankType)
+	if channel == "haojia" {
+		cacheKey = "smzdm_haojia"
+		typeName = smzdmChannelMap[channel]
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if channel == "haojia" {
+			return h.fetchHaojia(ctx)
+		}
+		return h.fetchSmzdm(ctx, rankType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
-		"smzdm",                 // name: 平台调用名称
-		"什么值得买",                 // title: 平台显示名称
-		h.getTypeName(rankType), // type: 榜单类型
+		"smzdm",  // name: 平台调用名称
+		"什么值得买",  // title: 平台显示名称
+		typeName, // type: 榜单类型
 		"发现优质消费资讯与好物推荐",          // description: 平台描述
 		"https://www.smzdm.com/", // link: 官方链接
-		map[string]interface{}{"type": map[string]string{
-			"1": "今日热门", "7": "周热门", "30": "月热门",
-		}}, // params: 类型映射
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		map[string]interface{}{
+			"channel": smzdmChannelMap,
+			"type": map[string]string{
+				"1": "今日热门", "7": "周热门", "30": "月热门",
+			},
+		}, // params: 频道与类型映射
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -73,7 +112,7 @@ func (h *SmzdmHandler) getTypeName(typeID string) string {
 
 // fetchSmzdm 从什么值得买 API 获取数据
 func (h *SmzdmHandler) fetchSmzdm(ctx context.Context, rankType string) ([]models.HotData, error) {
-	apiURL := fmt.Sprintf("https://post.smzdm.com/rank/json_more/?unit=%s", rankType)
+	apiURL := fmt.Sprintf("%s?unit=%s", smzdmRankAPI, rankType)
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
@@ -86,7 +125,7 @@ func (h *SmzdmHandler) fetchSmzdm(ctx context.Context, rankType string) ([]model
 		"Sec-Fetch-Site":   "same-origin",
 		"Sec-Fetch-Dest":   "empty",
 	}
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求什么值得买 API 失败: %w", err)
 	}
@@ -107,7 +146,7 @@ func (h *SmzdmHandler) transformData(items []SmzdmItem) []models.HotData {
 
 	for _, item := range items {
 		// 热度转换(收藏数)
-		hot := parseCollectionCount(item.CollectionCount)
+		hot := models.ToInt64(item.CollectionCount)
 
 		hotData := models.HotData{
 			ID:        item.ArticleID,
@@ -127,23 +166,132 @@ func (h *SmzdmHandler) transformData(items []SmzdmItem) []models.HotData {
 	return result
 }
 
-func parseCollectionCount(value interface{}) int64 {
-	switch v := value.(type) {
-	case string:
-		if v == "" {
-			return 0
+// fetchHaojia 获取好价榜
+func (h *SmzdmHandler) fetchHaojia(ctx context.Context) ([]models.HotData, error) {
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, smzdmHaojiaAPI, map[string]string{
+		"Accept":           "application/json, text/plain, */*",
+		"Referer":          "https://www.smzdm.com/top/",
+		"X-Requested-With": "XMLHttpRequest",
+		"Accept-Language":  "zh-CN,zh;q=0.9,en;q=0.8",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求什么值得买好价榜失败: %w", err)
+	}
+
+	items, err := h.parseHaojia(body)
+	if err != nil {
+		return nil, fmt.Errorf("解析什么值得买好价榜失败: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("什么值得买好价榜%w", service.ErrEmptyData)
+	}
+	return h.transformHaojia(items), nil
+}
+
+// parseHaojia 解析好价榜响应
+// 接口有时直接返回数组,有时包在 data 或 data.list 中
+func (h *SmzdmHandler) parseHaojia(body []byte) ([]SmzdmHaojiaItem, error) {
+	var items []SmzdmHaojiaItem
+	if err := json.Unmarshal(body, &items); err == nil {
+		return items, nil
+	}
+
+	var wrapped struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(wrapped.Data, &items); err == nil {
+		return items, nil
+	}
+
+	var list struct {
+		List []SmzdmHaojiaItem `json:"list"`
+	}
+	if err := json.Unmarshal(wrapped.Data, &list); err != nil {
+		return nil, err
+	}
+	return list.List, nil
+}
+
+// transformHaojia 将好价条目转换为统一格式
+// 热度取"值"的票数;价格、商城与折扣信息放入 Extra
+func (h *SmzdmHandler) transformHaojia(items []SmzdmHaojiaItem) []models.HotData {
+	result := make([]models.HotData, 0, len(items))
+
+	for _, item := range items {
+		id := models.ToString(item.ArticleID)
+		title := strings.TrimSpace(item.Title)
+		if id == "" || title == "" {
+			continue
+		}
+
+		url := item.URL
+		if url == "" {
+			url = fmt.Sprintf("https://www.smzdm.com/p/%s/", id)
+		}
+
+		worthy := models.ToInt64(item.Worthy)
+		unworthy := models.ToInt64(item.Unworthy)
+		extra := map[string]interface{}{
+			"price":    strings.TrimSpace(item.Price),
+			"mall":     strings.TrimSpace(item.Mall),
+			"worthy":   worthy,
+			"unworthy": unworthy,
+			"comments": models.ToInt64(item.Comment),
+		}
+		if value, ok := parseSmzdmPrice(item.Price); ok {
+			extra["price_value"] = value
+		}
+		// 优惠说明: 价格文案中金额之后的部分,如 "包邮"、"需用券"
+		if promotion := smzdmPromotion(item.Price); promotion != "" {
+			extra["promotion"] = promotion
 		}
-		if num, err := strconv.ParseInt(v, 10, 64); err == nil {
-			return num
+		// 值率: "值"在全部投票中的占比(百分比)
+		if votes := worthy + unworthy; votes > 0 {
+			extra["worthy_rate"] = worthy * 100 / votes
 		}
-	case float64:
-		return int64(v)
-	case int:
-		return int64(v)
-	case int64:
-		return v
+
+		timestamp := timeutil.ParseTime(item.TimeSort)
+		if timestamp == 0 {
+			timestamp = timeutil.ParseTimeIn(item.Date, timeutil.Beijing, time.Now())
+		}
+
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     title,
+			Desc:      strings.TrimSpace(item.Price),
+			Cover:     item.Pic,
+			Hot:       worthy,
+			Timestamp: timestamp,
+			URL:       url,
+			MobileURL: url,
+			Extra:     extra,
+		})
 	}
-	return 0
+
+	return result
+}
+
+// parseSmzdmPrice 提取价格文案中的金额
+func parseSmzdmPrice(price string) (float64, bool) {
+	match := smzdmPricePattern.FindStringSubmatch(price)
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+	return value, err == nil
+}
+
+// smzdmPromotion 提取价格文案中金额之后的优惠说明,去掉括号等分隔符
+func smzdmPromotion(price string) string {
+	loc := smzdmPricePattern.FindStringIndex(price)
+	if loc == nil {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(price[loc[1]:]), "（）()，, ")
 }
 
 // 以下是什么值得买 API 的响应结构体定义
@@ -164,3 +312,18 @@ type SmzdmItem struct {
 	TimeSort        int64       `json:"time_sort"`        // 时间戳
 	JumpLink        string      `json:"jump_link"`        // 跳转链接
 }
+
+// SmzdmHaojiaItem 好价榜条目
+type SmzdmHaojiaItem struct {
+	ArticleID interface{} `json:"article_id"`       // 好价 ID(可能为字符串或数字)
+	Title     string      `json:"article_title"`    // 标题
+	Price     string      `json:"article_price"`    // 价格文案,如 "29.9元包邮"
+	Mall      string      `json:"article_mall"`     // 商城
+	Pic       string      `json:"article_pic"`      // 商品图
+	URL       string      `json:"article_url"`      // 详情链接
+	Date      string      `json:"article_date"`     // 发布时间文案(北京时间)
+	Worthy    interface{} `json:"article_worthy"`   // "值"的票数
+	Unworthy  interface{} `json:"article_unworthy"` // "不值"的票数
+	Comment   interface{} `json:"article_comment"`  // 评论数
+	TimeSort  int64       `json:"timesort"`         // 时间戳
+}
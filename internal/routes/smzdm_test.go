@@ -0,0 +1,172 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// smzdmHaojiaItems 好价榜条目(节选)
+// 第二项没有时间戳和链接,只有发布时间文案;第三项没有标题
+const smzdmHaojiaItems = `[
+  {"article_id":112233445,"article_title":"小米 Redmi Buds 5 降噪耳机","article_price":"129元包邮","article_mall":"京东","article_pic":"https://qny.smzdm.com/202405/10/a.jpg","article_url":"https://www.smzdm.com/p/112233445/","article_date":"12:00","article_worthy":"90","article_unworthy":"10","article_comment":36,"timesort":1715313600},
+  {"article_id":"112233446","article_title":"  iPad Air 6 ","article_price":"¥4,399（需用券）","article_mall":"天猫","article_pic":"","article_url":"","article_date":"2024-05-10 09:30","article_worthy":0,"article_unworthy":0,"article_comment":"5","timesort":0},
+  {"article_id":112233447,"article_title":"","article_price":"9.9元"}
+]`
+
+// smzdmRankFixture 文章榜接口响应(节选)
+const smzdmRankFixture = `{"error_code":0,"data":[
+  {"article_id":"82345678","title":"618 耳机选购指南","content":"从百元到千元","pic_url":"https://qna.smzdm.com/202405/10/b.jpg","nickname":"值友小王","collection_count":"1520","time_sort":1715313600,"jump_link":"https://post.smzdm.com/p/82345678/"}
+]}`
+
+func TestSmzdmParseHaojia(t *testing.T) {
+	h := &SmzdmHandler{}
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"数组", smzdmHaojiaItems},
+		{"data 数组", `{"error_code":0,"data":` + smzdmHaojiaItems + `}`},
+		{"data.list", `{"error_code":0,"data":{"list":` + smzdmHaojiaItems + `}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := h.parseHaojia([]byte(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 3 || items[0].Title != "小米 Redmi Buds 5 降噪耳机" {
+				t.Errorf("items = %+v", items)
+			}
+		})
+	}
+
+	if _, err := h.parseHaojia([]byte(`<html>验证</html>`)); err == nil {
+		t.Error("err = nil, want error for non-JSON body")
+	}
+}
+
+func TestSmzdmTransformHaojia(t *testing.T) {
+	h := &SmzdmHandler{}
+	items, err := h.parseHaojia([]byte(smzdmHaojiaItems))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := h.transformHaojia(items)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "112233445" || first.Hot != int64(90) || first.Timestamp != int64(1715313600000) || first.Desc != "129元包邮" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	wantExtra := map[string]interface{}{
+		"price":       "129元包邮",
+		"price_value": 129.0,
+		"promotion":   "包邮",
+		"mall":        "京东",
+		"worthy":      int64(90),
+		"unworthy":    int64(10),
+		"worthy_rate": int64(90),
+		"comments":    int64(36),
+	}
+	for key, want := range wantExtra {
+		if first.Extra[key] != want {
+			t.Errorf("Extra[%s] = %v, want %v", key, first.Extra[key], want)
+		}
+	}
+
+	// 没有链接时按 ID 拼接,没有投票时不计算值率,没有时间戳时使用发布时间文案
+	second := data[1]
+	if second.Title != "iPad Air 6" || second.URL != "https://www.smzdm.com/p/112233446/" {
+		t.Errorf("data[1] = %+v", second)
+	}
+	if second.Extra["price_value"] != 4399.0 || second.Extra["promotion"] != "需用券" || second.Extra["comments"] != int64(5) {
+		t.Errorf("Extra = %v", second.Extra)
+	}
+	if _, ok := second.Extra["worthy_rate"]; ok {
+		t.Errorf("worthy_rate = %v, want none without votes", second.Extra["worthy_rate"])
+	}
+	if second.Timestamp != int64(1715304600000) {
+		t.Errorf("Timestamp = %v, want 2024-05-10 09:30 北京时间", second.Timestamp)
+	}
+}
+
+func TestParseSmzdmPrice(t *testing.T) {
+	tests := []struct {
+		price     string
+		value     float64
+		ok        bool
+		promotion string
+	}{
+		{"29.9元包邮", 29.9, true, "包邮"},
+		{"¥1,299", 1299, true, ""},
+		{"899元（定金50）", 899, true, "定金50"},
+		{"限时秒杀", 0, false, ""},
+	}
+	for _, tt := range tests {
+		value, ok := parseSmzdmPrice(tt.price)
+		if value != tt.value || ok != tt.ok {
+			t.Errorf("parseSmzdmPrice(%q) = %v, %v, want %v, %v", tt.price, value, ok, tt.value, tt.ok)
+		}
+		if got := smzdmPromotion(tt.price); got != tt.promotion {
+			t.Errorf("smzdmPromotion(%q) = %q, want %q", tt.price, got, tt.promotion)
+		}
+	}
+}
+
+func TestSmzdmChannel(t *testing.T) {
+	var requested string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		if r.URL.Path == "/haojia" {
+			fmt.Fprint(w, smzdmHaojiaItems)
+			return
+		}
+		requested += "?unit=" + r.URL.Query().Get("unit")
+		fmt.Fprint(w, smzdmRankFixture)
+	}))
+	defer upstream.Close()
+
+	originalRank, originalHaojia := smzdmRankAPI, smzdmHaojiaAPI
+	smzdmRankAPI, smzdmHaojiaAPI = upstream.URL+"/rank", upstream.URL+"/haojia"
+	defer func() { smzdmRankAPI, smzdmHaojiaAPI = originalRank, originalHaojia }()
+
+	h := &SmzdmHandler{fetcher: newTestFetcher(t)}
+	app := fiber.New()
+	app.Get(h.GetPath(), h.Handle)
+
+	tests := []struct {
+		query    string
+		want     string
+		wantType string
+		wantLen  int
+	}{
+		{"", "/rank?unit=1", "今日热门", 1},
+		{"&type=7", "/rank?unit=7", "周热门", 1},
+		// 好价榜忽略 type 参数
+		{"&channel=haojia&type=7", "/haojia", "好价榜", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			status, result := getJSON(t, app, "/smzdm?cache=false"+tt.query)
+			if status != fiber.StatusOK {
+				t.Fatalf("status = %d", status)
+			}
+			if requested != tt.want || result["type"] != tt.wantType {
+				t.Errorf("requested = %q, type = %v", requested, result["type"])
+			}
+			if items, _ := result["data"].([]interface{}); len(items) != tt.wantLen {
+				t.Errorf("len(data) = %d, want %d", len(items), tt.wantLen)
+			}
+		})
+	}
+
+	if status, _ := getJSON(t, app, "/smzdm?channel=unknown"); status != fiber.StatusBadRequest {
+		t.Errorf("channel=unknown: status = %d, want 400", status)
+	}
+}
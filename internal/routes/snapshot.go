@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// snapshotDayPattern 只精确到天的日期,如 "2024-01-02"
+var snapshotDayPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// handleSnapshot 历史快照查询处理器
+//
+//   - /history/:platform                        列出全部快照的小时
+//   - /history/:platform?date=yyyy-mm-dd        列出当天快照的小时
+//   - /history/:platform?date=yyyy-mm-dd-HH     返回该小时的快照内容
+func (r *Registry) handleSnapshot(c *fiber.Ctx) error {
+	snapshots := r.fetcher.Snapshots()
+	if snapshots == nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, "未开启抓取快照(snapshot.enabled)"))
+	}
+
+	platform := c.Params("platform")
+	if _, ok := r.handlers["/"+platform]; !ok {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, "未知的平台: "+platform))
+	}
+
+	date := c.Query("date")
+	if date == "" || snapshotDayPattern.MatchString(date) {
+		hours, err := snapshots.List(platform, date)
+		if err != nil {
+			return fetchError(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"code":      200,
+			"platform":  platform,
+			"count":     len(hours),
+			"snapshots": hours,
+		})
+	}
+
+	snapshot, err := snapshots.Load(platform, date)
+	if errors.Is(err, service.ErrSnapshotNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, err.Error()))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponseObj(fiber.StatusBadRequest, err.Error()))
+	}
+
+	return c.JSON(fiber.Map{
+		"code":     200,
+		"platform": snapshot.Platform,
+		"hour":     snapshot.Hour,
+		"entries":  snapshot.Entries,
+	})
+}
@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// sseEvent 推送给 SSE 客户端的数据
+type sseEvent struct {
+	Platform  string           `json:"platform"`  // 平台路由名,如 "weibo"
+	Data      []models.HotData `json:"data"`      // 最新热榜数据
+	Timestamp int64            `json:"timestamp"` // 推送时间(毫秒)
+}
+
+// EnableSSE 启用 /sse/:platform 推送
+// hub 负责变化检测与分发,heartbeat 为心跳间隔;未启用时 /sse 返回 404
+func (r *Registry) EnableSSE(hub *service.Hub, heartbeat time.Duration) {
+	r.hub = hub
+	r.sseHeartbeat = heartbeat
+}
+
+// handleSSE SSE 实时推送处理器
+// 连接建立后推送一次当前数据(读取缓存),之后后台刷新检测到数据变化时推送 update 事件;
+// 空闲时按心跳间隔发送注释行保活,写入失败(客户端断开)或服务关闭时结束连接并取消订阅
+func (r *Registry) handleSSE(c *fiber.Ctx) error {
+	if r.hub == nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, "未开启 SSE 推送(sse.enabled)"))
+	}
+
+	// 参数指向请求缓冲区,流式写入发生在处理器返回之后,需要复制
+	platform := strings.Clone(c.Params("platform"))
+	if _, ok := r.handlers["/"+platform]; !ok {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponseObj(fiber.StatusNotFound, "未知的平台: "+platform))
+	}
+
+	sub, err := r.hub.Subscribe(platform)
+	if errors.Is(err, service.ErrTooManySubscribers) || errors.Is(err, service.ErrHubClosed) {
+		c.Set(fiber.HeaderRetryAfter, "30")
+		return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponseObj(fiber.StatusServiceUnavailable, err.Error()))
+	}
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // 关闭 nginx 缓冲
+
+	// server.write_timeout 只在开始写响应时设置一次截止时间,长连接需要在每次写入前顺延
+	conn := c.Context().Conn()
+	heartbeat := r.sseHeartbeat
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer r.hub.Unsubscribe(sub)
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		lastSent := ""
+		write := func(chunk string) error {
+			_ = conn.SetWriteDeadline(time.Now().Add(2 * heartbeat))
+			if _, err := w.WriteString(chunk); err != nil {
+				return err
+			}
+			return w.Flush()
+		}
+		push := func(data []models.HotData) error {
+			signature := service.DataSignature(data)
+			if signature == lastSent {
+				return nil
+			}
+			body, err := json.Marshal(sseEvent{Platform: platform, Data: data, Timestamp: time.Now().UnixMilli()})
+			if err != nil {
+				return err
+			}
+			lastSent = signature
+			return write(fmt.Sprintf("event: update\nid: %s\ndata: %s\n\n", signature, body))
+		}
+
+		// 告知浏览器断线后的重连间隔
+		if err := write(fmt.Sprintf("retry: %d\n\n", heartbeat.Milliseconds())); err != nil {
+			return
+		}
+
+		// 先发送响应头再读取初始数据,回源较慢时客户端不必一直等待;
+		// 读取失败时不中断连接,等待后台刷新推送
+		if resp, err := r.Current(context.Background(), "/"+platform); err == nil {
+			if err := push(resp.Data); err != nil {
+				return
+			}
+		} else {
+			logger.Warn("SSE 初始数据获取失败", zap.String("platform", platform), zap.Error(err))
+		}
+
+		for {
+			select {
+			case data, ok := <-sub.C:
+				if !ok {
+					// 服务关闭
+					return
+				}
+				if err := push(data); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if err := write(": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
@@ -12,6 +12,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// sspaiTypeMap 榜单类型映射: type 参数 -> 榜单名称
+// 不在映射中的 type 视为文章标签(兼容旧的 ?type=<标签名> 用法)
+var sspaiTypeMap = map[string]string{
+	"hot":    "热门文章",
+	"index":  "首页推荐",
+	"matrix": "Matrix",
+}
+
+// sspaiAPIMap 榜单类型对应的接口地址
+var sspaiAPIMap = map[string]string{
+	"index":  "https://sspai.com/api/v1/article/index/page/get?limit=40&offset=0",
+	"matrix": "https://sspai.com/api/v1/articles?offset=0&limit=40&is_matrix=1&sort=matrix_at&include_total=false",
+}
+
+// sspaiTagURL 按标签获取文章的接口,热门文章与自定义标签共用
+var sspaiTagURL = "https://sspai.com/api/v1/article/tag/page/get"
+
 // SspaiHandler 少数派处理器
 type SspaiHandler struct {
 	fetcher *service.Fetcher
@@ -31,37 +48,55 @@ func (h *SspaiHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *SspaiHandler) Handle(c *fiber.Ctx) error {
-	// 获取查询参数
-	tag := c.Query("type", "热门文章")
+	// 获取查询参数: 榜单类型,或任意文章标签
+	rankType := c.Query("type", "hot")
 	noCache := c.Query("cache") == "false"
 
+	label, ok := sspaiTypeMap[rankType]
+	if !ok {
+		label = rankType
+	}
+
 	// 获取数据
-	data, err := h.fetchSspaiHot(c.Context(), tag)
+	cacheKey := fmt.Sprintf("sspai_%s", rankType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchSspai(ctx, rankType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
-		"sspai",                            // name: 平台调用名称
-		"少数派",                              // title: 平台显示名称
-		fmt.Sprintf("热榜 · %s", tag),        // type: 榜单类型
-		"发现少数派热门文章",                        // description: 平台描述
-		"https://sspai.com/",               // link: 官方链接
-		map[string]interface{}{"tag": tag}, // params: 标签参数映射
-		data,                               // data: 热榜数据
-		!noCache,                           // fromCache: 是否来自缓存
+		"sspai",                       // name: 平台调用名称
+		"少数派",                         // title: 平台显示名称
+		fmt.Sprintf("热榜 · %s", label), // type: 榜单类型
+		"发现少数派热门文章",                   // description: 平台描述
+		"https://sspai.com/",          // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": sspaiTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
-// fetchSspaiHot 从少数派 API 获取数据
-func (h *SspaiHandler) fetchSspaiHot(ctx context.Context, tag string) ([]models.HotData, error) {
-	apiURL := fmt.Sprintf("https://sspai.com/api/v1/article/tag/page/get?limit=40&tag=%s", url.QueryEscape(tag))
+// fetchSspai 从少数派 API 获取数据
+func (h *SspaiHandler) fetchSspai(ctx context.Context, rankType string) ([]models.HotData, error) {
+	apiURL, ok := sspaiAPIMap[rankType]
+	if !ok {
+		// 热门文章及自定义标签都走标签接口
+		tag := rankType
+		if label, known := sspaiTypeMap[rankType]; known {
+			tag = label
+		}
+		apiURL = fmt.Sprintf("%s?limit=40&tag=%s", sspaiTagURL, url.QueryEscape(tag))
+	}
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求少数派 API 失败: %w", err)
 	}
@@ -89,6 +124,10 @@ func (h *SspaiHandler) transformData(items []SspaiItem) []models.HotData {
 			URL:       fmt.Sprintf("https://sspai.com/post/%d", item.ID),
 			MobileURL: fmt.Sprintf("https://sspai.com/post/%d", item.ID),
 			Timestamp: item.ReleasedTime * 1000, // 时间戳转换为毫秒级
+			Extra: map[string]interface{}{
+				"likes":    item.LikeCount,
+				"comments": item.CommentCount,
+			},
 		}
 
 		result = append(result, hotData)
@@ -110,6 +149,7 @@ type SspaiItem struct {
 	Banner       string      `json:"banner"`
 	Author       SspaiAuthor `json:"author"`
 	LikeCount    int64       `json:"like_count"`
+	CommentCount int64       `json:"comment_count"`
 	ReleasedTime int64       `json:"released_time"`
 }
 
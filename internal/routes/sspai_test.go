@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// sspaiFixture 文章列表接口响应(节选)
+const sspaiFixture = `{"error":0,"msg":"","data":[
+  {"id":88123,"title":"我的 2024 年效率工具清单","summary":"从笔记到自动化,一次说清。","banner":"https://cdn.sspai.com/article/cover.png","author":{"id":1,"nickname":"少数派编辑部","avatar":"https://cdn.sspai.com/avatar.png"},"like_count":356,"comment_count":42,"released_time":1714550400,"is_matrix":false},
+  {"id":88124,"title":"用快捷指令整理照片","summary":"","banner":"","author":{"nickname":"Matrix 作者"},"like_count":0,"comment_count":3,"released_time":1714464000,"is_matrix":true}
+]}`
+
+func TestSspaiTransformData(t *testing.T) {
+	var resp SspaiAPIResponse
+	if err := json.Unmarshal([]byte(sspaiFixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &SspaiHandler{}
+	data := h.transformData(resp.Data)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "88123" || first.Title != "我的 2024 年效率工具清单" || first.Author != "少数派编辑部" || first.Desc != "从笔记到自动化,一次说清。" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://cdn.sspai.com/article/cover.png" || first.Hot != int64(356) || first.Timestamp != int64(1714550400000) {
+		t.Errorf("Cover = %q, Hot = %v, Timestamp = %v", first.Cover, first.Hot, first.Timestamp)
+	}
+	if first.URL != "https://sspai.com/post/88123" || first.Extra["likes"] != int64(356) || first.Extra["comments"] != int64(42) {
+		t.Errorf("URL = %q, Extra = %v", first.URL, first.Extra)
+	}
+	if data[1].Author != "Matrix 作者" || data[1].Extra["comments"] != int64(3) {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+}
+
+func TestSspaiFetchType(t *testing.T) {
+	var requested string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path + "?" + r.URL.Query().Get("tag")
+		fmt.Fprint(w, sspaiFixture)
+	}))
+	defer upstream.Close()
+
+	originalAPIs, originalTag := sspaiAPIMap, sspaiTagURL
+	sspaiAPIMap = map[string]string{
+		"index":  upstream.URL + "/index",
+		"matrix": upstream.URL + "/matrix",
+	}
+	sspaiTagURL = upstream.URL + "/tag"
+	defer func() { sspaiAPIMap, sspaiTagURL = originalAPIs, originalTag }()
+
+	h := &SspaiHandler{fetcher: newTestFetcher(t)}
+	tests := []struct {
+		rankType string
+		want     string
+	}{
+		{"index", "/index?"},
+		{"matrix", "/matrix?"},
+		// 热门文章按标签名请求,其他取值视为标签
+		{"hot", "/tag?热门文章"},
+		{"效率工具", "/tag?效率工具"},
+	}
+	for _, tt := range tests {
+		data, err := h.fetchSspai(context.Background(), tt.rankType)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.rankType, err)
+		}
+		if requested != tt.want || len(data) != 2 {
+			t.Errorf("%s: requested %q with %d items, want %q", tt.rankType, requested, len(data), tt.want)
+		}
+	}
+}
@@ -36,9 +36,12 @@ func (h *TechCrunchHandler) GetPath() string {
 func (h *TechCrunchHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
-	data, err := h.fetchTechCrunch(c.Context())
+	cacheKey := "techcrunch"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchTechCrunch(ctx)
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	resp := models.SuccessResponse(
@@ -49,7 +52,7 @@ func (h *TechCrunchHandler) Handle(c *fiber.Ctx) error {
 		"https://techcrunch.com/",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -58,7 +61,7 @@ func (h *TechCrunchHandler) Handle(c *fiber.Ctx) error {
 // fetchTechCrunch 拉取并转换 TechCrunch RSS 数据
 func (h *TechCrunchHandler) fetchTechCrunch(ctx context.Context) ([]models.HotData, error) {
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(techCrunchFeedURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, techCrunchFeedURL, map[string]string{
 		"Accept":          "application/rss+xml, application/xml;q=0.9, */*;q=0.8",
 		"Accept-Language": "en-US,en;q=0.9",
 	})
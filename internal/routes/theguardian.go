@@ -33,9 +33,12 @@ func (h *GuardianHandler) GetPath() string {
 func (h *GuardianHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
-	data, err := h.fetchGuardian(c.Context())
+	cacheKey := "theguardian"
+	data, fromCache, err := h.fetcher.FetchWithFallback(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, h.sources(), func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchGuardian(ctx)
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	resp := models.SuccessResponse(
@@ -46,7 +49,7 @@ func (h *GuardianHandler) Handle(c *fiber.Ctx) error {
 		"https://www.theguardian.com/world",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -60,11 +63,24 @@ func (h *GuardianHandler) fetchGuardian(ctx context.Context) ([]models.HotData,
 		"Accept-Language": "en-US,en;q=0.9",
 	}
 
-	body, err := httpClient.Get(guardianFeedURL, headers)
+	body, err := httpClient.GetContext(ctx, guardianFeedURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求 The Guardian feed 失败: %w", err)
 	}
 
+	return h.parseGuardian(body)
+}
+
+// sources 备用源解析策略: feed2json 转换结果按主源格式解析,其余地址按订阅源解析(如官方 RSS)
+func (h *GuardianHandler) sources() service.SourceParsers {
+	return service.SourceParsers{
+		"feed2json.org": h.parseGuardian,
+		"*":             parseFeedSource,
+	}
+}
+
+// parseGuardian 解析 feed2json 转换后的 JSON Feed
+func (h *GuardianHandler) parseGuardian(body []byte) ([]models.HotData, error) {
 	var feed guardianFeedResponse
 	if err := json.Unmarshal(body, &feed); err != nil {
 		return nil, fmt.Errorf("解析 The Guardian feed 失败: %w", err)
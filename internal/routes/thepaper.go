@@ -11,6 +11,19 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// thepaperTypeMap 榜单类型映射
+var thepaperTypeMap = map[string]string{
+	"hot":   "热榜",
+	"news":  "要闻",
+	"video": "视频",
+}
+
+// thepaperChannelIDs 频道类榜单对应的频道 ID
+var thepaperChannelIDs = map[string]string{
+	"news":  "25950", // 时事
+	"video": "26916", // 视频
+}
+
 // ThePaperHandler 澎湃新闻处理器
 type ThePaperHandler struct {
 	fetcher *service.Fetcher
@@ -30,25 +43,40 @@ func (h *ThePaperHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *ThePaperHandler) Handle(c *fiber.Ctx) error {
-	// 获取缓存标志
+	// 获取查询参数
+	listType := c.Query("type", "hot")
+	if _, ok := thepaperTypeMap[listType]; !ok {
+		listType = "hot"
+	}
 	noCache := c.Query("cache") == "false"
 
-	// 获取数据
-	data, err := h.fetchThePaper(c.Context())
+	// 获取数据(热榜沿用原缓存键)
+	cacheKey := "thepaper"
+	if listType != "hot" {
+		cacheKey = fmt.Sprintf("thepaper_%s", listType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if channelID, ok := thepaperChannelIDs[listType]; ok {
+			return h.fetchChannel(ctx, channelID)
+		}
+		return h.fetchThePaper(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
 		"thepaper",                 // name: 平台调用名称
 		"澎湃新闻",                     // title: 平台显示名称
-		"热榜",                       // type: 榜单类型
+		thepaperTypeMap[listType],  // type: 榜单类型
 		"发现澎湃新闻热门资讯",               // description: 平台描述
 		"https://www.thepaper.cn/", // link: 官方链接
-		nil,                        // params: 无参数映射
-		data,                       // data: 热榜数据
-		!noCache,                   // fromCache: 是否来自缓存
+		map[string]interface{}{ // params: 参数说明
+			"type": thepaperTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -60,7 +88,7 @@ func (h *ThePaperHandler) fetchThePaper(ctx context.Context) ([]models.HotData,
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求澎湃新闻 API 失败: %w", err)
 	}
@@ -75,25 +103,43 @@ func (h *ThePaperHandler) fetchThePaper(ctx context.Context) ([]models.HotData,
 	return h.transformData(apiResp.Data.HotNews), nil
 }
 
+// fetchChannel 获取频道内容列表(要闻、视频)
+func (h *ThePaperHandler) fetchChannel(ctx context.Context, channelID string) ([]models.HotData, error) {
+	apiURL := "https://api.thepaper.cn/contentapi/nodeCont/getByChannelId"
+
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.PostContext(ctx, apiURL, map[string]interface{}{
+		"channelId":      channelID,
+		"excludeContIds": []string{},
+		"pageSize":       20,
+		"pageNum":        1,
+	}, map[string]string{
+		"Content-Type": "application/json",
+		"Referer":      "https://www.thepaper.cn/",
+		"Origin":       "https://www.thepaper.cn",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求澎湃新闻频道失败: %w", err)
+	}
+
+	var apiResp ThePaperChannelResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析澎湃新闻频道响应失败: %w", err)
+	}
+	if apiResp.Code != 200 {
+		return nil, fmt.Errorf("澎湃新闻频道返回错误(code=%d): %s", apiResp.Code, apiResp.Desc)
+	}
+
+	return h.transformData(apiResp.Data.List), nil
+}
+
 // transformData 将澎湃新闻原始数据转换为统一格式
 func (h *ThePaperHandler) transformData(items []ThePaperItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
 		// 处理PraiseTimes字段(可能是int64或string)
-		var praiseTimes int64
-		switch v := item.PraiseTimes.(type) {
-		case float64:
-			praiseTimes = int64(v)
-		case int64:
-			praiseTimes = v
-		case string:
-			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-				praiseTimes = parsed
-			}
-		default:
-			praiseTimes = 0
-		}
+		praiseTimes := models.ToInt64(item.PraiseTimes)
 
 		// 转换时间戳(毫秒 -> 秒)
 		timestamp := strconv.FormatInt(item.PubTimeLong/1000, 10)
@@ -102,6 +148,7 @@ func (h *ThePaperHandler) transformData(items []ThePaperItem) []models.HotData {
 			ID:        item.ContID,
 			Title:     item.Name,
 			Cover:     item.Pic,
+			Author:    item.NodeInfo.Name, // 来源栏目
 			Hot:       praiseTimes,
 			Timestamp: timestamp,
 			URL:       fmt.Sprintf("https://www.thepaper.cn/newsDetail_forward_%s", item.ContID),
@@ -133,4 +180,16 @@ type ThePaperItem struct {
 	Pic         string      `json:"pic"`         // 封面图
 	PraiseTimes interface{} `json:"praiseTimes"` // 点赞数 (可能是int64或string)
 	PubTimeLong int64       `json:"pubTimeLong"` // 发布时间(毫秒)
+	NodeInfo    struct {
+		Name string `json:"name"` // 栏目名称,如 "中国政库"
+	} `json:"nodeInfo"` // 所属栏目
+}
+
+// ThePaperChannelResponse 澎湃新闻频道接口响应
+type ThePaperChannelResponse struct {
+	Code int    `json:"code"`
+	Desc string `json:"desc"`
+	Data struct {
+		List []ThePaperItem `json:"list"`
+	} `json:"data"`
 }
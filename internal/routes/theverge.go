@@ -35,9 +35,12 @@ func (h *TheVergeHandler) GetPath() string {
 func (h *TheVergeHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
-	data, err := h.fetchTheVerge(c.Context())
+	cacheKey := "theverge"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchTheVerge(ctx)
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	resp := models.SuccessResponse(
@@ -48,7 +51,7 @@ func (h *TheVergeHandler) Handle(c *fiber.Ctx) error {
 		"https://www.theverge.com/",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -57,7 +60,7 @@ func (h *TheVergeHandler) Handle(c *fiber.Ctx) error {
 // fetchTheVerge 拉取并转换 The Verge Atom feed
 func (h *TheVergeHandler) fetchTheVerge(ctx context.Context) ([]models.HotData, error) {
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(theVergeFeedURL, map[string]string{
+	body, err := httpClient.GetContext(ctx, theVergeFeedURL, map[string]string{
 		"Accept":          "application/atom+xml, application/xml;q=0.9, */*;q=0.8",
 		"Accept-Language": "en-US,en;q=0.9",
 	})
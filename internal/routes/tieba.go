@@ -1,16 +1,36 @@
 package routes
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/transform"
 )
 
+// tiebaForumFilter 吧内帖子过滤规则: 过滤置顶帖(吧规、公告等)
+var tiebaForumFilter = models.ItemFilter{
+	Flags: []string{"pinned"},
+}
+
+// tiebaCommentPattern 吧页面的帖子列表包在 HTML 注释中延迟渲染,解析前需要去掉注释符号
+var tiebaCommentPattern = regexp.MustCompile(`<!--|-->`)
+
+// tiebaGBKPattern 识别页面声明的 GBK 系编码(旧版页面)
+var tiebaGBKPattern = regexp.MustCompile(`(?i)charset\s*=\s*["']?\s*(gbk|gb2312|gb18030)`)
+
 // TiebaHandler 百度贴吧处理器
 type TiebaHandler struct {
 	fetcher *service.Fetcher
@@ -30,12 +50,21 @@ func (h *TiebaHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *TiebaHandler) Handle(c *fiber.Ctx) error {
+	// 获取查询参数: 指定吧名时抓取该吧热帖,缺省为热议榜
+	name := strings.TrimSuffix(strings.TrimSpace(c.Query("name")), "吧")
 	noCache := c.Query("cache") == "false"
 
+	if name != "" {
+		return h.handleForum(c, name, noCache)
+	}
+
 	// 直接调用fetch函数获取数据
-	data, err := h.fetchTieba(c.Context())
+	cacheKey := "tieba"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchTieba(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建响应
@@ -45,9 +74,34 @@ func (h *TiebaHandler) Handle(c *fiber.Ctx) error {
 		"热议榜",
 		"百度贴吧热议榜",
 		"https://tieba.baidu.com/hottopic/browse/topicList",
-		nil,
+		map[string]interface{}{"name": "吧名,如 ?name=原神;缺省为热议榜"},
 		data,
-		!noCache,
+		fromCache,
+	)
+
+	return c.JSON(resp)
+}
+
+// handleForum 处理指定吧名的请求
+func (h *TiebaHandler) handleForum(c *fiber.Ctx, name string, noCache bool) error {
+	cacheKey := fmt.Sprintf("tieba_forum_%s", name)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchForum(ctx, name)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	forumURL := h.forumURL(name)
+	resp := models.SuccessResponse(
+		"tieba_hot",
+		"百度贴吧",
+		fmt.Sprintf("%s吧", name),
+		fmt.Sprintf("百度贴吧 %s吧 热帖", name),
+		forumURL,
+		map[string]interface{}{"name": name},
+		data,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -59,7 +113,7 @@ func (h *TiebaHandler) fetchTieba(ctx context.Context) ([]models.HotData, error)
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求百度贴吧 API 失败: %w", err)
 	}
@@ -72,7 +126,7 @@ func (h *TiebaHandler) fetchTieba(ctx context.Context) ([]models.HotData, error)
 
 	// 检查数据结构
 	if apiResp.Data.BangTopic.TopicList == nil {
-		return nil, fmt.Errorf("百度贴吧数据为空")
+		return nil, fmt.Errorf("百度贴吧%w", service.ErrEmptyData)
 	}
 
 	// 转换为统一格式
@@ -88,17 +142,7 @@ func (h *TiebaHandler) transformData(items []TiebaItem) []models.HotData {
 		timestamp := strconv.FormatInt(item.CreateTime, 10)
 
 		// 处理TopicID字段（可能是int64或string）
-		topicID := ""
-		switch v := item.TopicID.(type) {
-		case float64:
-			topicID = strconv.FormatInt(int64(v), 10)
-		case int64:
-			topicID = strconv.FormatInt(v, 10)
-		case string:
-			topicID = v
-		default:
-			topicID = ""
-		}
+		topicID := models.ToString(item.TopicID)
 
 		hotData := models.HotData{
 			ID:        topicID,
@@ -117,6 +161,112 @@ func (h *TiebaHandler) transformData(items []TiebaItem) []models.HotData {
 	return result
 }
 
+// forumURL 吧首页地址
+func (h *TiebaHandler) forumURL(name string) string {
+	return fmt.Sprintf("https://tieba.baidu.com/f?kw=%s&ie=utf-8", url.QueryEscape(name))
+}
+
+// fetchForum 抓取指定吧的帖子列表
+// 贴吧对无浏览器特征的请求会跳转到安全验证页,需要带完整的浏览器请求头
+func (h *TiebaHandler) fetchForum(ctx context.Context, name string) ([]models.HotData, error) {
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, h.forumURL(name), map[string]string{
+		"User-Agent":      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		"Accept-Language": "zh-CN,zh;q=0.9",
+		"Referer":         "https://tieba.baidu.com/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求贴吧页面失败: %w", err)
+	}
+
+	html, err := h.decode(body)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(html, "wappass.baidu.com") || strings.Contains(html, "百度安全验证") {
+		return nil, fmt.Errorf("贴吧触发安全验证,请稍后再试")
+	}
+
+	data, err := h.parseForum(html)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("贴吧 %s吧 %w", name, service.ErrEmptyData)
+	}
+
+	return h.fetcher.FilterItems("tieba", tiebaForumFilter, data), nil
+}
+
+// decode 将页面转换为 UTF-8,旧版页面使用 GBK 编码
+func (h *TiebaHandler) decode(body []byte) (string, error) {
+	head := body
+	if len(head) > 2048 {
+		head = head[:2048]
+	}
+	if !tiebaGBKPattern.Match(head) {
+		return string(body), nil
+	}
+
+	utf8Data, err := io.ReadAll(transform.NewReader(bytes.NewReader(body), simplifiedchinese.GB18030.NewDecoder()))
+	if err != nil {
+		return "", fmt.Errorf("转换贴吧页面编码失败: %w", err)
+	}
+	return string(utf8Data), nil
+}
+
+// parseForum 解析吧页面的帖子列表
+// 每个帖子的 data-field 属性为 JSON,包含帖子 ID、作者、回复数与是否置顶
+func (h *TiebaHandler) parseForum(html string) ([]models.HotData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(tiebaCommentPattern.ReplaceAllString(html, "")))
+	if err != nil {
+		return nil, fmt.Errorf("解析贴吧页面失败: %w", err)
+	}
+
+	result := make([]models.HotData, 0, 50)
+	seen := make(map[int64]bool)
+	doc.Find("li.j_thread_list").Each(func(_ int, s *goquery.Selection) {
+		var field TiebaThreadField
+		if err := json.Unmarshal([]byte(s.AttrOr("data-field", "")), &field); err != nil || field.ID == 0 || seen[field.ID] {
+			return
+		}
+
+		link := s.Find("a.j_th_tit").First()
+		title := strings.TrimSpace(link.AttrOr("title", link.Text()))
+		if title == "" {
+			return
+		}
+		seen[field.ID] = true
+
+		author := field.AuthorNickname
+		if author == "" {
+			author = field.AuthorName
+		}
+
+		cover := s.Find("img.threadlist_pic").First()
+		threadURL := fmt.Sprintf("https://tieba.baidu.com/p/%d", field.ID)
+
+		result = append(result, models.HotData{
+			ID:        strconv.FormatInt(field.ID, 10),
+			Title:     title,
+			Desc:      strings.TrimSpace(s.Find(".threadlist_abs").First().Text()),
+			Cover:     cover.AttrOr("bpic", cover.AttrOr("data-original", "")),
+			Author:    author,
+			Hot:       field.ReplyNum,
+			Timestamp: timeutil.ParseTime(strings.TrimSpace(s.Find(".is_show_create_time").First().Text())),
+			URL:       threadURL,
+			MobileURL: threadURL,
+			Extra: map[string]interface{}{
+				"pinned": field.IsTop,
+				"good":   field.IsGood,
+			},
+		})
+	})
+
+	return result, nil
+}
+
 // 以下是百度贴吧 API 的响应结构体定义
 
 // TiebaAPIResponse 百度贴吧 API 响应
@@ -144,3 +294,13 @@ type TiebaItem struct {
 	CreateTime int64       `json:"create_time"` // 创建时间
 	TopicURL   string      `json:"topic_url"`   // 话题链接
 }
+
+// TiebaThreadField 吧页面帖子的 data-field 属性
+type TiebaThreadField struct {
+	ID             int64       `json:"id"`              // 帖子 ID
+	AuthorName     string      `json:"author_name"`     // 作者用户名
+	AuthorNickname string      `json:"author_nickname"` // 作者昵称
+	ReplyNum       int64       `json:"reply_num"`       // 回复数
+	IsTop          interface{} `json:"is_top"`          // 是否置顶(bool 或 0/1)
+	IsGood         interface{} `json:"is_good"`         // 是否精品(bool 或 0/1)
+}
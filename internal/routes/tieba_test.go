@@ -0,0 +1,107 @@
+package routes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// tiebaForumFixture 吧页面(节选),帖子列表包在 HTML 注释中
+const tiebaForumFixture = `<!DOCTYPE html><html><head><meta charset="UTF-8"></head><body>
+<code class="pagelet_html" id="pagelet_html_frs-list/pagelet/thread_list"><!--
+<ul id="thread_list" class="threadlist_bright j_threadlist_bright">
+  <li class=" j_thread_list thread_top j_thread_list clearfix" data-field='{"id":8912345670,"author_name":"吧务","author_nickname":null,"reply_num":12,"is_top":true,"is_good":false}'>
+    <a rel="noreferrer" href="/p/8912345670" title="【吧规】本吧发帖须知" target="_blank" class="j_th_tit ">【吧规】本吧发帖须知</a>
+  </li>
+  <li class=" j_thread_list clearfix" data-field='{"id":8912345671,"author_name":"tb_user1","author_nickname":"旅行者","reply_num":356,"is_top":0,"is_good":1}'>
+    <div class="threadlist_title"><a rel="noreferrer" href="/p/8912345671" title="新版本角色强度讨论" target="_blank" class="j_th_tit ">新版本角色强度讨论</a></div>
+    <div class="threadlist_abs threadlist_abs_onlyline "> 大家觉得新角色值得抽吗 </div>
+    <img class="threadlist_pic j_m_pic " bpic="https://imgsa.baidu.com/forum/pic/item/a.jpg" src="">
+    <span class="threadlist_reply_date pull_right j_reply_data" title="最后回复时间">12:30</span>
+    <span class="pull-right is_show_create_time" title="创建时间">2024-05-01</span>
+  </li>
+  <li class=" j_thread_list clearfix" data-field='{"id":8912345671,"reply_num":1}'>
+    <a class="j_th_tit" title="重复的帖子">重复的帖子</a>
+  </li>
+  <li class=" j_thread_list clearfix" data-field='not json'><a class="j_th_tit">无效</a></li>
+</ul>
+--></code>
+</body></html>`
+
+func TestTiebaParseForum(t *testing.T) {
+	h := &TiebaHandler{}
+	data, err := h.parseForum(tiebaForumFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	// 置顶帖保留 pinned 标记,由平台过滤规则去掉
+	if data[0].ID != "8912345670" || data[0].Author != "吧务" || data[0].Extra["pinned"] != true {
+		t.Errorf("data[0] = %+v", data[0])
+	}
+
+	thread := data[1]
+	if thread.ID != "8912345671" || thread.Title != "新版本角色强度讨论" || thread.Author != "旅行者" || thread.Hot != int64(356) {
+		t.Errorf("data[1] = %+v", thread)
+	}
+	if thread.Desc != "大家觉得新角色值得抽吗" || thread.Cover != "https://imgsa.baidu.com/forum/pic/item/a.jpg" {
+		t.Errorf("Desc = %q, Cover = %q", thread.Desc, thread.Cover)
+	}
+	if thread.URL != "https://tieba.baidu.com/p/8912345671" || thread.Timestamp == int64(0) {
+		t.Errorf("URL = %q, Timestamp = %v", thread.URL, thread.Timestamp)
+	}
+	if thread.Extra["good"] != float64(1) {
+		t.Errorf("Extra = %v", thread.Extra)
+	}
+}
+
+func TestTiebaDecode(t *testing.T) {
+	h := &TiebaHandler{}
+	page := `<html><head><meta http-equiv="Content-Type" content="text/html; charset=gbk"></head><body>原神吧</body></html>`
+	gbk, err := simplifiedchinese.GBK.NewEncoder().String(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := h.decode([]byte(gbk))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != page {
+		t.Errorf("decode(gbk) = %q, want %q", got, page)
+	}
+
+	// UTF-8 页面原样返回
+	if got, _ := h.decode([]byte(tiebaForumFixture)); got != tiebaForumFixture {
+		t.Error("decode(utf-8) changed the page")
+	}
+}
+
+// tiebaTopicFixture 热议榜接口响应(节选)
+const tiebaTopicFixture = `{"errno":0,"errmsg":"success","data":{"bang_topic":{"module_title":"贴吧热议榜","topic_list":[
+  {"topic_id":28375,"topic_name":"五一假期去哪玩","topic_desc":"晒出你的旅行照片","abstract":"","topic_pic":"https://tieba-fe.cdn.bcebos.com/topic.jpg","tag":2,"discuss_num":1234567,"idx_num":1,"create_time":1714550400,"content_num":0,"topic_avatar":"","is_video_topic":"0","topic_url":"https://tieba.baidu.com/hottopic/browse/hottopic?topic_id=28375&topic_name=%E4%BA%94%E4%B8%80"},
+  {"topic_id":"28376","topic_name":"字符串 ID 的话题","discuss_num":99,"create_time":1714464000,"topic_url":"https://tieba.baidu.com/hottopic/browse/hottopic?topic_id=28376"}
+]}}}`
+
+func TestTiebaTransformData(t *testing.T) {
+	var resp TiebaAPIResponse
+	if err := json.Unmarshal([]byte(tiebaTopicFixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &TiebaHandler{}
+	data := h.transformData(resp.Data.BangTopic.TopicList)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+	if data[0].ID != "28375" || data[0].Title != "五一假期去哪玩" || data[0].Hot != int64(1234567) || data[0].Timestamp != "1714550400" {
+		t.Errorf("data[0] = %+v", data[0])
+	}
+	if data[1].ID != "28376" {
+		t.Errorf("data[1].ID = %q, want string topic_id", data[1].ID)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
@@ -34,9 +33,12 @@ func (h *ToutiaoHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 获取数据
-	data, err := h.fetchToutiaoHot(c.Context())
+	cacheKey := "toutiao"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchToutiaoHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
@@ -48,7 +50,7 @@ func (h *ToutiaoHandler) Handle(c *fiber.Ctx) error {
 		"https://www.toutiao.com/", // link: 官方链接
 		nil,                        // params: 无参数映射
 		data,                       // data: 热榜数据
-		!noCache,                   // fromCache: 是否来自缓存
+		fromCache,                  // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -59,7 +61,7 @@ func (h *ToutiaoHandler) fetchToutiaoHot(ctx context.Context) ([]models.HotData,
 	apiURL := "https://www.toutiao.com/hot-event/hot-board/?origin=toutiao_pc"
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求今日头条 API 失败: %w", err)
 	}
@@ -69,7 +71,9 @@ func (h *ToutiaoHandler) fetchToutiaoHot(ctx context.Context) ([]models.HotData,
 		return nil, fmt.Errorf("解析今日头条响应失败: %w", err)
 	}
 
-	return h.transformData(apiResp.Data), nil
+	// 热榜接口本身不含广告,内置规则为空,只应用配置中的过滤规则
+	data := h.transformData(apiResp.Data)
+	return h.fetcher.FilterItems("toutiao", models.ItemFilter{}, data), nil
 }
 
 // transformData 转换数据格式
@@ -84,20 +88,7 @@ func (h *ToutiaoHandler) transformData(items []ToutiaoItem) []models.HotData {
 		}
 
 		// 处理HotValue字段(可能是int64或string)
-		var hotValue int64
-		switch v := item.HotValue.(type) {
-		case float64:
-			hotValue = int64(v)
-		case int64:
-			hotValue = v
-		case string:
-			// 字符串转int64
-			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-				hotValue = parsed
-			}
-		default:
-			hotValue = 0
-		}
+		hotValue := models.ToInt64(item.HotValue)
 
 		hotData := models.HotData{
 			ID:        item.ClusterIdStr,
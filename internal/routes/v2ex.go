@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -12,6 +14,9 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// v2exNodePattern 合法的节点名,如 go、create、python
+var v2exNodePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
 // V2exHandler V2EX处理器
 type V2exHandler struct {
 	fetcher *service.Fetcher
@@ -35,10 +40,25 @@ func (h *V2exHandler) Handle(c *fiber.Ctx) error {
 	topicType := c.Query("type", "hot")
 	noCache := c.Query("cache") == "false"
 
+	// 指定节点时抓取该节点的最新主题,忽略 type
+	node := strings.ToLower(strings.TrimSpace(c.Query("node")))
+	if node != "" && !v2exNodePattern.MatchString(node) {
+		return c.Status(400).JSON(models.ErrorResponseObj(400, "node 参数只能包含字母、数字、下划线和短横线"))
+	}
+
 	// 获取数据
-	data, err := h.fetchV2exHot(c.Context(), topicType)
+	cacheKey := fmt.Sprintf("v2ex_%s", topicType)
+	if node != "" {
+		cacheKey = fmt.Sprintf("v2ex_node_%s", node)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if node != "" {
+			return h.fetchV2exNode(ctx, node)
+		}
+		return h.fetchV2exHot(ctx, topicType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 准备类型映射表 - 用于前端显示支持的类型
@@ -52,6 +72,14 @@ func (h *V2exHandler) Handle(c *fiber.Ctx) error {
 	if typeName == "" {
 		typeName = "最热主题"
 	}
+	if node != "" {
+		typeName = fmt.Sprintf("节点 %s", node)
+		if len(data) > 0 {
+			if title, ok := data[0].Extra["node"].(string); ok && title != "" {
+				typeName = fmt.Sprintf("节点 %s", title)
+			}
+		}
+	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
@@ -62,9 +90,10 @@ func (h *V2exHandler) Handle(c *fiber.Ctx) error {
 		"https://www.v2ex.com/", // link: 官方链接
 		map[string]interface{}{ // params: 参数说明
 			"type": typeMap,
+			"node": "节点名,如 go、create,指定后返回该节点的最新主题",
 		},
-		data,     // data: 热榜数据
-		!noCache, // fromCache: 是否来自缓存
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -79,6 +108,26 @@ func (h *V2exHandler) fetchV2exHot(ctx context.Context, topicType string) ([]mod
 		apiURL = "https://r.jina.ai/https://www.v2ex.com/api/topics/latest.json"
 	}
 
+	return h.fetchV2exTopics(ctx, apiURL)
+}
+
+// fetchV2exNode 获取指定节点的最新主题
+// 节点主题接口返回的主题额外带有 node 信息,不存在的节点返回空数组
+func (h *V2exHandler) fetchV2exNode(ctx context.Context, node string) ([]models.HotData, error) {
+	apiURL := "https://r.jina.ai/https://www.v2ex.com/api/topics/show.json?node_name=" + url.QueryEscape(node)
+
+	data, err := h.fetchV2exTopics(ctx, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("V2EX 节点 %s 不存在或%w", node, service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// fetchV2exTopics 请求主题列表接口
+func (h *V2exHandler) fetchV2exTopics(ctx context.Context, apiURL string) ([]models.HotData, error) {
 	headers := map[string]string{
 		"User-Agent":      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
 		"Accept":          "text/plain; charset=utf-8",
@@ -86,7 +135,7 @@ func (h *V2exHandler) fetchV2exHot(ctx context.Context, topicType string) ([]mod
 	}
 
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求V2EX API 失败: %w", err)
 	}
@@ -120,6 +169,12 @@ func (h *V2exHandler) transformData(items []V2exItem) []models.HotData {
 			URL:       item.URL,
 			MobileURL: item.URL,
 		}
+		if item.Node.Name != "" {
+			hotData.Extra = map[string]interface{}{
+				"node":      item.Node.Title,
+				"node_name": item.Node.Name,
+			}
+		}
 
 		result = append(result, hotData)
 	}
@@ -136,6 +191,13 @@ type V2exItem struct {
 	Replies int        `json:"replies"`
 	Created int64      `json:"created"` // 创建时间戳(秒级)
 	Member  V2exMember `json:"member"`
+	Node    V2exNode   `json:"node"`
+}
+
+// V2exNode 节点信息
+type V2exNode struct {
+	Name  string `json:"name"`  // 节点名,如 go
+	Title string `json:"title"` // 节点标题,如 Go 编程语言
 }
 
 // V2exMember 成员信息
@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/service"
+	"go.uber.org/zap"
+)
+
+// warmUpConcurrency 缓存预热的最大并发数,避免启动时资源占用过高
+const warmUpConcurrency = 3
+
+// WarmUpTarget 缓存预热的平台
+type WarmUpTarget struct {
+	Path     string // 平台路径,如 "/weibo"
+	CacheKey string // 规范榜单的缓存键,如 "weibo_realtime"
+}
+
+// DefaultWarmUpTargets 默认预热的热门平台,高热度平台优先
+//
+// 其他可按需加入预热的候选平台(更新频繁、访问量较高),加入时需填写其规范榜单的缓存键:
+//
+//	"/ithome"  IT之家热榜
+//	"/cnbeta"  cnBeta 最新资讯
+//	"/36kr"    36氪人气榜
+var DefaultWarmUpTargets = []WarmUpTarget{
+	{Path: "/weibo", CacheKey: "weibo_realtime"},    // 微博热搜
+	{Path: "/toutiao", CacheKey: "toutiao"},         // 今日头条
+	{Path: "/baidu", CacheKey: "baidu_realtime"},    // 百度热搜
+	{Path: "/bilibili", CacheKey: "bilibili_hot_0"}, // B站热榜
+	{Path: "/douyin", CacheKey: "douyin_hot"},       // 抖音热点
+	{Path: "/github", CacheKey: "github_daily"},     // GitHub趋势
+	{Path: "/csdn", CacheKey: "csdn"},               // CSDN热门
+	{Path: "/v2ex", CacheKey: "v2ex_hot"},           // V2EX最热
+	{Path: "/hackernews", CacheKey: "hackernews"},   // Hacker News
+	{Path: "/zhihu", CacheKey: "zhihu"},             // 知乎热榜
+}
+
+// WarmUpResult 缓存预热的结果
+type WarmUpResult struct {
+	Cached int // 缓存中已有数据(如其他实例写入 Redis)而跳过的平台数
+	Warmed int // 回源抓取成功的平台数
+	Failed int // 抓取失败的平台数
+}
+
+// WarmUp 预热平台的缓存
+// 先通过一次批量读取(L2 只需一次往返)跳过已有缓存的平台,已在 L2 中的数据同时回填到本地 L1;
+// 其余平台在进程内并发抓取,抓取结果收集后通过一次批量写入写入缓存
+// ctx 取消(服务关闭)后不再发起新的抓取,已抓取的结果仍会写入缓存
+// 必须在 RegisterRoutes 之后调用
+func (r *Registry) WarmUp(ctx context.Context, targets []WarmUpTarget) WarmUpResult {
+	var result WarmUpResult
+
+	keys := make([]string, 0, len(targets))
+	for _, target := range targets {
+		r.defaultKeys.Store(target.Path, target.CacheKey)
+		keys = append(keys, target.CacheKey)
+	}
+	cached := r.fetcher.CachedMany(ctx, keys)
+
+	batch := &service.WriteBatch{}
+	batchID := newInternalRequestToken()
+	r.writeBatches.Store(batchID, batch)
+	defer r.writeBatches.Delete(batchID)
+	headers := map[string]string{internalWriteBatchHeader: batchID}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, warmUpConcurrency)
+	for _, target := range targets {
+		if _, ok := cached[target.CacheKey]; ok {
+			result.Cached++
+			continue
+		}
+
+		wg.Add(1)
+		go func(target WarmUpTarget) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// 服务正在关闭,跳过剩余的预热
+			if ctx.Err() != nil {
+				return
+			}
+
+			start := time.Now()
+			_, err := r.invokeWithHeaders(ctx, target.Path, "DailyHotApi/CacheWarmer", headers)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failed++
+				logger.Warn("缓存预热失败",
+					zap.String("platform", target.Path),
+					zap.Error(err),
+				)
+				return
+			}
+			result.Warmed++
+			logger.Info("缓存预热成功",
+				zap.String("platform", target.Path),
+				zap.Duration("elapsed", time.Since(start)),
+			)
+		}(target)
+	}
+	wg.Wait()
+
+	// 服务关闭时 ctx 已取消,写入缓存不能再依赖它
+	if err := r.fetcher.FlushWrites(context.Background(), batch); err != nil {
+		logger.Warn("缓存预热结果写入失败", zap.Error(err))
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestDefaultWarmUpTargetsCacheKeys(t *testing.T) {
+	fetcher := newTestFetcher(t)
+	r := NewRegistry(fetcher)
+	r.Register(NewWeiboHandler(fetcher))
+	r.Register(NewToutiaoHandler(fetcher))
+	r.Register(NewBaiduHandler(fetcher))
+	r.Register(NewBilibiliHandler(fetcher))
+	r.Register(NewDouyinHandler(fetcher))
+	r.Register(NewGitHubHandler(fetcher))
+	r.Register(NewCSDNHandler(fetcher))
+	r.Register(NewV2exHandler(fetcher))
+	r.Register(NewHackerNewsHandler(fetcher))
+	r.Register(NewZhihuHandler(fetcher))
+	app := fiber.New()
+	r.RegisterRoutes(app)
+
+	// 只读缓存模式不回源,只记录处理器读取的规范榜单缓存键
+	for _, target := range DefaultWarmUpTargets {
+		res, err := app.Test(httptest.NewRequest("GET", target.Path+"?cache=only", nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != fiber.StatusNoContent {
+			t.Errorf("%s: status = %d, want 204", target.Path, res.StatusCode)
+		}
+		key, ok := r.defaultKeys.Load(target.Path)
+		if !ok || key != target.CacheKey {
+			t.Errorf("%s: cache key = %v, want %s", target.Path, key, target.CacheKey)
+		}
+	}
+}
+
+func TestWarmUp(t *testing.T) {
+	fetcher := newTestFetcher(t)
+	r := NewRegistry(fetcher)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		r.Register(&panicHandler{path: path, fetcher: fetcher})
+	}
+	r.RegisterRoutes(fiber.New())
+
+	// /a 已有缓存,预热时跳过
+	if _, _, err := fetcher.Fetch(context.Background(), "a_hot", time.Minute, false, func(ctx context.Context) ([]models.HotData, error) {
+		return []models.HotData{{ID: "1", Title: "已缓存"}}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	result := r.WarmUp(context.Background(), []WarmUpTarget{
+		{Path: "/a", CacheKey: "a_hot"},
+		{Path: "/b", CacheKey: "b_hot"},
+		{Path: "/missing", CacheKey: "missing_hot"},
+	})
+	if result != (WarmUpResult{Cached: 1, Warmed: 1, Failed: 1}) {
+		t.Errorf("WarmUp = %+v, want 1 cached, 1 warmed, 1 failed", result)
+	}
+	if cached := fetcher.CachedMany(context.Background(), []string{"b_hot"}); len(cached["b_hot"]) != 1 {
+		t.Errorf("b_hot not cached after warm-up: %v", cached)
+	}
+
+	// /all 返回已知缓存键的平台的缓存状态
+	_, body := getJSON(t, r.app, "/all")
+	routes, _ := body["routes"].([]interface{})
+	want := map[string]interface{}{"/a": true, "/b": true, "/c": nil}
+	for _, item := range routes {
+		route := item.(map[string]interface{})
+		if got := route["cached"]; got != want[route["path"].(string)] {
+			t.Errorf("%s: cached = %v, want %v", route["path"], got, want[route["path"].(string)])
+		}
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
@@ -28,9 +31,21 @@ func (h *WeatherAlarmHandler) GetPath() string {
 	return "/weatheralarm"
 }
 
+// weatherAlarmLevelPattern 从预警标题中提取预警级别
+// 如 "广东省气象台发布暴雨橙色预警" -> "橙色"
+var weatherAlarmLevelPattern = regexp.MustCompile(`(红色|橙色|黄色|蓝色)预警`)
+
+// weatherAlarmLevelCode 预警级别对应的英文代码,便于前端按颜色渲染
+var weatherAlarmLevelCode = map[string]string{
+	"红色": "red",
+	"橙色": "orange",
+	"黄色": "yellow",
+	"蓝色": "blue",
+}
+
 // Handle 处理请求
 func (h *WeatherAlarmHandler) Handle(c *fiber.Ctx) error {
-	province := c.Query("province", "") // 省份参数(可选)
+	province := strings.TrimSpace(c.Query("province", "")) // 省份参数(可选)
 	noCache := c.Query("cache") == "false"
 
 	subtitle := "全国气象预警"
@@ -38,10 +53,14 @@ func (h *WeatherAlarmHandler) Handle(c *fiber.Ctx) error {
 		subtitle = province + "气象预警"
 	}
 
-	// 直接调用fetch函数获取数据
-	data, err := h.fetchWeatherAlarm(c.Context(), province)
+	// 缓存键需要带上省份,避免不同省份的数据互相覆盖
+	cacheKey := fmt.Sprintf("weatheralarm_%s", province)
+
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, 10*time.Minute, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchWeatherAlarm(ctx, province)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建params
@@ -61,7 +80,7 @@ func (h *WeatherAlarmHandler) Handle(c *fiber.Ctx) error {
 		"http://www.nmc.cn",
 		params,
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -74,7 +93,7 @@ func (h *WeatherAlarmHandler) fetchWeatherAlarm(ctx context.Context, province st
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求中央气象台 API 失败: %w", err)
 	}
@@ -85,8 +104,36 @@ func (h *WeatherAlarmHandler) fetchWeatherAlarm(ctx context.Context, province st
 		return nil, fmt.Errorf("解析中央气象台响应失败: %w", err)
 	}
 
+	// 接口的 province 参数并不总是生效,解析后再按地区过滤一次
+	items := h.filterByProvince(apiResp.Data.Page.List, province)
+
 	// 转换为统一格式
-	return h.transformData(apiResp.Data.Page.List), nil
+	return h.transformData(items), nil
+}
+
+// filterByProvince 按省份过滤预警数据
+// 预警标题以发布单位开头(如 "广东省气象台"),据此判断所属地区
+func (h *WeatherAlarmHandler) filterByProvince(items []WeatherAlarmItem, province string) []WeatherAlarmItem {
+	if province == "" {
+		return items
+	}
+
+	// 去掉行政区划后缀,兼容 "广东"、"广东省" 两种写法
+	keyword := province
+	for _, suffix := range []string{"维吾尔自治区", "壮族自治区", "回族自治区", "自治区", "特别行政区", "省", "市"} {
+		if strings.HasSuffix(keyword, suffix) && keyword != suffix {
+			keyword = strings.TrimSuffix(keyword, suffix)
+			break
+		}
+	}
+
+	result := make([]WeatherAlarmItem, 0, len(items))
+	for _, item := range items {
+		if strings.Contains(item.Title, keyword) {
+			result = append(result, item)
+		}
+	}
+	return result
 }
 
 // transformData 将气象预警数据转换为统一格式
@@ -107,6 +154,14 @@ func (h *WeatherAlarmHandler) transformData(items []WeatherAlarmItem) []models.H
 			MobileURL: "http://nmc.cn" + item.URL,
 		}
 
+		// 预警级别(红/橙/黄/蓝)放入扩展字段
+		if matches := weatherAlarmLevelPattern.FindStringSubmatch(item.Title); len(matches) > 1 {
+			hotData.Extra = map[string]interface{}{
+				"level":      matches[1],
+				"level_code": weatherAlarmLevelCode[matches[1]],
+			}
+		}
+
 		result = append(result, hotData)
 	}
 
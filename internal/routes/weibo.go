@@ -28,43 +28,82 @@ func (h *WeiboHandler) GetPath() string {
 	return "/weibo"
 }
 
+// weiboTypeMap 榜单类型映射: type 参数 -> 榜单名称
+var weiboTypeMap = map[string]string{
+	"realtime": "热搜榜",
+	"ent":      "文娱榜",
+	"news":     "要闻榜",
+}
+
+// weiboFilterTypeMap 榜单类型对应的 containerid 过滤类型
+// 与 https://s.weibo.com/top/summary?cate=xxx 的 cate 参数一致
+var weiboFilterTypeMap = map[string]string{
+	"realtime": "realtimehot",
+	"ent":      "entrank",
+	"news":     "socialevent",
+}
+
+// weiboAdFilter 广告识别规则: 推广位带有 promotion 字段,标签为 "荐"
+var weiboAdFilter = models.ItemFilter{
+	Flags: []string{"ad"},
+}
+
 // Handle 处理请求
 func (h *WeiboHandler) Handle(c *fiber.Ctx) error {
+	// 获取榜单类型 (实时热搜/文娱/要闻)
+	listType := c.Query("type", "realtime")
+	if _, ok := weiboTypeMap[listType]; !ok {
+		listType = "realtime"
+	}
+
 	// 获取缓存标志
 	noCache := c.Query("cache") == "false"
 
 	// 获取热搜数据
-	data, err := h.fetchWeiboHot(c.Context())
+	cacheKey := fmt.Sprintf("weibo_%s", listType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchWeiboHot(ctx, listType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
-		"weibo",                           // name: 平台调用名称
-		"微博",                              // title: 平台显示名称
-		"热搜榜",                             // type: 榜单类型
-		"发现微博实时热门话题",                      // description: 平台描述
-		"https://s.weibo.com/top/summary", // link: 官方链接
-		nil,                               // params: 无参数映射
-		data,                              // data: 热榜数据
-		!noCache,                          // fromCache: 是否来自缓存
+		"weibo",                // name: 平台调用名称
+		"微博",                   // title: 平台显示名称
+		weiboTypeMap[listType], // type: 榜单类型
+		"发现微博实时热门话题",           // description: 平台描述
+		"https://s.weibo.com/top/summary?cate="+weiboFilterTypeMap[listType], // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": weiboTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
 }
 
-// fetchWeiboHot 从微博 API 获取热搜数据
-func (h *WeiboHandler) fetchWeiboHot(ctx context.Context) ([]models.HotData, error) {
-	// 微博热搜 API
-	apiURL := "https://m.weibo.cn/api/container/getIndex?containerid=106003type%3D25%26t%3D3%26disable_hot%3D1%26filter_type%3Drealtimehot&title=%E5%BE%AE%E5%8D%9A%E7%83%AD%E6%90%9C&extparam=filter_type%3Drealtimehot%26mi_cid%3D100103%26pos%3D0_0%26c_type%3D30%26display_time%3D1540538388&luicode=10000011&lfid=231583"
+// fetchWeiboHot 从微博 API 获取指定榜单数据
+func (h *WeiboHandler) fetchWeiboHot(ctx context.Context, listType string) ([]models.HotData, error) {
+	filterType := weiboFilterTypeMap[listType]
+
+	// 微博热搜 API (不同榜单通过 containerid 中的 filter_type 区分)
+	containerID := "106003type=25&t=3&disable_hot=1&filter_type=" + filterType
+	extParam := "filter_type=" + filterType + "&mi_cid=100103&pos=0_0&c_type=30&display_time=1540538388"
+	apiURL := fmt.Sprintf("https://m.weibo.cn/api/container/getIndex?containerid=%s&title=%s&extparam=%s&luicode=10000011&lfid=231583",
+		url.QueryEscape(containerID),
+		url.QueryEscape("微博"+weiboTypeMap[listType]),
+		url.QueryEscape(extParam),
+	)
 
 	// 发起 HTTP 请求(需要特定的 User-Agent 模拟移动端)
 	// Cookie来源: https://github.com/teg1c/weibo-hot-crawler
 	// 感谢 teg1c 提供的微博Cookie解决方案
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, map[string]string{
-		"Referer":          "https://s.weibo.com/top/summary?cate=realtimehot",
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
+		"Referer":          "https://s.weibo.com/top/summary?cate=" + filterType,
 		"MWeibo-Pwa":       "1",
 		"X-Requested-With": "XMLHttpRequest",
 		"User-Agent":       "Mozilla/5.0 (iPhone; CPU iPhone OS 11_0 like Mac OS X) AppleWebKit/604.1.38 (KHTML, like Gecko) Version/11.0 Mobile/15A372 Safari/604.1",
@@ -87,11 +126,12 @@ func (h *WeiboHandler) fetchWeiboHot(ctx context.Context) ([]models.HotData, err
 
 	// 检查数据结构
 	if len(apiResp.Data.Cards) == 0 || apiResp.Data.Cards[0].CardGroup == nil {
-		return nil, fmt.Errorf("微博数据为空")
+		return nil, fmt.Errorf("微博%w", service.ErrEmptyData)
 	}
 
-	// 转换为统一格式
-	return h.transformData(apiResp.Data.Cards[0].CardGroup), nil
+	// 转换为统一格式并过滤推广位
+	data := h.transformData(apiResp.Data.Cards[0].CardGroup)
+	return h.fetcher.FilterItems("weibo", weiboAdFilter, data), nil
 }
 
 // transformData 将微博原始数据转换为统一格式
@@ -99,7 +139,7 @@ func (h *WeiboHandler) transformData(items []WeiboItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
-		// 跳过第一个(通常是置顶广告)
+		// 跳过卡片组的标题项(没有话题内容)
 		if item.Desc == "" {
 			continue
 		}
@@ -122,6 +162,9 @@ func (h *WeiboHandler) transformData(items []WeiboItem) []models.HotData {
 			// 可选字段
 			Timestamp: item.OnboardTime * 1000, // 时间戳转换为毫秒级
 		}
+		if (len(item.Promotion) > 0 && string(item.Promotion) != "null") || item.IconDesc == "荐" {
+			hotData.Extra = map[string]interface{}{"ad": true}
+		}
 
 		result = append(result, hotData)
 	}
@@ -149,10 +192,12 @@ type WeiboCard struct {
 
 // WeiboItem 单个热搜项
 type WeiboItem struct {
-	ItemID      string `json:"itemid"`       // 唯一标识
-	Desc        string `json:"desc"`         // 热搜标题
-	WordScheme  string `json:"word_scheme"`  // 话题关键词
-	OnboardTime int64  `json:"onboard_time"` // 上榜时间
-	Num         int64  `json:"num"`          // 热度值
-	Scheme      string `json:"scheme"`       // 移动端链接
+	ItemID      string          `json:"itemid"`       // 唯一标识
+	Desc        string          `json:"desc"`         // 热搜标题
+	WordScheme  string          `json:"word_scheme"`  // 话题关键词
+	OnboardTime int64           `json:"onboard_time"` // 上榜时间
+	Num         int64           `json:"num"`          // 热度值
+	Scheme      string          `json:"scheme"`       // 移动端链接
+	IconDesc    string          `json:"icon_desc"`    // 标签,如 "热"、"新"、"荐"
+	Promotion   json.RawMessage `json:"promotion"`    // 推广信息,仅推广位存在
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf16"
 
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
@@ -34,11 +35,17 @@ func (h *WereadHandler) GetPath() string {
 func (h *WereadHandler) Handle(c *fiber.Ctx) error {
 	rankType := c.Query("type", "rising") // 默认飙升榜
 	noCache := c.Query("cache") == "false"
+	if err := validateParam("type", rankType, isSlug); err != nil {
+		return paramError(c, err)
+	}
 
 	// 直接调用fetch函数获取数据
-	data, err := h.fetchWeread(c.Context(), rankType)
+	cacheKey := fmt.Sprintf("weread_%s", rankType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchWeread(ctx, rankType)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建响应
@@ -50,7 +57,7 @@ func (h *WereadHandler) Handle(c *fiber.Ctx) error {
 		"https://weread.qq.com",
 		map[string]interface{}{"type": rankType},
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -81,7 +88,7 @@ func (h *WereadHandler) fetchWeread(ctx context.Context, rankType string) ([]mod
 		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36 Edg/114.0.1823.67",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求微信读书 API 失败: %w", err)
 	}
@@ -107,20 +114,13 @@ func (h *WereadHandler) transformData(items []WereadBook) []models.HotData {
 		cover := strings.Replace(book.Cover, "s_", "t9_", 1)
 
 		// 处理PublishTime字段(可能是int64或string)
-		var timestamp string
-		switch v := book.PublishTime.(type) {
-		case float64:
-			timestamp = strconv.FormatInt(int64(v), 10)
-		case int64:
-			timestamp = strconv.FormatInt(v, 10)
-		case string:
-			timestamp = v
-		default:
-			timestamp = ""
-		}
+		timestamp := models.ToString(book.PublishTime)
 
-		// 获取书籍 ID 的 Base64 编码形式
+		// 获取书籍 ID 的编码形式,缺少 bookId 时无法生成详情页链接
 		bookID := h.getWereadID(book.BookID)
+		if bookID == "" {
+			continue
+		}
 
 		hotData := models.HotData{
 			ID:        book.BookID,
@@ -142,7 +142,13 @@ func (h *WereadHandler) transformData(items []WereadBook) []models.HotData {
 
 // getWereadID 将 bookId 转换为编码格式
 // 参考原TypeScript实现: src/utils/getToken/weread.ts
+// bookId 为空时返回空字符串
 func (h *WereadHandler) getWereadID(bookID string) string {
+	bookID = strings.TrimSpace(bookID)
+	if bookID == "" {
+		return ""
+	}
+
 	// 使用 MD5 哈希算法
 	hash := md5.Sum([]byte(bookID))
 	str := fmt.Sprintf("%x", hash)
@@ -175,9 +181,10 @@ func (h *WereadHandler) getWereadID(bookID string) string {
 		fa = []interface{}{"3", chunks}
 	} else {
 		// 如果书籍 ID 包含其他字符，则将每个字符的 Unicode 编码转换为十六进制表示
+		// 与 JS 的 charCodeAt 保持一致,按 UTF-16 码元而不是 rune 转换
 		hexStr := ""
-		for _, ch := range bookID {
-			hexStr += fmt.Sprintf("%x", ch)
+		for _, unit := range utf16.Encode([]rune(bookID)) {
+			hexStr += fmt.Sprintf("%x", unit)
 		}
 		fa = []interface{}{"4", []string{hexStr}}
 	}
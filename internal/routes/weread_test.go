@@ -0,0 +1,43 @@
+package routes
+
+import "testing"
+
+// 期望值由原 TypeScript 实现(src/utils/getToken/weread.ts)计算得到
+func TestGetWereadID(t *testing.T) {
+	tests := []struct {
+		name   string
+		bookID string
+		want   string
+	}{
+		{"纯数字多段", "3300046137", "b57322e0813ab7885g017bdd"},
+		{"纯数字单段", "695233", "ce032b305a9bc1ce0b0dd2a"},
+		{"单个数字", "1", "c4c329b011c4ca4238a0201"},
+		{"前导零", "000000001", "97732df011977bc7f022b25"},
+		{"20 位数字", "12345678901234567890", "fd832710775bcd15g06bc614eg025a1f7"},
+		{"含字母", "CB_3eB9aXbpeD3e6DG6Dh", "0a742422a43425f3365423961586270654433653644473644686d9"},
+		{"公众号书籍", "MP_WXS_3073282833", "e7442e4224d505f5758535f333037333238323833335e1"},
+		{"非 ASCII", "书", "2f34261044e662f3703e8d6"},
+		// 编码前缀长度恰好为 20,不需要补齐
+		{"前缀恰好 20 位", "1000000001", "404327f075f5e100g011f8e"},
+		// 编码前缀 19 位,补齐 1 位
+		{"前缀 19 位", "0100000001", "c96325d06989680g011c15c"},
+	}
+
+	h := &WereadHandler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.getWereadID(tt.bookID); got != tt.want {
+				t.Errorf("getWereadID(%q) = %q, want %q", tt.bookID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWereadIDEmpty(t *testing.T) {
+	h := &WereadHandler{}
+	for _, bookID := range []string{"", "   "} {
+		if got := h.getWereadID(bookID); got != "" {
+			t.Errorf("getWereadID(%q) = %q, want empty", bookID, got)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"github.com/dailyhot/api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	xiaohongshuBaseURL    = "https://www.xiaohongshu.com/"
+	xiaohongshuExploreURL = "https://www.xiaohongshu.com/explore"
+	xiaohongshuHotListURL = "https://edith.xiaohongshu.com/api/sns/v1/search/hot_list"
+	xiaohongshuUserAgent  = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36"
+)
+
+// xiaohongshuTypeMap 榜单类型映射
+var xiaohongshuTypeMap = map[string]string{
+	"search": "热搜",
+	"note":   "热门笔记",
+}
+
+// xiaohongshuStatePattern 发现页内嵌的初始状态
+var xiaohongshuStatePattern = regexp.MustCompile(`(?s)window\.__INITIAL_STATE__\s*=\s*(\{.*?\})\s*</script>`)
+
+// xiaohongshuUndefinedPattern 初始状态是 JS 对象字面量,其中的 undefined 需替换为 null 才能按 JSON 解析
+var xiaohongshuUndefinedPattern = regexp.MustCompile(`([:\[,])\s*undefined\b`)
+
+// XiaohongshuHandler 小红书处理器
+type XiaohongshuHandler struct {
+	fetcher *service.Fetcher
+}
+
+// NewXiaohongshuHandler 创建小红书处理器
+func NewXiaohongshuHandler(fetcher *service.Fetcher) *XiaohongshuHandler {
+	return &XiaohongshuHandler{
+		fetcher: fetcher,
+	}
+}
+
+// GetPath 获取路由路径
+func (h *XiaohongshuHandler) GetPath() string {
+	return "/xiaohongshu"
+}
+
+// Handle 处理请求
+func (h *XiaohongshuHandler) Handle(c *fiber.Ctx) error {
+	listType := c.Query("type", "search")
+	if _, ok := xiaohongshuTypeMap[listType]; !ok {
+		listType = "search"
+	}
+	noCache := c.Query("cache") == "false"
+
+	cacheKey := fmt.Sprintf("xiaohongshu_%s", listType)
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if listType == "note" {
+			return h.fetchXiaohongshuNotes(ctx)
+		}
+		return h.fetchXiaohongshuHot(ctx)
+	})
+	if err != nil {
+		return fetchError(c, err)
+	}
+
+	resp := models.SuccessResponse(
+		"xiaohongshu",                  // name: 平台调用名称
+		"小红书",                          // title: 平台显示名称
+		xiaohongshuTypeMap[listType],   // type: 榜单类型
+		"你的生活指南",                       // description: 平台描述
+		"https://www.xiaohongshu.com/", // link: 官方链接
+		map[string]interface{}{ // params: 参数说明
+			"type": xiaohongshuTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
+	)
+
+	return c.JSON(resp)
+}
+
+// fetchXiaohongshuHot 获取热搜榜
+// 接口需要访客 Cookie(webId、a1 等),先访问首页获取;获取失败时降级为不带 Cookie 请求
+func (h *XiaohongshuHandler) fetchXiaohongshuHot(ctx context.Context) ([]models.HotData, error) {
+	httpClient := h.fetcher.GetHTTPClient()
+
+	cookies := make(map[string]string)
+	if err := h.prefetchCookies(ctx, cookies); err != nil {
+		logger.Warn("获取小红书访客 Cookie 失败, 将尝试无 Cookie 请求", zap.Error(err))
+	}
+
+	headers := map[string]string{
+		"User-Agent":      xiaohongshuUserAgent,
+		"Referer":         xiaohongshuBaseURL,
+		"Origin":          strings.TrimSuffix(xiaohongshuBaseURL, "/"),
+		"Accept":          "application/json, text/plain, */*",
+		"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8",
+	}
+	if len(cookies) > 0 {
+		headers["Cookie"] = buildCookieHeader(cookies)
+	}
+
+	body, err := httpClient.GetContext(ctx, xiaohongshuHotListURL, headers)
+	if err != nil && len(cookies) > 0 {
+		logger.Warn("携带 Cookie 请求小红书热搜失败, 将尝试不带 Cookie", zap.Error(err))
+		delete(headers, "Cookie")
+		body, err = httpClient.GetContext(ctx, xiaohongshuHotListURL, headers)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("请求小红书热搜失败: %w", err)
+	}
+
+	var apiResp XiaohongshuHotResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析小红书热搜响应失败: %w", err)
+	}
+	if !apiResp.Success {
+		// 接口签名或风控策略变化时会返回 success=false,记录原因便于排查
+		logger.Warn("小红书热搜接口返回失败",
+			zap.Int("code", apiResp.Code),
+			zap.String("msg", apiResp.Msg),
+		)
+		return nil, fmt.Errorf("小红书热搜接口返回失败: code=%d msg=%s", apiResp.Code, apiResp.Msg)
+	}
+
+	return h.transformHotData(apiResp.Data.Items), nil
+}
+
+// fetchXiaohongshuNotes 获取发现页热门笔记
+// 笔记流接口需要 x-s 签名,这里直接解析发现页服务端渲染的初始状态
+func (h *XiaohongshuHandler) fetchXiaohongshuNotes(ctx context.Context) ([]models.HotData, error) {
+	body, err := h.fetcher.GetHTTPClient().GetContext(ctx, xiaohongshuExploreURL, map[string]string{
+		"User-Agent":      xiaohongshuUserAgent,
+		"Referer":         xiaohongshuBaseURL,
+		"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求小红书发现页失败: %w", err)
+	}
+
+	matches := xiaohongshuStatePattern.FindSubmatch(body)
+	if len(matches) < 2 {
+		logger.Warn("小红书发现页未找到初始状态, 页面结构可能已变化", zap.Int("body_size", len(body)))
+		return nil, fmt.Errorf("小红书发现页未找到初始状态")
+	}
+
+	raw := xiaohongshuUndefinedPattern.ReplaceAll(matches[1], []byte("${1}null"))
+	var state XiaohongshuInitialState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("解析小红书发现页初始状态失败: %w", err)
+	}
+
+	data := h.transformNoteData(state.Feed.Feeds)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("小红书发现页笔记%w", service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// prefetchCookies 访问首页获取访客 Cookie
+func (h *XiaohongshuHandler) prefetchCookies(ctx context.Context, cookies map[string]string) error {
+	resp, err := h.fetcher.GetHTTPClient().GetWithResponseContext(ctx, xiaohongshuBaseURL, map[string]string{
+		"User-Agent":      xiaohongshuUserAgent,
+		"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8",
+	})
+	if err != nil {
+		return fmt.Errorf("请求小红书首页失败: %w", err)
+	}
+
+	setCookies := collectCookiesFromResponse(resp, cookies)
+	if len(cookies) == 0 {
+		return fmt.Errorf("首页未返回 Cookie\n%s", buildSetCookieDebug(setCookies))
+	}
+	return nil
+}
+
+// transformHotData 转换热搜数据
+func (h *XiaohongshuHandler) transformHotData(items []XiaohongshuHotItem) []models.HotData {
+	result := make([]models.HotData, 0, len(items))
+
+	for _, item := range items {
+		title := strings.TrimSpace(item.Title)
+		if title == "" {
+			continue
+		}
+
+		id := item.ID
+		if id == "" {
+			id = title
+		}
+
+		searchURL := fmt.Sprintf("https://www.xiaohongshu.com/search_result?keyword=%s", url.QueryEscape(title))
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     title,
+			Cover:     item.Icon,
+			Hot:       parseAbbrevCount(item.Score),
+			URL:       searchURL,
+			MobileURL: searchURL,
+			Extra: map[string]interface{}{
+				"label": item.WordType,
+			},
+		})
+	}
+
+	return result
+}
+
+// transformNoteData 转换笔记数据,以点赞数作为热度
+func (h *XiaohongshuHandler) transformNoteData(feeds []XiaohongshuFeed) []models.HotData {
+	result := make([]models.HotData, 0, len(feeds))
+
+	for _, feed := range feeds {
+		note := feed.NoteCard
+		title := strings.TrimSpace(note.DisplayTitle)
+		if feed.ID == "" || title == "" {
+			continue
+		}
+
+		noteURL := fmt.Sprintf("https://www.xiaohongshu.com/explore/%s", feed.ID)
+		if feed.XsecToken != "" {
+			noteURL += "?xsec_token=" + url.QueryEscape(feed.XsecToken) + "&xsec_source=pc_feed"
+		}
+
+		result = append(result, models.HotData{
+			ID:        feed.ID,
+			Title:     title,
+			Cover:     note.Cover.URLDefault,
+			Author:    note.User.Nickname,
+			Hot:       parseAbbrevCount(strings.ReplaceAll(note.InteractInfo.LikedCount, "万", "w")),
+			URL:       noteURL,
+			MobileURL: noteURL,
+			Extra: map[string]interface{}{
+				"type": note.Type,
+			},
+		})
+	}
+
+	return result
+}
+
+// XiaohongshuHotResponse 热搜接口响应
+type XiaohongshuHotResponse struct {
+	Success bool   `json:"success"`
+	Code    int    `json:"code"`
+	Msg     string `json:"msg"`
+	Data    struct {
+		Items []XiaohongshuHotItem `json:"items"`
+	} `json:"data"`
+}
+
+// XiaohongshuHotItem 热搜条目
+type XiaohongshuHotItem struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Score    string `json:"score"`     // 热度,如 "933.2w"
+	WordType string `json:"word_type"` // 标签,如 "热"、"新"
+	Icon     string `json:"icon"`
+}
+
+// XiaohongshuInitialState 发现页初始状态(只解析用到的部分)
+type XiaohongshuInitialState struct {
+	Feed struct {
+		Feeds []XiaohongshuFeed `json:"feeds"`
+	} `json:"feed"`
+}
+
+// XiaohongshuFeed 发现页笔记
+type XiaohongshuFeed struct {
+	ID        string              `json:"id"`
+	XsecToken string              `json:"xsecToken"`
+	NoteCard  XiaohongshuNoteCard `json:"noteCard"`
+}
+
+// XiaohongshuNoteCard 笔记卡片
+type XiaohongshuNoteCard struct {
+	DisplayTitle string `json:"displayTitle"`
+	Type         string `json:"type"` // normal 图文 / video 视频
+	Cover        struct {
+		URLDefault string `json:"urlDefault"`
+	} `json:"cover"`
+	User struct {
+		Nickname string `json:"nickname"`
+	} `json:"user"`
+	InteractInfo struct {
+		LikedCount string `json:"likedCount"` // 点赞数,如 "1.2万"
+	} `json:"interactInfo"`
+}
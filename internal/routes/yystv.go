@@ -4,13 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"strings"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/dailyhot/api/internal/models"
 	"github.com/dailyhot/api/internal/service"
+	"github.com/dailyhot/api/pkg/utils/timeutil"
 	"github.com/gofiber/fiber/v2"
 )
 
+// yystvTagMap 栏目标签映射: tag 参数 -> 栏目名称,对应 https://www.yystv.cn/b/<tag>
+var yystvTagMap = map[string]string{
+	"recommend": "推游",
+	"history":   "游戏史",
+	"big":       "大事件",
+	"culture":   "文化",
+	"life":      "趣闻",
+	"video":     "经典回顾",
+}
+
 // YystvHandler 游研社处理器
 type YystvHandler struct {
 	fetcher *service.Fetcher
@@ -30,24 +43,42 @@ func (h *YystvHandler) GetPath() string {
 
 // Handle 处理请求
 func (h *YystvHandler) Handle(c *fiber.Ctx) error {
+	// 获取查询参数: 指定栏目标签时只返回该栏目的文章
+	tag := c.Query("tag")
+	if _, ok := yystvTagMap[tag]; !ok {
+		tag = ""
+	}
 	noCache := c.Query("cache") == "false"
 
-	// 直接调用fetch函数获取数据
-	data, err := h.fetchYystv(c.Context())
+	// 直接调用fetch函数获取数据(全部文章沿用原缓存键)
+	cacheKey := "yystv"
+	typeName := "全部文章"
+	if tag != "" {
+		cacheKey = fmt.Sprintf("yystv_%s", tag)
+		typeName = yystvTagMap[tag]
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if tag != "" {
+			return h.fetchYystvTag(ctx, tag)
+		}
+		return h.fetchYystv(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建响应
 	resp := models.SuccessResponse(
 		"yystv_docs",
 		"游研社",
-		"全部文章",
-		"游研社全部文章",
+		typeName,
+		fmt.Sprintf("游研社%s", typeName),
 		"https://www.yystv.cn",
-		nil,
+		map[string]interface{}{
+			"tag": yystvTagMap,
+		},
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -59,7 +90,7 @@ func (h *YystvHandler) fetchYystv(ctx context.Context) ([]models.HotData, error)
 
 	// 发起 HTTP 请求
 	httpClient := h.fetcher.GetHTTPClient()
-	body, err := httpClient.Get(apiURL, nil)
+	body, err := httpClient.GetContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("请求游研社 API 失败: %w", err)
 	}
@@ -74,43 +105,76 @@ func (h *YystvHandler) fetchYystv(ctx context.Context) ([]models.HotData, error)
 	return h.transformData(apiResp.Data), nil
 }
 
+// fetchYystvTag 抓取栏目页面的文章列表
+// 栏目页没有 JSON 接口,直接解析页面
+func (h *YystvHandler) fetchYystvTag(ctx context.Context, tag string) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("https://www.yystv.cn/b/%s", tag)
+
+	httpClient := h.fetcher.GetHTTPClient()
+	body, err := httpClient.GetContext(ctx, apiURL, map[string]string{
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Accept":     "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("请求游研社栏目页面失败: %w", err)
+	}
+
+	data, err := h.parseTagPage(string(body), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("游研社栏目 %s %w", tag, service.ErrEmptyData)
+	}
+	return data, nil
+}
+
+// parseTagPage 解析栏目页面的文章列表,"3天前" 等相对时间以 now 为基准按北京时间解析
+func (h *YystvHandler) parseTagPage(html string, now time.Time) ([]models.HotData, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("解析游研社栏目页面失败: %w", err)
+	}
+
+	result := make([]models.HotData, 0, 20)
+	doc.Find(".list-container li").Each(func(_ int, s *goquery.Selection) {
+		title := strings.TrimSpace(s.Find(".list-article-title").First().Text())
+		href := s.Find("a").First().AttrOr("href", "")
+		if title == "" || href == "" {
+			return
+		}
+		if !strings.HasPrefix(href, "http") {
+			href = "https://www.yystv.cn" + href
+		}
+
+		img := s.Find("img").First()
+		result = append(result, models.HotData{
+			ID:        strings.TrimPrefix(href, "https://www.yystv.cn/p/"),
+			Title:     title,
+			Desc:      strings.TrimSpace(s.Find(".list-article-intro").First().Text()),
+			Cover:     img.AttrOr("data-original", img.AttrOr("src", "")),
+			Author:    strings.TrimSpace(s.Find(".handler-author-link").First().Text()),
+			Timestamp: timeutil.ParseTimeIn(strings.TrimSpace(s.Find(".c-999").First().Text()), timeutil.Beijing, now),
+			URL:       href,
+			MobileURL: href,
+		})
+	})
+
+	return result, nil
+}
+
 // transformData 将游研社原始数据转换为统一格式
 func (h *YystvHandler) transformData(items []YystvItem) []models.HotData {
 	result := make([]models.HotData, 0, len(items))
 
 	for _, item := range items {
-		// 处理ID字段(可能是int64或string)
-		var itemID int64
-		var itemIDStr string
-		switch v := item.ID.(type) {
-		case float64:
-			itemID = int64(v)
-			itemIDStr = strconv.FormatInt(int64(v), 10)
-		case int64:
-			itemID = v
-			itemIDStr = strconv.FormatInt(v, 10)
-		case string:
-			itemIDStr = v
-			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-				itemID = parsed
-			}
-		default:
-			itemID = 0
+		// 处理ID、CreateTime字段(可能是int64或string)
+		itemID := models.ToInt64(item.ID)
+		itemIDStr := models.ToString(item.ID)
+		if itemIDStr == "" {
 			itemIDStr = "0"
 		}
-
-		// 处理CreateTime字段(可能是int64或string)
-		var timestamp string
-		switch v := item.CreateTime.(type) {
-		case float64:
-			timestamp = strconv.FormatInt(int64(v), 10)
-		case int64:
-			timestamp = strconv.FormatInt(v, 10)
-		case string:
-			timestamp = v
-		default:
-			timestamp = ""
-		}
+		timestamp := models.ToString(item.CreateTime)
 
 		hotData := models.HotData{
 			ID:        itemIDStr,
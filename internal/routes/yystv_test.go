@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/pkg/utils/timeutil"
+)
+
+// yystvTagFixture 栏目页面(节选)
+const yystvTagFixture = `<html><body><div class="list-container"><ul>
+<li class="list-item">
+  <a href="/p/11823" target="_blank"><div class="list-img-box"><img class="lazy" data-original="https://alioss.yystv.cn/doc/11823/cover.jpg" src="/static/img/loading.png"></div></a>
+  <div class="list-article-info">
+    <a href="/p/11823"><div class="list-article-title"> 那些年我们追过的国产单机 </div></a>
+    <div class="list-article-intro">从仙剑到古剑,国产单机走过的三十年</div>
+    <div class="handler"><a class="handler-author-link" href="/u/12">游研社</a><span class="c-999">3天前</span></div>
+  </div>
+</li>
+<li class="list-item">
+  <a href="https://www.yystv.cn/p/11790"><img src="https://alioss.yystv.cn/doc/11790/cover.jpg"></a>
+  <div class="list-article-title">红白机的最后一款游戏</div>
+  <div class="handler"><a class="handler-author-link">编辑部</a><span class="c-999">2024-04-20</span></div>
+</li>
+<li class="list-item"><div class="list-article-title">没有链接的条目</div></li>
+</ul></div></body></html>`
+
+func TestYystvParseTagPage(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, timeutil.Beijing)
+	h := &YystvHandler{}
+	data, err := h.parseTagPage(yystvTagFixture, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+
+	first := data[0]
+	if first.ID != "11823" || first.Title != "那些年我们追过的国产单机" || first.URL != "https://www.yystv.cn/p/11823" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if first.Cover != "https://alioss.yystv.cn/doc/11823/cover.jpg" || first.Author != "游研社" || first.Desc != "从仙剑到古剑,国产单机走过的三十年" {
+		t.Errorf("data[0] = %+v", first)
+	}
+	if want := now.AddDate(0, 0, -3).UnixMilli(); first.Timestamp != want {
+		t.Errorf("Timestamp = %v, want %d", first.Timestamp, want)
+	}
+
+	second := data[1]
+	want := time.Date(2024, 4, 20, 0, 0, 0, 0, timeutil.Beijing).UnixMilli()
+	if second.ID != "11790" || second.Cover != "https://alioss.yystv.cn/doc/11790/cover.jpg" || second.Timestamp != want {
+		t.Errorf("data[1] = %+v", second)
+	}
+}
+
+func TestYystvTransformData(t *testing.T) {
+	fixture := `{"data":[
+  {"id":11823,"title":"那些年我们追过的国产单机","cover":"https://alioss.yystv.cn/doc/11823/cover.jpg","author":"游研社","createtime":1715140800},
+  {"id":"11790","title":"红白机的最后一款游戏","cover":"","author":"编辑部","createtime":"1713542400"}
+]}`
+	var resp YystvAPIResponse
+	if err := json.Unmarshal([]byte(fixture), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &YystvHandler{}
+	data := h.transformData(resp.Data)
+	if len(data) != 2 {
+		t.Fatalf("len(data) = %d, want 2", len(data))
+	}
+	if data[0].ID != "11823" || data[0].URL != "https://www.yystv.cn/p/11823" || data[0].Timestamp != "1715140800" {
+		t.Errorf("data[0] = %+v", data[0])
+	}
+	if data[1].ID != "11790" || data[1].URL != "https://www.yystv.cn/p/11790" || data[1].Timestamp != "1713542400" {
+		t.Errorf("data[1] = %+v", data[1])
+	}
+}
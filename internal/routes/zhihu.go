@@ -12,6 +12,20 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// zhihuTypeMap 榜单类型映射: type 参数 -> 榜单名称
+var zhihuTypeMap = map[string]string{
+	"total":  "热榜",
+	"video":  "视频热榜",
+	"column": "专栏热门",
+}
+
+// zhihuListMap 榜单类型对应的网页版热榜列表名(www.zhihu.com/hot?list=<列表名>)
+// 综合热榜使用 App 接口,不在此表中
+var zhihuListMap = map[string]string{
+	"video":  "zvideo",
+	"column": "column",
+}
+
 // ZhihuHandler 知乎热榜处理器
 type ZhihuHandler struct {
 	fetcher *service.Fetcher
@@ -32,24 +46,39 @@ func (h *ZhihuHandler) GetPath() string {
 // Handle 处理请求
 func (h *ZhihuHandler) Handle(c *fiber.Ctx) error {
 	// 获取查询参数
+	rankType := c.Query("type", "total")
+	if _, ok := zhihuTypeMap[rankType]; !ok {
+		rankType = "total"
+	}
 	noCache := c.Query("cache") == "false"
 
-	// 获取热榜数据
-	data, err := h.fetchZhihuHot(c.Context())
+	// 获取热榜数据(综合热榜沿用原缓存键)
+	cacheKey := "zhihu"
+	if rankType != "total" {
+		cacheKey = fmt.Sprintf("zhihu_%s", rankType)
+	}
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		if list, ok := zhihuListMap[rankType]; ok {
+			return h.fetchZhihuList(ctx, list)
+		}
+		return h.fetchZhihuHot(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建完整响应 (向后兼容原项目API格式)
 	resp := models.SuccessResponse(
 		"zhihu",                     // name: 平台调用名称
 		"知乎",                        // title: 平台显示名称
-		"热榜",                        // type: 榜单类型
+		zhihuTypeMap[rankType],      // type: 榜单类型
 		"发现知乎热门话题",                  // description: 平台描述
 		"https://www.zhihu.com/hot", // link: 官方链接
-		nil,                         // params: 无参数映射
-		data,                        // data: 热榜数据
-		!noCache,                    // fromCache: 是否来自缓存
+		map[string]interface{}{ // params: 参数说明
+			"type": zhihuTypeMap,
+		},
+		data,      // data: 热榜数据
+		fromCache, // fromCache: 是否来自缓存
 	)
 
 	return c.JSON(resp)
@@ -70,7 +99,7 @@ func (h *ZhihuHandler) fetchZhihuHot(ctx context.Context) ([]models.HotData, err
 		"X-Requested-With": "XMLHttpRequest",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求知乎 API 失败: %w", err)
 	}
@@ -126,6 +155,97 @@ func (h *ZhihuHandler) transformData(items []ZhihuItem) []models.HotData {
 	return result
 }
 
+// fetchZhihuList 从网页版热榜接口获取视频、专栏等分类热榜
+// 网页版接口需要带 Referer 与 x-api-version,否则会返回 403
+func (h *ZhihuHandler) fetchZhihuList(ctx context.Context, list string) ([]models.HotData, error) {
+	apiURL := fmt.Sprintf("https://www.zhihu.com/api/v3/feed/topstory/hot-lists/%s?limit=50&desktop=true", list)
+
+	httpClient := h.fetcher.GetHTTPClient()
+	headers := map[string]string{
+		"User-Agent":       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		"Accept":           "application/json, text/plain, */*",
+		"Accept-Language":  "zh-CN,zh;q=0.9,en;q=0.8",
+		"Referer":          fmt.Sprintf("https://www.zhihu.com/hot?list=%s", list),
+		"x-api-version":    "3.0.76",
+		"x-requested-with": "fetch",
+	}
+
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
+	if err != nil {
+		return nil, fmt.Errorf("请求知乎分类热榜失败: %w", err)
+	}
+
+	var apiResp ZhihuListResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析知乎分类热榜失败: %w", err)
+	}
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("知乎分类热榜 %s %w", list, service.ErrEmptyData)
+	}
+
+	return h.transformList(apiResp.Data), nil
+}
+
+// transformList 将分类热榜数据转换为统一格式,热度取赞同数
+func (h *ZhihuHandler) transformList(items []ZhihuListItem) []models.HotData {
+	result := make([]models.HotData, 0, len(items))
+
+	for _, item := range items {
+		target := item.Target
+		id := strings.Trim(string(target.ID), `"`)
+		if id == "" || target.Title == "" {
+			continue
+		}
+
+		// 封面优先取条目缩略图,视频取视频封面
+		cover := target.Thumbnail
+		if cover == "" && len(item.Children) > 0 {
+			cover = item.Children[0].Thumbnail
+		}
+
+		// 发布时间: 视频为 published_at,文章为 created
+		published := target.PublishedAt
+		if published == 0 {
+			published = target.Created
+		}
+
+		link := h.webURL(target.Type, id, target.URL)
+		result = append(result, models.HotData{
+			ID:        id,
+			Title:     target.Title,
+			Desc:      target.Excerpt,
+			Cover:     cover,
+			Author:    target.Author.Name,
+			Hot:       target.VoteupCount,
+			Timestamp: published * 1000, // 时间戳转换为毫秒级
+			URL:       link,
+			MobileURL: link,
+			Extra: map[string]interface{}{
+				"voteup":   target.VoteupCount,
+				"comments": target.CommentCount,
+				"plays":    target.PlayCount,
+				"heat":     h.parseHot(item.DetailText),
+			},
+		})
+	}
+
+	return result
+}
+
+// webURL 将接口返回的 API 地址转换为网页地址
+// 视频: https://www.zhihu.com/zvideo/<id>,文章: https://zhuanlan.zhihu.com/p/<id>
+func (h *ZhihuHandler) webURL(targetType, id, apiURL string) string {
+	switch targetType {
+	case "zvideo":
+		return fmt.Sprintf("https://www.zhihu.com/zvideo/%s", id)
+	case "article":
+		return fmt.Sprintf("https://zhuanlan.zhihu.com/p/%s", id)
+	}
+
+	link := strings.ReplaceAll(apiURL, "api.", "www.")
+	return strings.ReplaceAll(link, "questions", "question")
+}
+
 // parseHot 解析热度文本
 // 例如: "100 万热度" -> 1000000
 func (h *ZhihuHandler) parseHot(detailText string) int64 {
@@ -184,3 +304,37 @@ type ZhihuTarget struct {
 type ZhihuChild struct {
 	Thumbnail string `json:"thumbnail"` // 缩略图
 }
+
+// ZhihuListResponse 网页版分类热榜响应
+type ZhihuListResponse struct {
+	Data []ZhihuListItem `json:"data"`
+}
+
+// ZhihuListItem 分类热榜条目
+type ZhihuListItem struct {
+	Target     ZhihuListTarget `json:"target"`      // 视频或文章
+	DetailText string          `json:"detail_text"` // 热度文本
+	Children   []ZhihuChild    `json:"children"`    // 子内容(包含封面图)
+}
+
+// ZhihuListTarget 分类热榜中的视频或文章
+// ID 在不同类型中可能是数字或字符串,保留原始值
+type ZhihuListTarget struct {
+	ID           json.RawMessage `json:"id"`
+	Type         string          `json:"type"` // zvideo / article / question
+	Title        string          `json:"title"`
+	Excerpt      string          `json:"excerpt"`
+	URL          string          `json:"url"`
+	Thumbnail    string          `json:"thumbnail"`
+	Author       ZhihuAuthor     `json:"author"`
+	VoteupCount  int64           `json:"voteup_count"`  // 赞同数
+	CommentCount int64           `json:"comment_count"` // 评论数
+	PlayCount    int64           `json:"play_count"`    // 播放数(视频)
+	Created      int64           `json:"created"`
+	PublishedAt  int64           `json:"published_at"`
+}
+
+// ZhihuAuthor 作者信息
+type ZhihuAuthor struct {
+	Name string `json:"name"`
+}
@@ -33,9 +33,12 @@ func (h *ZhihuDailyHandler) Handle(c *fiber.Ctx) error {
 	noCache := c.Query("cache") == "false"
 
 	// 直接调用fetch函数获取数据
-	data, err := h.fetchZhihuDaily(c.Context())
+	cacheKey := "zhihu-daily"
+	data, fromCache, err := h.fetcher.Fetch(c.Context(), cacheKey, service.DefaultCacheDuration, noCache, func(ctx context.Context) ([]models.HotData, error) {
+		return h.fetchZhihuDaily(ctx)
+	})
 	if err != nil {
-		return c.Status(500).JSON(models.ErrorResponseObj(500, err.Error()))
+		return fetchError(c, err)
 	}
 
 	// 构建响应
@@ -47,7 +50,7 @@ func (h *ZhihuDailyHandler) Handle(c *fiber.Ctx) error {
 		"https://daily.zhihu.com",
 		nil,
 		data,
-		!noCache,
+		fromCache,
 	)
 
 	return c.JSON(resp)
@@ -64,7 +67,7 @@ func (h *ZhihuDailyHandler) fetchZhihuDaily(ctx context.Context) ([]models.HotDa
 		"Host":    "daily.zhihu.com",
 	}
 
-	body, err := httpClient.Get(apiURL, headers)
+	body, err := httpClient.GetContext(ctx, apiURL, headers)
 	if err != nil {
 		return nil, fmt.Errorf("请求知乎日报 API 失败: %w", err)
 	}
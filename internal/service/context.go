@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dailyhot/api/internal/cache"
+)
+
+// contextKey 上下文键类型,避免与其他包的键冲突
+type contextKey string
+
+// PlatformContextKey 请求上下文中保存平台路由名的键
+// 路由层在调用处理器前写入(如 "bilibili"),service 层据此读取平台级配置
+const PlatformContextKey contextKey = "platform"
+
+// CacheOnlyContextKey 请求上下文中保存只读缓存模式状态的键,值为 *CacheOnly
+// 路由层在请求带 ?cache=only 时写入
+const CacheOnlyContextKey contextKey = "cache_only"
+
+// CacheRemainingContextKey 请求上下文中保存缓存剩余时间记录的键,值为 *CacheRemaining
+// 路由层在请求带 ?ttl=true 时写入
+const CacheRemainingContextKey contextKey = "cache_remaining"
+
+// CacheHitContextKey 请求上下文中保存是否命中缓存的键,值为 bool
+// 路由层在平台处理器返回后写入,供访问日志使用
+const CacheHitContextKey contextKey = "cache_hit"
+
+// SnapshotContextKey 请求上下文中标记抓取结果可以保存快照的键,值为 bool
+// 路由层只在请求平台的规范榜单(未带影响数据的参数)时写入,避免任意参数组合写满快照
+const SnapshotContextKey contextKey = "snapshot"
+
+// FetchTraceContextKey 请求上下文中记录本次请求抓取过的缓存键的键,值为 *FetchTrace
+// 路由层在调用处理器前写入,处理器 panic 时据此查找陈旧数据降级
+const FetchTraceContextKey contextKey = "fetch_trace"
+
+// WriteBatchContextKey 请求上下文中收集缓存写入的键,值为 *WriteBatch
+// 缓存预热等一次抓取多个平台的内部任务写入,抓取结果最后通过 Fetcher.FlushWrites 一次写入缓存
+const WriteBatchContextKey contextKey = "write_batch"
+
+// PlatformFromContext 从上下文中获取平台路由名,未设置时返回空串
+func PlatformFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	platform, _ := ctx.Value(PlatformContextKey).(string)
+	return platform
+}
+
+// snapshotFromContext 抓取结果是否可以保存快照
+func snapshotFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	snapshot, _ := ctx.Value(SnapshotContextKey).(bool)
+	return snapshot
+}
+
+// CacheOnly 只读缓存模式(?cache=only)的请求状态
+// 该模式下 Fetch 只读缓存、绝不回源,未命中时记录到 Missed 供路由层生成响应
+type CacheOnly struct {
+	missed atomic.Bool
+}
+
+// Missed 是否有缓存未命中
+func (c *CacheOnly) Missed() bool {
+	return c.missed.Load()
+}
+
+// cacheOnlyFromContext 从上下文中获取只读缓存模式状态,未启用时返回 nil
+func cacheOnlyFromContext(ctx context.Context) *CacheOnly {
+	if ctx == nil {
+		return nil
+	}
+	cacheOnly, _ := ctx.Value(CacheOnlyContextKey).(*CacheOnly)
+	return cacheOnly
+}
+
+// CacheRemaining 缓存剩余时间(?ttl=true)的请求状态
+// Fetch 每返回一份数据就记录该数据在缓存中的剩余时间;聚合接口读取多个缓存键时保留最小值,即最早过期的那份
+type CacheRemaining struct {
+	mu        sync.Mutex
+	remaining time.Duration
+	recorded  bool
+}
+
+// record 记录一份数据的剩余时间,负数(未设置过期时间)不参与比较
+func (c *CacheRemaining) record(remaining time.Duration) {
+	if remaining < 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.recorded || remaining < c.remaining {
+		c.remaining = remaining
+		c.recorded = true
+	}
+}
+
+// Remaining 返回记录到的最短剩余时间,没有任何记录时 ok 为 false
+func (c *CacheRemaining) Remaining() (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remaining, c.recorded
+}
+
+// cacheRemainingFromContext 从上下文中获取缓存剩余时间记录,未启用时返回 nil
+func cacheRemainingFromContext(ctx context.Context) *CacheRemaining {
+	if ctx == nil {
+		return nil
+	}
+	remaining, _ := ctx.Value(CacheRemainingContextKey).(*CacheRemaining)
+	return remaining
+}
+
+// FetchTrace 请求中调用 Fetch 的缓存键记录
+type FetchTrace struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+// record 记录一次抓取的缓存键(带版本号),重复的键只记录一次
+func (t *FetchTrace) record(storeKey string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range t.keys {
+		if key == storeKey {
+			return
+		}
+	}
+	t.keys = append(t.keys, storeKey)
+}
+
+// CacheKeys 返回记录的缓存键(不带版本号前缀)
+func (t *FetchTrace) CacheKeys() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	keys := make([]string, len(t.keys))
+	for i, key := range t.keys {
+		keys[i] = strings.TrimPrefix(key, CacheVersion+":")
+	}
+	return keys
+}
+
+// fetchTraceFromContext 从上下文中获取抓取记录,未设置时返回 nil
+func fetchTraceFromContext(ctx context.Context) *FetchTrace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Value(FetchTraceContextKey).(*FetchTrace)
+	return trace
+}
+
+// WriteBatch 收集多次抓取的缓存写入,由 Fetcher.FlushWrites 通过一次 MSet 写入(L2 只需一次往返)
+// 写入前其他请求读不到这些数据;刷新之后到达的写入(如超时后仍在后台完成的抓取)直接写入缓存
+type WriteBatch struct {
+	mu      sync.Mutex
+	items   []cache.Item
+	flushed bool
+}
+
+// add 加入一条写入,已刷新时返回 false
+func (b *WriteBatch) add(item cache.Item) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.flushed {
+		return false
+	}
+	b.items = append(b.items, item)
+	return true
+}
+
+// take 取出收集的写入并标记为已刷新
+func (b *WriteBatch) take() []cache.Item {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	items := b.items
+	b.items = nil
+	b.flushed = true
+	return items
+}
+
+// writeBatchFromContext 从上下文中获取写入批次,未设置时返回 nil
+func writeBatchFromContext(ctx context.Context) *WriteBatch {
+	if ctx == nil {
+		return nil
+	}
+	batch, _ := ctx.Value(WriteBatchContextKey).(*WriteBatch)
+	return batch
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	httpclient "github.com/dailyhot/api/internal/http"
+	"github.com/dailyhot/api/internal/models"
+)
+
+// ErrEmptyData 上游返回的数据为空
+// 处理器可用 fmt.Errorf("微博%w", ErrEmptyData) 包装,便于错误分类
+var ErrEmptyData = errors.New("数据为空")
+
+// ClassifyError 将抓取错误归类为 models.ErrorType* 之一
+// 依赖错误链(errors.Is / errors.As),处理器需要用 %w 包装底层错误;无法识别的错误归为上游错误
+func ClassifyError(err error) string {
+	var (
+		statusErr *httpclient.StatusError
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		netErr    net.Error
+	)
+
+	switch {
+	case errors.Is(err, ErrFetchTimeout), errors.Is(err, context.DeadlineExceeded):
+		return models.ErrorTypeTimeout
+	case errors.Is(err, context.Canceled):
+		return models.ErrorTypeCanceled
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return models.ErrorTypeTimeout
+	case errors.Is(err, httpclient.ErrHostThrottled):
+		return models.ErrorTypeRateLimited
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests:
+		return models.ErrorTypeRateLimited
+	case errors.Is(err, ErrEmptyData):
+		return models.ErrorTypeEmptyData
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return models.ErrorTypeParse
+	default:
+		return models.ErrorTypeUpstream
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	httpclient "github.com/dailyhot/api/internal/http"
+	"github.com/dailyhot/api/internal/models"
+)
+
+func TestClassifyErrorStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusTooManyRequests, models.ErrorTypeRateLimited},
+		{http.StatusForbidden, models.ErrorTypeUpstream},
+		{http.StatusInternalServerError, models.ErrorTypeUpstream},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("请求失败: %w", &httpclient.StatusError{StatusCode: tt.status})
+		if got := ClassifyError(err); got != tt.want {
+			t.Errorf("ClassifyError(%d) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyErrorThrottled(t *testing.T) {
+	err := fmt.Errorf("请求失败: %w", httpclient.ErrHostThrottled)
+	if got := ClassifyError(err); got != models.ErrorTypeRateLimited {
+		t.Errorf("ClassifyError(ErrHostThrottled) = %q, want %q", got, models.ErrorTypeRateLimited)
+	}
+}
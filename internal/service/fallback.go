@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"go.uber.org/zap"
+)
+
+// SourceParser 备用源解析函数,将备用源的响应体解析为热榜数据
+type SourceParser func(body []byte) ([]models.HotData, error)
+
+// SourceStrategy 平台的数据源策略
+// 处理器通过它声明每个备用源对应的解析函数: 主源失败或返回空数据时,
+// FetchWithFallback 依次请求 platforms.<name>.fallback_urls 中的地址,并交给对应的解析函数
+type SourceStrategy interface {
+	// SourceParser 返回解析 rawURL 响应的函数,返回 nil 表示不支持该地址
+	SourceParser(rawURL string) SourceParser
+}
+
+// SourceParsers 按域名声明解析函数的数据源策略
+// 键为备用源域名(如 "hn.algolia.com"),"*" 匹配其余所有地址
+type SourceParsers map[string]SourceParser
+
+// SourceParser 实现 SourceStrategy: 先按域名精确匹配,再回退到 "*"
+func (p SourceParsers) SourceParser(rawURL string) SourceParser {
+	if u, err := url.Parse(rawURL); err == nil {
+		if parse, ok := p[u.Hostname()]; ok {
+			return parse
+		}
+	}
+	return p["*"]
+}
+
+// fallbackHeaders 请求备用源时使用的请求头
+var fallbackHeaders = map[string]string{
+	"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+	"Accept":     "application/json, application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8",
+}
+
+// FetchWithFallback 获取热榜数据列表(带缓存与备用源)
+// 与 Fetch 相同,但主源失败或返回空数据时依次尝试平台配置的备用源(platforms.<name>.fallback_urls),
+// 第一个成功且非空的备用源结果会像主源结果一样写入缓存;全部失败时返回主源的结果与错误。
+// 平台取自 ctx(PlatformFromContext),未配置备用源或 strategy 为 nil 时等同于 Fetch
+func (f *Fetcher) FetchWithFallback(
+	ctx context.Context,
+	cacheKey string,
+	cacheDuration time.Duration,
+	noCache bool,
+	strategy SourceStrategy,
+	fetchFunc FetchFunc,
+) ([]models.HotData, bool, error) {
+	return f.Fetch(ctx, cacheKey, cacheDuration, noCache, f.withFallback(PlatformFromContext(ctx), strategy, fetchFunc))
+}
+
+// withFallback 为抓取函数包装备用源降级逻辑
+func (f *Fetcher) withFallback(platform string, strategy SourceStrategy, fetchFunc FetchFunc) FetchFunc {
+	fallbackURLs := f.cfg.Platform(platform).FallbackURLs
+	if strategy == nil || len(fallbackURLs) == 0 {
+		return fetchFunc
+	}
+
+	return func(ctx context.Context) ([]models.HotData, error) {
+		data, err := fetchFunc(ctx)
+		if err == nil && len(data) > 0 {
+			return data, nil
+		}
+
+		for _, rawURL := range fallbackURLs {
+			if ctx.Err() != nil {
+				break
+			}
+
+			parse := strategy.SourceParser(rawURL)
+			if parse == nil {
+				logger.Warn("备用源没有对应的解析函数,跳过",
+					zap.String("platform", platform),
+					zap.String("url", rawURL),
+				)
+				continue
+			}
+
+			fallbackData, fallbackErr := f.fetchSource(ctx, rawURL, parse)
+			if fallbackErr != nil {
+				logger.Warn("备用源抓取失败",
+					zap.String("platform", platform),
+					zap.String("url", rawURL),
+					zap.Error(fallbackErr),
+				)
+				continue
+			}
+
+			logger.Warn("主源不可用,已降级到备用源",
+				zap.String("platform", platform),
+				zap.String("url", rawURL),
+				zap.NamedError("primary_error", err),
+				zap.Int("count", len(fallbackData)),
+			)
+			return fallbackData, nil
+		}
+
+		return data, err
+	}
+}
+
+// fetchSource 请求并解析单个备用源,结果为空时返回 ErrEmptyData
+func (f *Fetcher) fetchSource(ctx context.Context, rawURL string, parse SourceParser) ([]models.HotData, error) {
+	body, err := f.httpClient.GetContext(ctx, rawURL, fallbackHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("请求备用源失败: %w", err)
+	}
+
+	data, err := parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("解析备用源失败: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+	return data, nil
+}
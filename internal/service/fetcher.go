@@ -3,10 +3,16 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"runtime/debug"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dailyhot/api/internal/cache"
+	"github.com/dailyhot/api/internal/config"
 	"github.com/dailyhot/api/internal/http"
 	"github.com/dailyhot/api/internal/logger"
 	"github.com/dailyhot/api/internal/models"
@@ -14,20 +20,91 @@ import (
 	"go.uber.org/zap"
 )
 
+// CacheVersion 缓存数据结构版本号,作为所有缓存键的前缀,如 "v2:bilibili_hot_0"
+//
+// 版本变更流程: 修改 HotData 等缓存内容的结构(增删字段、调整字段类型)后,
+// 将版本号加一即可。新版本读写的都是新前缀的键,旧缓存不再被读取,
+// 在 L1 中随过期时间淘汰,在 L2(Redis) 中随 TTL 过期,无需手动清理
+const CacheVersion = "v2"
+
+// versionedKey 为缓存键加上版本号前缀
+func versionedKey(cacheKey string) string {
+	return CacheVersion + ":" + cacheKey
+}
+
+// DefaultCacheDuration 使用配置中的默认缓存时长(cache.default_expire)
+const DefaultCacheDuration time.Duration = 0
+
+// ErrFetchTimeout 抓取超时错误
+// 超时且没有可用的陈旧数据时返回,可用 errors.Is 判断;
+// 错误链中同时包含 context.DeadlineExceeded,上层可按 context 错误统一处理
+var ErrFetchTimeout = errors.New("抓取超时")
+
+// fetchTimeoutError 带超时时长的抓取超时错误
+type fetchTimeoutError struct {
+	timeout time.Duration
+}
+
+// Error 实现 error 接口,如 "抓取超时(15s)"
+func (e *fetchTimeoutError) Error() string {
+	return fmt.Sprintf("%s(%s)", ErrFetchTimeout, e.timeout)
+}
+
+// Unwrap 使 errors.Is 同时匹配 ErrFetchTimeout 与 context.DeadlineExceeded
+func (e *fetchTimeoutError) Unwrap() []error {
+	return []error{ErrFetchTimeout, context.DeadlineExceeded}
+}
+
+// ErrCacheOnlyMiss 只读缓存模式(?cache=only)下缓存未命中
+var ErrCacheOnlyMiss = errors.New("缓存未命中(cache=only)")
+
 // Fetcher 数据获取服务
 // 负责协调缓存和 HTTP 请求,提供统一的数据获取接口
 type Fetcher struct {
-	cache      *cache.Manager   // 缓存管理器
-	httpClient *http.Client     // HTTP 客户端
-	objectPool *pool.ObjectPool // 对象池管理器(用于内存优化)
+	cfg        *config.Config            // 应用配置
+	cache      *cache.Manager            // 缓存管理器
+	httpClient *http.Client              // HTTP 客户端
+	objectPool *pool.ObjectPool          // 对象池管理器(用于内存优化)
+	stats      *platformStats            // 平台抓取统计
+	upstreams  *upstreamStats            // 上游请求统计
+	stale      *staleStore               // 陈旧数据存储(抓取超时时兜底)
+	snapshots  *SnapshotStore            // 抓取快照存储,未开启时为 nil
+	filters    map[string]platformFilter // 配置的条目过滤规则
+	intervals  *fetchIntervals           // 各平台最近一次回源时间(min_interval 限流)
+	inflight   sync.WaitGroup            // 在途抓取协程,优雅关闭时等待其写完缓存
 }
 
 // NewFetcher 创建数据获取服务
-func NewFetcher(cacheManager *cache.Manager) *Fetcher {
+func NewFetcher(cacheManager *cache.Manager, cfg *config.Config) *Fetcher {
+	var snapshots *SnapshotStore
+	if cfg.Snapshot.Enabled {
+		snapshots = NewSnapshotStore(cfg.Snapshot.Dir, cfg.Snapshot.RetentionDays, cfg.Snapshot.FlushInterval)
+	}
+
+	transport := cfg.HTTP.Transport
+	httpClient := http.GetDefaultClient().SetTransportOptions(http.TransportOptions{
+		MaxIdleConns:        transport.MaxIdleConns,
+		MaxIdleConnsPerHost: transport.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     transport.MaxConnsPerHost,
+		IdleConnTimeout:     transport.IdleConnTimeout,
+		TLSHandshakeTimeout: transport.TLSHandshakeTimeout,
+		ForceAttemptHTTP2:   transport.ForceAttemptHTTP2,
+	})
+	if len(cfg.HTTP.ThrottleStatuses) > 0 {
+		httpClient.SetThrottleStatuses(cfg.HTTP.ThrottleStatuses)
+	}
+
 	return &Fetcher{
+		cfg:        cfg,
 		cache:      cacheManager,
-		httpClient: http.GetDefaultClient(),
+		httpClient: httpClient,
 		objectPool: pool.NewObjectPool(), // 初始化对象池
+		stats:      newPlatformStats(),
+		upstreams:  newUpstreamStats(),
+		stale:      newStaleStore(cfg.Cache.StaleExpire, cfg.Cache.StaleMaxEntries),
+		snapshots:  snapshots,
+		filters:    compileFilters(cfg.Platforms),
+		intervals:  newFetchIntervals(),
 	}
 }
 
@@ -60,54 +137,400 @@ func (f *Fetcher) GetData(
 	cacheDuration time.Duration,
 	fetchFunc FetchFunc,
 ) (*models.Response, error) {
-	// 1. 尝试从缓存获取
-	cachedData, err := f.cache.Get(ctx, cacheKey)
-	if err == nil {
-		// 缓存命中,反序列化数据
-		var hotDataList []models.HotData
-		if err := json.Unmarshal(cachedData, &hotDataList); err == nil {
-			logger.Info("缓存命中",
-				zap.String("platform", platformName),
-				zap.String("cache_key", cacheKey),
-				zap.Int("count", len(hotDataList)),
-			)
-			// 使用 SimpleSuccessResponse 保持向后兼容
-			return models.SimpleSuccessResponse(platformName, subtitle, hotDataList, true), nil
+	hotDataList, fromCache, err := f.Fetch(ctx, cacheKey, cacheDuration, false, fetchFunc)
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 数据失败: %w", platformName, err)
+	}
+
+	// 使用 SimpleSuccessResponse 保持向后兼容
+	return models.SimpleSuccessResponse(platformName, subtitle, hotDataList, fromCache), nil
+}
+
+// Fetch 获取热榜数据列表(带缓存)
+// 与 GetData 的缓存逻辑相同,但只返回数据列表,响应由处理器自行组装
+//
+// 参数:
+//   - ctx: 上下文
+//   - cacheKey: 缓存键,需包含影响结果的参数,如 "weatheralarm_广东"(版本号前缀会自动添加)
+//   - cacheDuration: 缓存时长
+//   - noCache: 为 true 时跳过缓存读取,强制从源获取(结果仍会写入缓存)
+//   - fetchFunc: 数据获取函数
+//
+// ctx 中带有 CacheOnly 时(?cache=only)只读缓存,未命中时不回源,返回 ErrCacheOnlyMiss
+//
+// 返回:
+//   - 热榜数据列表
+//   - 是否来自缓存
+//   - 错误信息
+func (f *Fetcher) Fetch(
+	ctx context.Context,
+	cacheKey string,
+	cacheDuration time.Duration,
+	noCache bool,
+	fetchFunc FetchFunc,
+) ([]models.HotData, bool, error) {
+	return f.fetch(ctx, cacheKey, cacheDuration, noCache, fetchFunc, true)
+}
+
+// FetchTransient 与 Fetch 相同,但抓取结果只写入缓存,不保存陈旧数据与快照
+// 用于缓存键由用户输入决定(如任意网页地址)的接口,避免陈旧数据存储被大量一次性的键占满
+func (f *Fetcher) FetchTransient(
+	ctx context.Context,
+	cacheKey string,
+	cacheDuration time.Duration,
+	noCache bool,
+	fetchFunc FetchFunc,
+) ([]models.HotData, bool, error) {
+	return f.fetch(ctx, cacheKey, cacheDuration, noCache, fetchFunc, false)
+}
+
+// fetch Fetch 与 FetchTransient 的实现,keep 为 false 时不保存陈旧数据与快照
+// 快照还要求上下文带有 SnapshotContextKey 标记(平台的规范榜单)
+func (f *Fetcher) fetch(
+	ctx context.Context,
+	cacheKey string,
+	cacheDuration time.Duration,
+	noCache bool,
+	fetchFunc FetchFunc,
+	keep bool,
+) ([]models.HotData, bool, error) {
+	storeKey := versionedKey(cacheKey)
+	cacheOnly := cacheOnlyFromContext(ctx)
+	if keep {
+		if trace := fetchTraceFromContext(ctx); trace != nil {
+			trace.record(storeKey)
+		}
+	}
+
+	// 1. 尝试从缓存获取(只读缓存模式忽略 noCache)
+	if !noCache || cacheOnly != nil {
+		cachedData, err := f.cache.Get(ctx, storeKey)
+		if err == nil {
+			// 缓存命中,反序列化数据
+			var hotDataList []models.HotData
+			if err := json.Unmarshal(cachedData, &hotDataList); err == nil {
+				logger.Info("缓存命中",
+					zap.String("cache_key", cacheKey),
+					zap.Int("count", len(hotDataList)),
+				)
+				f.recordRemaining(ctx, storeKey)
+				return hotDataList, true, nil
+			}
+			logger.Warn("缓存数据反序列化失败", zap.Error(err))
+		} else if !errors.Is(err, cache.ErrCacheMiss) {
+			// 缓存后端故障(如 Redis 不可用): 优先返回内存中的陈旧数据,避免故障期间每个请求都回源
+			if staleData, ok := f.stale.get(storeKey); ok {
+				logger.Warn("缓存后端故障,返回陈旧数据",
+					zap.String("cache_key", cacheKey),
+					zap.Error(err),
+				)
+				f.recordStale(ctx)
+				return staleData, true, nil
+			}
 		}
-		logger.Warn("缓存数据反序列化失败", zap.Error(err))
 	}
 
-	// 2. 缓存未命中,调用 fetchFunc 获取原始数据
+	// 只读缓存模式: 未命中时不回源
+	if cacheOnly != nil {
+		cacheOnly.missed.Store(true)
+		logger.Info("缓存未命中,只读缓存模式不回源",
+			zap.String("cache_key", cacheKey),
+		)
+		return nil, false, ErrCacheOnlyMiss
+	}
+
+	// 距上次回源未达平台的最小间隔时返回陈旧数据,保护频率限制严格的上游(?cache=false 同样受限)
+	platform := PlatformFromContext(ctx)
+	if staleData, ok := f.throttledStale(platform, storeKey); ok {
+		logger.Info("未达最小回源间隔,返回陈旧数据",
+			zap.String("cache_key", cacheKey),
+			zap.Duration("min_interval", f.cfg.Platform(platform).MinInterval),
+		)
+		f.recordStale(ctx)
+		return staleData, true, nil
+	}
+
+	// 2. 缓存未命中,调用 fetchFunc 获取原始数据(带超时)
 	logger.Info("缓存未命中,从源获取数据",
-		zap.String("platform", platformName),
 		zap.String("cache_key", cacheKey),
 	)
 
-	hotDataList, err := fetchFunc(ctx)
+	timeout := f.fetchTimeout(platform)
+	snapshot := keep && snapshotFromContext(ctx)
+	batch := writeBatchFromContext(ctx)
+	hotDataList, err := f.fetchWithTimeout(platform, cacheKey, cacheDuration, timeout, fetchFunc, keep, snapshot, batch)
 	if err != nil {
+		// 超时、上游失败或解析 panic 时优先返回陈旧数据,避免单个上游故障导致整体失败
+		if staleData, ok := f.stale.get(storeKey); ok {
+			logger.Warn("抓取失败,返回陈旧数据",
+				zap.String("cache_key", cacheKey),
+				zap.Duration("timeout", timeout),
+				zap.Error(err),
+			)
+			f.recordStale(ctx)
+			return staleData, true, nil
+		}
+
 		logger.Error("获取数据失败",
-			zap.String("platform", platformName),
+			zap.String("cache_key", cacheKey),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("获取 %s 数据失败: %w", platformName, err)
+		return nil, false, err
+	}
+
+	// 3. 返回数据(缓存已在抓取协程中写入)
+	if remaining := cacheRemainingFromContext(ctx); remaining != nil {
+		if cacheDuration == DefaultCacheDuration {
+			cacheDuration = f.cfg.Cache.DefaultExpire
+		}
+		remaining.record(cacheDuration)
+	}
+	return hotDataList, false, nil
+}
+
+// recordRemaining 请求需要缓存剩余时间(?ttl=true)时,查询并记录缓存键的剩余时间
+// 剩余时间未知(如 L1 中重启前写入的条目)时不记录
+func (f *Fetcher) recordRemaining(ctx context.Context, storeKey string) {
+	remaining := cacheRemainingFromContext(ctx)
+	if remaining == nil {
+		return
 	}
+	if ttl, err := f.cache.TTL(ctx, storeKey); err == nil {
+		remaining.record(ttl)
+	}
+}
+
+// recordStale 返回陈旧数据时记录剩余时间为 0: 数据已不在缓存中
+func (f *Fetcher) recordStale(ctx context.Context) {
+	if remaining := cacheRemainingFromContext(ctx); remaining != nil {
+		remaining.record(0)
+	}
+}
+
+// StaleFallback 处理器 panic 时查找本次请求可用的陈旧数据
+// 只有请求恰好抓取过一个榜单时才返回,多个榜单(如组合接口)无法确定该返回哪一个
+func (f *Fetcher) StaleFallback(ctx context.Context) ([]models.HotData, bool) {
+	trace := fetchTraceFromContext(ctx)
+	if trace == nil {
+		return nil, false
+	}
+	trace.mu.Lock()
+	keys := trace.keys
+	trace.mu.Unlock()
+	if len(keys) != 1 {
+		return nil, false
+	}
+	data, ok := f.stale.get(keys[0])
+	if ok {
+		f.recordStale(ctx)
+	}
+	return data, ok
+}
 
-	// 3. 将数据写入缓存
-	if len(hotDataList) > 0 {
-		dataBytes, err := json.Marshal(hotDataList)
+// CachedMany 批量读取多个缓存键的数据
+// 聚合接口先用它一次性读取所有缓存(L2 只需一次往返),只对未命中的键调用 Fetch 回源
+// 返回命中且能解析的数据: 缓存键(不带版本号前缀) -> 数据;缓存后端故障时返回已读到的部分
+func (f *Fetcher) CachedMany(ctx context.Context, cacheKeys []string) map[string][]models.HotData {
+	storeKeys := make([]string, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		storeKeys[i] = versionedKey(cacheKey)
+	}
+
+	cached, err := f.cache.MGet(ctx, storeKeys)
+	if err != nil {
+		logger.Warn("批量读取缓存失败", zap.Strings("cache_keys", cacheKeys), zap.Error(err))
+	}
+
+	result := make(map[string][]models.HotData, len(cached))
+	for i, cacheKey := range cacheKeys {
+		data, ok := cached[storeKeys[i]]
+		if !ok {
+			continue
+		}
+		var hotDataList []models.HotData
+		if err := json.Unmarshal(data, &hotDataList); err != nil {
+			logger.Warn("缓存数据反序列化失败", zap.String("cache_key", cacheKey), zap.Error(err))
+			continue
+		}
+		result[cacheKey] = hotDataList
+		f.recordRemaining(ctx, storeKeys[i])
+	}
+	return result
+}
+
+// FlushWrites 将批次中收集的缓存写入通过一次 MSet 写入缓存
+// 之后到达的写入不再进入批次,直接写入缓存
+func (f *Fetcher) FlushWrites(ctx context.Context, batch *WriteBatch) error {
+	items := batch.take()
+	if len(items) == 0 {
+		return nil
+	}
+	if err := f.cache.MSet(ctx, items); err != nil {
+		return fmt.Errorf("批量写入缓存失败: %w", err)
+	}
+	logger.Info("批量写入缓存", zap.Int("keys", len(items)))
+	return nil
+}
+
+// fetchResult 抓取协程的结果
+type fetchResult struct {
+	data []models.HotData
+	err  error
+}
+
+// fetchWithTimeout 在独立协程中执行抓取,超过 timeout 时返回 ErrFetchTimeout
+// 抓取函数收到的 ctx 会在超时后取消,处理器通过 GetContext、Fetcher.Get 等发起的上游请求随之中止;
+// 不响应 ctx 的抓取会继续执行,完成后结果仍会写入缓存,供后续请求直接使用;keep 为 false 时不保存陈旧数据,snapshot 为 false 时不保存快照;
+// batch 不为 nil 时缓存写入加入该批次
+func (f *Fetcher) fetchWithTimeout(
+	platform string,
+	cacheKey string,
+	cacheDuration time.Duration,
+	timeout time.Duration,
+	fetchFunc FetchFunc,
+	keep bool,
+	snapshot bool,
+	batch *WriteBatch,
+) ([]models.HotData, error) {
+	storeKey := versionedKey(cacheKey)
+
+	// 抓取协程可能比请求活得更久,不能继承请求上下文
+	fetchCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan fetchResult, 1)
+	f.inflight.Add(1)
+	go func() {
+		defer f.inflight.Done()
+
+		// 协程中的 panic 无法被路由层捕获,这里转换为错误
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.Error("抓取协程发生 panic",
+					zap.String("cache_key", storeKey),
+					zap.Any("panic", rec),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				done <- fetchResult{err: fmt.Errorf("数据处理异常: %v", rec)}
+			}
+		}()
+
+		data, err := fetchFunc(fetchCtx)
 		if err == nil {
-			_ = f.cache.Set(ctx, cacheKey, dataBytes, cacheDuration)
-			logger.Info("数据已缓存",
-				zap.String("platform", platformName),
-				zap.String("cache_key", cacheKey),
-				zap.Int("count", len(hotDataList)),
-			)
+			f.store(batch, storeKey, data, cacheDuration, keep)
+			if snapshot {
+				f.saveSnapshot(platform, cacheKey, data)
+			}
 		}
+		done <- fetchResult{data: data, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.data, result.err
+	case <-fetchCtx.Done():
+		return nil, &fetchTimeoutError{timeout: timeout}
 	}
+}
 
-	// 4. 返回数据
-	// 使用 SimpleSuccessResponse 保持向后兼容
-	return models.SimpleSuccessResponse(platformName, subtitle, hotDataList, false), nil
+// Wait 等待在途抓取完成
+// 包括请求已超时返回、但仍在后台执行的抓取,确保其结果写入缓存后再关闭缓存
+// ctx 到期时不再等待,返回 ctx.Err()
+func (f *Fetcher) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		f.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// store 将抓取结果写入缓存,keep 为 true 时同时写入陈旧数据存储
+// batch 不为 nil 且尚未刷新时只加入批次,由 FlushWrites 统一写入;陈旧数据总是立即写入
+// 只保存规范的 HotData 列表;去重、排序、多语言等展示层变体由路由层在响应时即时处理,不写入缓存
+func (f *Fetcher) store(batch *WriteBatch, storeKey string, hotDataList []models.HotData, cacheDuration time.Duration, keep bool) {
+	if len(hotDataList) == 0 {
+		return
+	}
+
+	dataBytes, err := json.Marshal(hotDataList)
+	if err != nil {
+		return
+	}
+
+	item := cache.Item{Key: storeKey, Value: dataBytes, Expiration: cacheDuration}
+	if batch == nil || !batch.add(item) {
+		_ = f.cache.Set(context.Background(), storeKey, dataBytes, cacheDuration)
+	}
+	if keep {
+		f.stale.set(storeKey, hotDataList)
+	}
+	logger.Info("数据已缓存",
+		zap.String("cache_key", storeKey),
+		zap.Int("count", len(hotDataList)),
+	)
+}
+
+// saveSnapshot 保存抓取快照(未开启快照或平台名未知时跳过)
+func (f *Fetcher) saveSnapshot(platform, cacheKey string, hotDataList []models.HotData) {
+	if f.snapshots == nil || platform == "" || len(hotDataList) == 0 {
+		return
+	}
+
+	if err := f.snapshots.Save(platform, cacheKey, hotDataList, time.Now()); err != nil {
+		logger.Warn("保存抓取快照失败",
+			zap.String("platform", platform),
+			zap.String("cache_key", cacheKey),
+			zap.Error(err),
+		)
+	}
+}
+
+// throttledStale 平台配置了 min_interval 且距上次回源未达间隔时,返回该缓存键的陈旧数据
+// 没有陈旧数据(如首次请求某个榜单)时仍允许回源,避免直接失败
+func (f *Fetcher) throttledStale(platform, storeKey string) ([]models.HotData, bool) {
+	interval := f.cfg.Platform(platform).MinInterval
+	if platform == "" || interval <= 0 {
+		return nil, false
+	}
+	if f.intervals.acquire(platform, interval) {
+		return nil, false
+	}
+	return f.stale.get(storeKey)
+}
+
+// fetchTimeout 获取平台的抓取超时时间
+// 优先使用 platforms.<name>.timeout,未配置时取 HTTP 客户端超时
+func (f *Fetcher) fetchTimeout(platform string) time.Duration {
+	if timeout := f.cfg.Platform(platform).Timeout; timeout > 0 {
+		return timeout
+	}
+	return f.httpClient.Timeout()
+}
+
+// PlatformCookie 获取平台配置的登录态 Cookie(platforms.<name>.cookie),未配置时返回空串
+func (f *Fetcher) PlatformCookie(platform string) string {
+	return strings.TrimSpace(f.cfg.Platform(platform).Cookie)
+}
+
+// PlatformToken 获取平台配置的 API 访问令牌(platforms.<name>.token),未配置时返回空串
+func (f *Fetcher) PlatformToken(platform string) string {
+	return strings.TrimSpace(f.cfg.Platform(platform).Token)
+}
+
+// FieldMapping 获取平台配置的响应字段映射(platforms.<name>.fields),未配置时返回零值
+func (f *Fetcher) FieldMapping(platform string) models.FieldMapping {
+	fields := f.cfg.Platform(platform).Fields
+	return models.FieldMapping{
+		Aliases: fields.Aliases,
+		Rename:  fields.Rename,
+	}
 }
 
 // GetHTTPClient 获取 HTTP 客户端
@@ -116,10 +539,31 @@ func (f *Fetcher) GetHTTPClient() *http.Client {
 	return f.httpClient
 }
 
+// Get 发起 GET 请求并记录上游请求统计
+// 与 http.Client.GetContext 用法一致,ctx 取消(如平台抓取超时)时请求立即中止,
+// 耗时、重试次数等按域名汇总到 /stats 的 upstreams 中
+func (f *Fetcher) Get(ctx context.Context, rawURL string, headers map[string]string) ([]byte, error) {
+	body, metrics, err := f.httpClient.GetWithMetrics(ctx, rawURL, headers)
+
+	host := rawURL
+	if u, parseErr := url.Parse(rawURL); parseErr == nil && u.Host != "" {
+		host = u.Host
+	}
+	f.upstreams.record(host, metrics, err == nil)
+
+	return body, err
+}
+
 // InvalidateCache 使缓存失效
-// 用于手动清除某个平台的缓存
+// 用于手动清除某个平台的缓存,cacheKey 不需要带版本号前缀
 func (f *Fetcher) InvalidateCache(ctx context.Context, cacheKey string) error {
-	return f.cache.Delete(ctx, cacheKey)
+	return f.cache.Delete(ctx, versionedKey(cacheKey))
+}
+
+// CacheTTL 获取缓存键的剩余过期时间,cacheKey 不需要带版本号前缀
+// 错误与返回值含义见 cache.Manager.TTL
+func (f *Fetcher) CacheTTL(ctx context.Context, cacheKey string) (time.Duration, error) {
+	return f.cache.TTL(ctx, versionedKey(cacheKey))
 }
 
 // GetCacheStats 获取缓存统计信息
@@ -127,6 +571,32 @@ func (f *Fetcher) GetCacheStats() map[string]interface{} {
 	return f.cache.GetStats()
 }
 
+// RecordFetch 记录一次平台抓取的耗时与结果
+// 由路由层在每次处理请求后调用
+func (f *Fetcher) RecordFetch(platform string, latency time.Duration, success bool) {
+	f.stats.record(platform, latency, success)
+}
+
+// GetPlatformStats 获取各平台最近的抓取统计
+func (f *Fetcher) GetPlatformStats() []PlatformStat {
+	return f.stats.snapshot()
+}
+
+// GetUpstreamStats 获取各上游域名的请求统计
+func (f *Fetcher) GetUpstreamStats() []UpstreamStat {
+	return f.upstreams.snapshot()
+}
+
+// GetThrottleStats 获取各上游域名的风控退避状态
+func (f *Fetcher) GetThrottleStats() []http.ThrottleStat {
+	return f.httpClient.ThrottleStats()
+}
+
+// Snapshots 获取抓取快照存储,未开启快照时返回 nil
+func (f *Fetcher) Snapshots() *SnapshotStore {
+	return f.snapshots
+}
+
 // GetObjectPool 获取对象池管理器
 // 用于 HTTP 客户端和其他组件使用
 func (f *Fetcher) GetObjectPool() *pool.ObjectPool {
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/internal/cache"
+	"github.com/dailyhot/api/internal/config"
+	"github.com/dailyhot/api/internal/models"
+)
+
+// newTestFetcher 创建只使用 L1 缓存的抓取器
+func newTestFetcher(t *testing.T) *Fetcher {
+	t.Helper()
+	cfg := &config.Config{
+		Cache: config.CacheConfig{
+			Enabled:          true,
+			DefaultExpire:    time.Minute,
+			CleanupInterval:  time.Minute,
+			MaxEntries:       100,
+			MaxEntrySize:     1024,
+			HardMaxCacheSize: 8,
+			StaleExpire:      time.Hour,
+			StaleMaxEntries:  10,
+		},
+	}
+
+	cacheManager, err := cache.NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cacheManager.Close() })
+	return NewFetcher(cacheManager, cfg)
+}
+
+func okFetch(title string) FetchFunc {
+	return func(ctx context.Context) ([]models.HotData, error) {
+		return []models.HotData{{ID: "1", Title: title}}, nil
+	}
+}
+
+func TestFetchFallsBackToStale(t *testing.T) {
+	tests := []struct {
+		name  string
+		fetch FetchFunc
+	}{
+		{"上游失败", func(ctx context.Context) ([]models.HotData, error) {
+			return nil, errors.New("上游返回 502")
+		}},
+		{"解析 panic", func(ctx context.Context) ([]models.HotData, error) {
+			panic("index out of range")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFetcher(t)
+			ctx := context.Background()
+			if _, _, err := f.Fetch(ctx, "test_hot", time.Minute, false, okFetch("旧数据")); err != nil {
+				t.Fatal(err)
+			}
+
+			// 跳过缓存强制回源,回源失败时返回陈旧数据
+			data, fromCache, err := f.Fetch(ctx, "test_hot", time.Minute, true, tt.fetch)
+			if err != nil {
+				t.Fatalf("err = %v, want stale data", err)
+			}
+			if !fromCache || len(data) != 1 || data[0].Title != "旧数据" {
+				t.Errorf("Fetch = %v, %v, want stale data from cache", data, fromCache)
+			}
+		})
+	}
+}
+
+func TestFetchWithoutStaleReturnsError(t *testing.T) {
+	f := newTestFetcher(t)
+	_, _, err := f.Fetch(context.Background(), "test_hot", time.Minute, true, func(ctx context.Context) ([]models.HotData, error) {
+		return nil, errors.New("上游返回 502")
+	})
+	if err == nil {
+		t.Error("err = nil, want upstream error")
+	}
+}
+
+func TestStaleFallback(t *testing.T) {
+	f := newTestFetcher(t)
+	if _, _, err := f.Fetch(context.Background(), "a_hot", time.Minute, false, okFetch("a")); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := f.Fetch(context.Background(), "b_hot", time.Minute, false, okFetch("b")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 请求只抓取过一个榜单: 返回该榜单的陈旧数据
+	trace := &FetchTrace{}
+	ctx := context.WithValue(context.Background(), FetchTraceContextKey, trace)
+	_, _, _ = f.Fetch(ctx, "a_hot", time.Minute, false, okFetch("a"))
+	if data, ok := f.StaleFallback(ctx); !ok || data[0].Title != "a" {
+		t.Errorf("StaleFallback = %v, %v, want a", data, ok)
+	}
+
+	// 抓取过多个榜单时无法确定返回哪一个
+	_, _, _ = f.Fetch(ctx, "b_hot", time.Minute, false, okFetch("b"))
+	if _, ok := f.StaleFallback(ctx); ok {
+		t.Error("StaleFallback ok = true with two keys, want false")
+	}
+
+	// 未记录抓取时没有可用数据
+	if _, ok := f.StaleFallback(context.Background()); ok {
+		t.Error("StaleFallback ok = true without trace, want false")
+	}
+}
+
+func TestFetchWriteBatch(t *testing.T) {
+	f := newTestFetcher(t)
+	batch := &WriteBatch{}
+	ctx := context.WithValue(context.Background(), WriteBatchContextKey, batch)
+
+	if _, _, err := f.Fetch(ctx, "a_hot", time.Minute, false, okFetch("a")); err != nil {
+		t.Fatal(err)
+	}
+	// 刷新前写入只在批次中
+	if cached := f.CachedMany(context.Background(), []string{"a_hot"}); len(cached) != 0 {
+		t.Fatalf("CachedMany before flush = %v, want empty", cached)
+	}
+
+	if err := f.FlushWrites(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+	if cached := f.CachedMany(context.Background(), []string{"a_hot"}); len(cached["a_hot"]) != 1 {
+		t.Fatalf("CachedMany after flush = %v, want a_hot", cached)
+	}
+
+	// 刷新之后到达的写入直接写入缓存
+	if _, _, err := f.Fetch(ctx, "b_hot", time.Minute, false, okFetch("b")); err != nil {
+		t.Fatal(err)
+	}
+	if cached := f.CachedMany(context.Background(), []string{"b_hot"}); len(cached["b_hot"]) != 1 {
+		t.Errorf("CachedMany after late write = %v, want b_hot", cached)
+	}
+}
+
+func TestFetchTimeoutCancelsUpstream(t *testing.T) {
+	canceled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(canceled)
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher(t)
+	f.cfg.Platforms = map[string]config.PlatformConfig{"slow": {Timeout: 50 * time.Millisecond}}
+	ctx := context.WithValue(context.Background(), PlatformContextKey, "slow")
+
+	_, _, err := f.Fetch(ctx, "slow_hot", time.Minute, false, func(ctx context.Context) ([]models.HotData, error) {
+		if _, err := f.Get(ctx, srv.URL, nil); err != nil {
+			return nil, err
+		}
+		return []models.HotData{{ID: "1", Title: "慢"}}, nil
+	})
+	if !errors.Is(err, ErrFetchTimeout) {
+		t.Fatalf("err = %v, want ErrFetchTimeout", err)
+	}
+
+	// 超时后上游请求随抓取上下文一起取消
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("upstream request not canceled after fetch timeout")
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"regexp"
+
+	"github.com/dailyhot/api/internal/config"
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"go.uber.org/zap"
+)
+
+// compileFilters 将配置中的过滤规则编译为 ItemFilter: 平台路由名 -> 过滤器
+// 启动时已校验正则,这里遇到非法正则只记录日志并跳过
+func compileFilters(platforms map[string]config.PlatformConfig) map[string]platformFilter {
+	filters := make(map[string]platformFilter, len(platforms))
+	for name, platform := range platforms {
+		cfg := platform.Filter
+		filter := models.ItemFilter{
+			IDs:   cfg.IDs,
+			Flags: cfg.Flags,
+		}
+		for _, pattern := range cfg.TitlePatterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				logger.Warn("忽略非法的标题过滤正则",
+					zap.String("platform", name),
+					zap.String("pattern", pattern),
+					zap.Error(err),
+				)
+				continue
+			}
+			filter.TitlePatterns = append(filter.TitlePatterns, re)
+		}
+		filters[name] = platformFilter{filter: filter, disableDefaults: cfg.DisableDefaults}
+	}
+	return filters
+}
+
+// platformFilter 单个平台的配置过滤规则
+type platformFilter struct {
+	filter          models.ItemFilter
+	disableDefaults bool
+}
+
+// FilterItems 按平台的广告识别规则过滤条目
+// defaults 为 handler 声明的内置规则,与配置 platforms.<name>.filter 合并生效;
+// 配置了 disable_defaults 时只使用配置中的规则
+func (f *Fetcher) FilterItems(platform string, defaults models.ItemFilter, data []models.HotData) []models.HotData {
+	filter := defaults
+	if configured, ok := f.filters[platform]; ok {
+		if configured.disableDefaults {
+			filter = configured.filter
+		} else {
+			filter = defaults.Merge(configured.filter)
+		}
+	}
+
+	result := filter.Apply(data)
+	if removed := len(data) - len(result); removed > 0 {
+		logger.Debug("过滤广告条目",
+			zap.String("platform", platform),
+			zap.Int("removed", removed),
+		)
+	}
+	return result
+}
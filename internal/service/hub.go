@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"sync"
+
+	"github.com/dailyhot/api/internal/models"
+)
+
+// ErrTooManySubscribers 订阅连接数已达上限
+var ErrTooManySubscribers = errors.New("订阅连接数已达上限")
+
+// ErrHubClosed 订阅中心已关闭(服务正在关闭)
+var ErrHubClosed = errors.New("订阅中心已关闭")
+
+// Subscription 单个订阅连接
+// 数据变化时最新数据写入 C;订阅中心关闭时 C 被关闭
+type Subscription struct {
+	Platform string
+	C        chan []models.HotData
+}
+
+// Hub 热榜变化订阅中心
+// 作为 Refresher 的监听器使用: 平台数据的签名与上一次不同时,把最新数据分发给该平台的所有订阅者。
+// 平台的第一个订阅者出现时开始后台刷新,最后一个订阅者离开时停止,没人订阅的平台不产生额外抓取
+type Hub struct {
+	maxSubscribers int
+	refresher      *Refresher
+
+	mu     sync.Mutex
+	subs   map[string]map[*Subscription]bool // 平台 -> 订阅者集合
+	last   map[string]string                 // 平台 -> 最近一次分发的数据签名
+	total  int                               // 所有平台的订阅者总数
+	closed bool
+}
+
+// NewHub 创建订阅中心
+// refresher 为驱动变化检测的后台刷新器,订阅中心按订阅情况增删其关注的平台
+func NewHub(maxSubscribers int, refresher *Refresher) *Hub {
+	return &Hub{
+		maxSubscribers: maxSubscribers,
+		refresher:      refresher,
+		subs:           make(map[string]map[*Subscription]bool),
+		last:           make(map[string]string),
+	}
+}
+
+// Subscribe 订阅平台的数据变化
+// 连接数达到上限时返回 ErrTooManySubscribers
+func (h *Hub) Subscribe(platform string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	if h.total >= h.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+
+	subs, ok := h.subs[platform]
+	if !ok {
+		subs = make(map[*Subscription]bool)
+		h.subs[platform] = subs
+		h.refresher.Watch(platform)
+	}
+
+	// 缓冲为 1: 订阅者来不及消费时只保留最新数据
+	sub := &Subscription{Platform: platform, C: make(chan []models.HotData, 1)}
+	subs[sub] = true
+	h.total++
+	return sub, nil
+}
+
+// Unsubscribe 取消订阅(客户端断开时调用),可重复调用
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs := h.subs[sub.Platform]
+	if !subs[sub] {
+		return
+	}
+	delete(subs, sub)
+	h.total--
+
+	if len(subs) == 0 {
+		delete(h.subs, sub.Platform)
+		delete(h.last, sub.Platform)
+		h.refresher.Unwatch(sub.Platform)
+	}
+}
+
+// HandleRefresh 处理一次刷新结果,注册为 Refresher 的监听器
+// 数据与上一次分发的相同时不推送
+func (h *Hub) HandleRefresh(_ context.Context, platform string, data []models.HotData) {
+	signature := DataSignature(data)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs := h.subs[platform]
+	if len(subs) == 0 || h.last[platform] == signature {
+		return
+	}
+	h.last[platform] = signature
+
+	for sub := range subs {
+		// 丢弃订阅者尚未消费的旧数据,只保留最新的一份
+		select {
+		case <-sub.C:
+		default:
+		}
+		sub.C <- data
+	}
+}
+
+// Count 当前订阅者总数
+func (h *Hub) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.total
+}
+
+// Close 关闭订阅中心,关闭所有订阅者的通道使连接结束
+// 服务关闭时调用,之后的订阅返回 ErrHubClosed
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for platform, subs := range h.subs {
+		for sub := range subs {
+			close(sub.C)
+		}
+		h.refresher.Unwatch(platform)
+	}
+	h.subs = make(map[string]map[*Subscription]bool)
+	h.total = 0
+}
+
+// DataSignature 计算数据列表的签名,用于判断两次数据是否相同
+func DataSignature(data []models.HotData) string {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	hash := fnv.New64a()
+	hash.Write(body)
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
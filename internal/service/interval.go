@@ -0,0 +1,34 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// fetchIntervals 记录各平台最近一次真实回源的时间
+// 用于 platforms.<name>.min_interval: 未达间隔时不回源,由 Fetch 返回陈旧数据
+type fetchIntervals struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// newFetchIntervals 创建回源间隔记录
+func newFetchIntervals() *fetchIntervals {
+	return &fetchIntervals{
+		last: make(map[string]time.Time),
+	}
+}
+
+// acquire 距该平台上次回源是否已超过 interval
+// 允许回源时同时记录本次回源时间,检查与记录在同一把锁内,并发请求只有一个能通过
+func (i *fetchIntervals) acquire(platform string, interval time.Duration) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := time.Now()
+	if last, ok := i.last[platform]; ok && now.Sub(last) < interval {
+		return false
+	}
+	i.last[platform] = now
+	return true
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/cache"
+	"github.com/dailyhot/api/internal/config"
+)
+
+// 预检项类型
+const (
+	PrecheckRedis = "redis" // Redis 连通性
+	PrecheckDNS   = "dns"   // 上游域名解析
+)
+
+// PrecheckResult 单项依赖的预检结果
+type PrecheckResult struct {
+	Kind    string        // 预检类型: redis、dns
+	Target  string        // 预检目标: Redis 地址或上游域名
+	Latency time.Duration // 耗时
+	Err     error         // 失败原因,成功时为 nil
+}
+
+// Precheck 启动时检查外部依赖的连通性
+// Redis 只在 redis.enabled 时检查(启动时已连接失败、降级为仅 L1 的也会报告为失败);
+// precheck.hosts 中的上游域名并发解析。整体受 ctx 超时控制,结果顺序为 Redis 在前、域名按配置顺序
+func Precheck(ctx context.Context, cfg *config.Config, cacheManager *cache.Manager) []PrecheckResult {
+	hosts := cfg.Precheck.Hosts
+	results := make([]PrecheckResult, 0, len(hosts)+1)
+
+	if cfg.Redis.Enabled {
+		start := time.Now()
+		err := cacheManager.PingL2(ctx)
+		results = append(results, PrecheckResult{
+			Kind:    PrecheckRedis,
+			Target:  fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Latency: time.Since(start),
+			Err:     err,
+		})
+	}
+
+	dnsResults := make([]PrecheckResult, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+
+			start := time.Now()
+			addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+			if err == nil && len(addrs) == 0 {
+				err = errors.New("没有解析到地址")
+			}
+			dnsResults[i] = PrecheckResult{
+				Kind:    PrecheckDNS,
+				Target:  host,
+				Latency: time.Since(start),
+				Err:     err,
+			}
+		}(i, strings.TrimSpace(host))
+	}
+	wg.Wait()
+
+	return append(results, dnsResults...)
+}
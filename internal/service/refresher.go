@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"go.uber.org/zap"
+)
+
+// RefreshFunc 平台刷新函数
+// 由路由层提供,负责绕过缓存重新抓取指定平台的数据
+type RefreshFunc func(ctx context.Context, platform string) ([]models.HotData, error)
+
+// RefreshListener 刷新结果监听器
+// 每次平台刷新成功后都会收到最新数据,用于变化检测、推送等
+// ctx 为刷新器的上下文,监听器启动的异步任务(如 webhook 推送)应在其取消后尽快结束
+type RefreshListener func(ctx context.Context, platform string, data []models.HotData)
+
+// Refresher 后台定时刷新器
+// 按固定间隔依次刷新关注的平台,并把结果分发给所有监听器
+type Refresher struct {
+	interval  time.Duration
+	refresh   RefreshFunc
+	mu        sync.RWMutex
+	platforms map[string]bool   // 需要刷新的平台集合
+	listeners []RefreshListener // 刷新结果监听器
+}
+
+// NewRefresher 创建后台刷新器
+func NewRefresher(interval time.Duration, refresh RefreshFunc) *Refresher {
+	return &Refresher{
+		interval:  interval,
+		refresh:   refresh,
+		platforms: make(map[string]bool),
+	}
+}
+
+// Watch 添加需要后台刷新的平台
+func (r *Refresher) Watch(platforms ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, platform := range platforms {
+		r.platforms[platform] = true
+	}
+}
+
+// Unwatch 移除不再需要后台刷新的平台
+func (r *Refresher) Unwatch(platforms ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, platform := range platforms {
+		delete(r.platforms, platform)
+	}
+}
+
+// OnRefresh 注册刷新结果监听器
+func (r *Refresher) OnRefresh(listener RefreshListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, listener)
+}
+
+// Start 启动后台刷新,阻塞直到 ctx 被取消
+// 启动后立即刷新一次,之后按 interval 周期刷新
+func (r *Refresher) Start(ctx context.Context) {
+	logger.Info("后台刷新已启动", zap.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.refreshAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			logger.Info("后台刷新已停止")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshAll 依次刷新所有关注的平台
+// 串行刷新可以避免定时任务对上游造成突发压力
+func (r *Refresher) refreshAll(ctx context.Context) {
+	r.mu.RLock()
+	platforms := make([]string, 0, len(r.platforms))
+	for platform := range r.platforms {
+		platforms = append(platforms, platform)
+	}
+	listeners := append([]RefreshListener(nil), r.listeners...)
+	r.mu.RUnlock()
+
+	for _, platform := range platforms {
+		if ctx.Err() != nil {
+			return
+		}
+
+		data, err := r.refresh(ctx, platform)
+		if err != nil {
+			logger.Warn("后台刷新失败",
+				zap.String("platform", platform),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for _, listener := range listeners {
+			listener(ctx, platform, data)
+		}
+	}
+}
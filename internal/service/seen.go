@@ -0,0 +1,164 @@
+package service
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+)
+
+// seenFalsePositive 布隆过滤器的目标误判率
+// 误判只会让极少数新条目被当作"已见"而少推送一次,不会重复推送
+const seenFalsePositive = 0.001
+
+// SeenSet 按平台隔离的"已见条目"集合
+// 每个平台使用一对轮换的布隆过滤器: 新 ID 写入当前代,查询时同时检查当前代与上一代,
+// 每过 ttl 丢弃上一代。条目在最后一次标记后的 ttl ~ 2*ttl 之间过期。
+// 单个过滤器的位数由 capacity 与误判率决定,内存占用固定,不随条目数增长
+type SeenSet struct {
+	ttl    time.Duration
+	bits   uint64 // 单个过滤器的位数
+	hashes int    // 哈希函数个数
+
+	mu        sync.Mutex
+	platforms map[string]*seenFilters
+}
+
+// seenFilters 单个平台的两代过滤器
+type seenFilters struct {
+	current   []uint64
+	previous  []uint64
+	rotatedAt time.Time
+}
+
+// NewSeenSet 创建已见集合
+// capacity 为每个平台在一个 ttl 周期内预计标记的条目数,超出后误判率会上升
+func NewSeenSet(ttl time.Duration, capacity int) *SeenSet {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	// m = -n*ln(p)/(ln2)^2, k = m/n*ln2
+	bits := uint64(math.Ceil(-float64(capacity) * math.Log(seenFalsePositive) / (math.Ln2 * math.Ln2)))
+	bits = (bits + 63) / 64 * 64
+	hashes := int(math.Round(float64(bits) / float64(capacity) * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+
+	return &SeenSet{
+		ttl:       ttl,
+		bits:      bits,
+		hashes:    hashes,
+		platforms: make(map[string]*seenFilters),
+	}
+}
+
+// Seen 判断 ID 是否在 ttl 内被标记过
+func (s *SeenSet) Seen(platform, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filters, ok := s.platforms[platform]
+	if !ok {
+		return false
+	}
+	s.rotate(filters, time.Now())
+	return s.contains(filters, id)
+}
+
+// Mark 标记 ID 为已见
+func (s *SeenSet) Mark(platform string, ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filters := s.filters(platform)
+	for _, id := range ids {
+		s.add(filters.current, id)
+	}
+}
+
+// MarkNew 标记 ID 为已见,返回其中此前未见过的 ID(保持原顺序)
+// 查重与标记在同一把锁内完成,并发调用时同一 ID 只会被一个调用方视为新条目
+func (s *SeenSet) MarkNew(platform string, ids ...string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filters := s.filters(platform)
+	fresh := make([]string, 0)
+	for _, id := range ids {
+		if !s.contains(filters, id) {
+			fresh = append(fresh, id)
+		}
+		s.add(filters.current, id)
+	}
+	return fresh
+}
+
+// filters 获取平台的过滤器,不存在时创建;调用方需持有锁
+func (s *SeenSet) filters(platform string) *seenFilters {
+	now := time.Now()
+	filters, ok := s.platforms[platform]
+	if !ok {
+		filters = &seenFilters{
+			current:   make([]uint64, s.bits/64),
+			previous:  make([]uint64, s.bits/64),
+			rotatedAt: now,
+		}
+		s.platforms[platform] = filters
+		return filters
+	}
+	s.rotate(filters, now)
+	return filters
+}
+
+// rotate 到期时轮换过滤器;长时间未访问(超过两个周期)时两代都清空
+func (s *SeenSet) rotate(filters *seenFilters, now time.Time) {
+	elapsed := now.Sub(filters.rotatedAt)
+	if s.ttl <= 0 || elapsed < s.ttl {
+		return
+	}
+
+	if elapsed >= 2*s.ttl {
+		clear(filters.previous)
+	} else {
+		copy(filters.previous, filters.current)
+	}
+	clear(filters.current)
+	filters.rotatedAt = now
+}
+
+// contains 当前代或上一代包含该 ID
+func (s *SeenSet) contains(filters *seenFilters, id string) bool {
+	return s.test(filters.current, id) || s.test(filters.previous, id)
+}
+
+// add 将 ID 写入过滤器
+func (s *SeenSet) add(filter []uint64, id string) {
+	h1, h2 := seenHash(id)
+	for i := 0; i < s.hashes; i++ {
+		pos := (h1 + uint64(i)*h2) % s.bits
+		filter[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// test 过滤器是否可能包含该 ID
+func (s *SeenSet) test(filter []uint64, id string) bool {
+	h1, h2 := seenHash(id)
+	for i := 0; i < s.hashes; i++ {
+		pos := (h1 + uint64(i)*h2) % s.bits
+		if filter[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// seenHash 计算双重哈希的两个基值(Kirsch-Mitzenmacher)
+func seenHash(id string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(id))
+	sum := h.Sum64()
+	// 第二个哈希取高低位混合,保证为奇数避免步长退化
+	h2 := (sum>>33 | sum<<31) | 1
+	return sum, h2
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"go.uber.org/zap"
+)
+
+// SnapshotHourLayout 快照文件名中的时间格式(精确到小时),如 "2024-01-02-15"
+const SnapshotHourLayout = "2006-01-02-15"
+
+// snapshotCleanupInterval 过期快照的清理间隔
+const snapshotCleanupInterval = time.Hour
+
+// snapshotPlatformPattern 合法的平台名,防止拼接出目录之外的路径
+var snapshotPlatformPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ErrSnapshotNotFound 快照不存在
+var ErrSnapshotNotFound = errors.New("快照不存在")
+
+// Snapshot 某个平台某一小时的抓取快照
+// 同一平台的不同榜单(缓存键不同)保存在同一个文件中,同一小时内只保留最后一次抓取
+type Snapshot struct {
+	Platform string                   `json:"platform"` // 平台路由名
+	Hour     string                   `json:"hour"`     // 所属小时,如 "2024-01-02-15"
+	Entries  map[string]SnapshotEntry `json:"entries"`  // 缓存键 -> 抓取结果
+}
+
+// SnapshotEntry 单个榜单的抓取结果
+type SnapshotEntry struct {
+	FetchedAt string           `json:"fetched_at"` // 抓取时间(RFC3339)
+	Data      []models.HotData `json:"data"`       // 热榜数据
+}
+
+// SnapshotStore 抓取快照的本地存储
+// 文件布局: <dir>/<平台>/<yyyy-mm-dd-HH>.json,超过保留天数的文件会被定期删除
+// Save 只把结果暂存在内存中,由 Run 按 flushInterval 批量写盘,抓取协程不会阻塞在文件读写上
+type SnapshotStore struct {
+	dir           string
+	retention     time.Duration
+	flushInterval time.Duration
+
+	mu          sync.Mutex // 保护快照文件的读写,需要同时持有时先取 mu 再取 pendingMu
+	lastCleanup time.Time
+
+	pendingMu sync.Mutex
+	pending   map[snapshotFile]map[string]SnapshotEntry // 尚未写盘的结果: 文件 -> 缓存键 -> 抓取结果
+}
+
+// snapshotFile 快照文件: 平台与所属小时
+type snapshotFile struct {
+	platform string
+	hour     string
+}
+
+// NewSnapshotStore 创建快照存储
+func NewSnapshotStore(dir string, retentionDays int, flushInterval time.Duration) *SnapshotStore {
+	return &SnapshotStore{
+		dir:           dir,
+		retention:     time.Duration(retentionDays) * 24 * time.Hour,
+		flushInterval: flushInterval,
+		pending:       make(map[snapshotFile]map[string]SnapshotEntry),
+	}
+}
+
+// Save 暂存一次抓取结果,下次写盘时合并到所属小时的快照文件
+// 同一小时内同一缓存键的多次抓取只保留最后一次
+// 暂存的是数据副本,写盘前调用方(如后续的过滤、字段处理)修改原切片不会影响快照
+func (s *SnapshotStore) Save(platform, cacheKey string, data []models.HotData, at time.Time) error {
+	if !snapshotPlatformPattern.MatchString(platform) {
+		return fmt.Errorf("非法的平台名: %q", platform)
+	}
+
+	file := snapshotFile{platform: platform, hour: at.Format(SnapshotHourLayout)}
+	entry := SnapshotEntry{FetchedAt: at.Format(time.RFC3339), Data: models.CloneHotData(data)}
+
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+	if s.pending[file] == nil {
+		s.pending[file] = make(map[string]SnapshotEntry)
+	}
+	s.pending[file][cacheKey] = entry
+	return nil
+}
+
+// Run 按 flushInterval 定期写盘,直到 ctx 结束
+// 退出前不会再写盘,优雅关闭时应在等待在途抓取后调用 Flush
+func (s *SnapshotStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				logger.Warn("快照写盘失败", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Flush 将暂存的结果合并写入快照文件,每个文件只读写一次
+// 写入失败的文件返回第一个错误,其余文件照常写入
+func (s *SnapshotStore) Flush() error {
+	// 写盘期间持有文件锁,Load 与 List 不会看到已取出暂存、但尚未写入文件的中间状态
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pendingMu.Lock()
+	pending := s.pending
+	s.pending = make(map[snapshotFile]map[string]SnapshotEntry)
+	s.pendingMu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var firstErr error
+	for file, entries := range pending {
+		path := s.path(file)
+
+		// 合并同一小时内已写盘的其他榜单
+		snapshot := &Snapshot{Platform: file.platform, Hour: file.hour}
+		if existing, err := readSnapshot(path); err == nil {
+			snapshot = existing
+		}
+		if snapshot.Entries == nil {
+			snapshot.Entries = make(map[string]SnapshotEntry, len(entries))
+		}
+		for cacheKey, entry := range entries {
+			snapshot.Entries[cacheKey] = entry
+		}
+
+		if err := writeSnapshot(path, snapshot); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	now := time.Now()
+	if now.Sub(s.lastCleanup) >= snapshotCleanupInterval {
+		s.lastCleanup = now
+		go s.cleanup(now)
+	}
+	return firstErr
+}
+
+// Load 读取指定平台某一小时的快照,包含尚未写盘的结果
+func (s *SnapshotStore) Load(platform, hour string) (*Snapshot, error) {
+	if !snapshotPlatformPattern.MatchString(platform) {
+		return nil, fmt.Errorf("非法的平台名: %q", platform)
+	}
+	if _, err := time.Parse(SnapshotHourLayout, hour); err != nil {
+		return nil, fmt.Errorf("非法的时间: %q,格式应为 yyyy-mm-dd-HH", hour)
+	}
+
+	file := snapshotFile{platform: platform, hour: hour}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot, err := readSnapshot(s.path(file))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+	entries := s.pending[file]
+	if snapshot == nil {
+		if len(entries) == 0 {
+			return nil, ErrSnapshotNotFound
+		}
+		snapshot = &Snapshot{Platform: platform, Hour: hour}
+	}
+	if snapshot.Entries == nil {
+		snapshot.Entries = make(map[string]SnapshotEntry, len(entries))
+	}
+	for cacheKey, entry := range entries {
+		snapshot.Entries[cacheKey] = entry
+	}
+	return snapshot, nil
+}
+
+// path 快照文件路径
+func (s *SnapshotStore) path(file snapshotFile) string {
+	return filepath.Join(s.dir, file.platform, file.hour+".json")
+}
+
+// List 列出指定平台已保存快照的小时,按时间倒序
+// prefix 用于过滤,如 "2024-01-02" 只返回当天的快照
+func (s *SnapshotStore) List(platform, prefix string) ([]string, error) {
+	if !snapshotPlatformPattern.MatchString(platform) {
+		return nil, fmt.Errorf("非法的平台名: %q", platform)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, platform))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("读取快照目录失败: %w", err)
+	}
+
+	seen := make(map[string]bool, len(entries))
+	hours := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		hour := strings.TrimSuffix(entry.Name(), ".json")
+		if entry.IsDir() || hour == entry.Name() || !strings.HasPrefix(hour, prefix) {
+			continue
+		}
+		seen[hour] = true
+		hours = append(hours, hour)
+	}
+
+	// 尚未写盘的小时
+	s.pendingMu.Lock()
+	for file := range s.pending {
+		if file.platform == platform && !seen[file.hour] && strings.HasPrefix(file.hour, prefix) {
+			seen[file.hour] = true
+			hours = append(hours, file.hour)
+		}
+	}
+	s.pendingMu.Unlock()
+
+	sort.Sort(sort.Reverse(sort.StringSlice(hours)))
+	return hours, nil
+}
+
+// cleanup 删除超过保留天数的快照文件
+func (s *SnapshotStore) cleanup(now time.Time) {
+	deadline := now.Add(-s.retention)
+
+	platforms, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+
+	removed := 0
+	for _, platform := range platforms {
+		if !platform.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(s.dir, platform.Name()))
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			hour, err := time.ParseInLocation(SnapshotHourLayout, strings.TrimSuffix(file.Name(), ".json"), now.Location())
+			if err != nil || !hour.Before(deadline) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(s.dir, platform.Name(), file.Name())); err == nil {
+				removed++
+			}
+		}
+	}
+
+	if removed > 0 {
+		logger.Info("已清理过期快照", zap.Int("files", removed))
+	}
+}
+
+// readSnapshot 读取快照文件
+func readSnapshot(path string) (*Snapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("解析快照失败: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// writeSnapshot 写入快照文件
+// 先写临时文件再重命名,避免读取到写了一半的文件
+func writeSnapshot(path string, snapshot *Snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建快照目录失败: %w", err)
+	}
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("序列化快照失败: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("写入快照失败: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+)
+
+func TestSnapshotStoreBuffersUntilFlush(t *testing.T) {
+	dir := t.TempDir()
+	store := NewSnapshotStore(dir, 7, time.Minute)
+	at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.Local)
+	path := filepath.Join(dir, "weibo", "2024-01-02-15.json")
+
+	for i := 0; i < 3; i++ {
+		data := []models.HotData{{ID: "1", Title: fmt.Sprintf("第%d次", i+1)}}
+		if err := store.Save("weibo", "weibo", data, at.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Save 不应直接写盘, stat err = %v", err)
+	}
+
+	// 未写盘的结果同样可以读取与列出
+	snapshot, err := store.Load("weibo", "2024-01-02-15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := snapshot.Entries["weibo"].Data[0].Title; got != "第3次" {
+		t.Errorf("pending title = %q, want 第3次", got)
+	}
+	if hours, _ := store.List("weibo", ""); len(hours) != 1 || hours[0] != "2024-01-02-15" {
+		t.Errorf("List = %v, want [2024-01-02-15]", hours)
+	}
+
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err = readSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := snapshot.Entries["weibo"].Data[0].Title; got != "第3次" {
+		t.Errorf("flushed title = %q, want 第3次", got)
+	}
+}
+
+func TestSnapshotStoreFlushMergesEntries(t *testing.T) {
+	dir := t.TempDir()
+	store := NewSnapshotStore(dir, 7, time.Minute)
+	at := time.Date(2024, 1, 2, 15, 0, 0, 0, time.Local)
+
+	_ = store.Save("bilibili", "bilibili_0", []models.HotData{{ID: "a"}}, at)
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Save("bilibili", "bilibili_188", []models.HotData{{ID: "b"}}, at)
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := store.Load("bilibili", "2024-01-02-15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Entries) != 2 {
+		t.Errorf("entries = %v, want both lists", snapshot.Entries)
+	}
+}
+
+func TestSnapshotStoreLoadMissing(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir(), 7, time.Minute)
+	if _, err := store.Load("weibo", "2024-01-02-15"); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Errorf("err = %v, want ErrSnapshotNotFound", err)
+	}
+	if err := store.Save("../etc", "x", nil, time.Now()); err == nil {
+		t.Error("want error for invalid platform")
+	}
+}
+
+func TestSnapshotStoreSaveCopiesData(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir(), 7, time.Minute)
+	at := time.Date(2024, 1, 2, 15, 0, 0, 0, time.Local)
+
+	data := []models.HotData{{ID: "1", Title: "原标题", Extra: map[string]interface{}{"tag": "热"}}}
+	if err := store.Save("weibo", "weibo", data, at); err != nil {
+		t.Fatal(err)
+	}
+	// 写盘前修改原切片不影响快照
+	data[0].Title = "改后"
+	data[0].Extra["tag"] = "新"
+
+	snapshot, err := store.Load("weibo", "2024-01-02-15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := snapshot.Entries["weibo"].Data[0]
+	if item.Title != "原标题" || item.Extra["tag"] != "热" {
+		t.Errorf("snapshot item = %+v, want original data", item)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+)
+
+// staleEntry 最近一次成功抓取的数据
+type staleEntry struct {
+	key       string
+	data      []models.HotData
+	fetchedAt time.Time
+}
+
+// staleStore 陈旧数据存储
+// 保存每个缓存键最近一次成功抓取的数据,抓取超时时作为兜底返回
+// 与 L1/L2 缓存独立: 缓存过期后数据仍保留 expire 时长
+//
+// 缓存键可能包含用户参数,条目数以 maxEntries 为上限,超出时淘汰最久未使用(读取或写入)的键;
+// 数据在写入与读取时都会复制,调用方修改返回值不会影响存储的数据
+type staleStore struct {
+	mu         sync.Mutex
+	expire     time.Duration
+	maxEntries int
+	order      *list.List               // 按最近使用排序,队首为最近使用
+	entries    map[string]*list.Element // 缓存键 -> order 中的元素(值为 *staleEntry)
+}
+
+// newStaleStore 创建陈旧数据存储
+func newStaleStore(expire time.Duration, maxEntries int) *staleStore {
+	if maxEntries <= 0 {
+		maxEntries = 1
+	}
+	return &staleStore{
+		expire:     expire,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// set 记录一次成功抓取的数据
+func (s *staleStore) set(key string, data []models.HotData) {
+	entry := &staleEntry{key: key, data: models.CloneHotData(data), fetchedAt: time.Now()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		elem.Value = entry
+		s.order.MoveToFront(elem)
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(entry)
+	for s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*staleEntry).key)
+	}
+}
+
+// get 获取未超过保留时间的陈旧数据
+// 超过保留时间的条目会被删除
+func (s *staleStore) get(key string) ([]models.HotData, bool) {
+	s.mu.Lock()
+	elem, ok := s.entries[key]
+	if !ok {
+		s.mu.Unlock()
+		return nil, false
+	}
+
+	entry := elem.Value.(*staleEntry)
+	if time.Since(entry.fetchedAt) > s.expire {
+		s.order.Remove(elem)
+		delete(s.entries, key)
+		s.mu.Unlock()
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+	s.mu.Unlock()
+
+	return models.CloneHotData(entry.data), true
+}
+
+// len 当前保存的缓存键数
+func (s *staleStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
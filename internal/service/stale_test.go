@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/internal/models"
+)
+
+func staleTestData(title string) []models.HotData {
+	return []models.HotData{{ID: "1", Title: title, Extra: map[string]interface{}{"rank": 1}}}
+}
+
+func TestStaleStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := newStaleStore(time.Hour, 2)
+	store.set("a", staleTestData("a"))
+	store.set("b", staleTestData("b"))
+
+	// 读取 a 后,b 成为最久未使用的键
+	if _, ok := store.get("a"); !ok {
+		t.Fatal("a 应存在")
+	}
+	store.set("c", staleTestData("c"))
+
+	if _, ok := store.get("b"); ok {
+		t.Error("b 应被淘汰")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := store.get(key); !ok {
+			t.Errorf("%s 应保留", key)
+		}
+	}
+	if n := store.len(); n != 2 {
+		t.Errorf("len = %d, want 2", n)
+	}
+}
+
+func TestStaleStoreBounded(t *testing.T) {
+	store := newStaleStore(time.Hour, 100)
+	for i := 0; i < 1000; i++ {
+		store.set(fmt.Sprintf("readability_%d", i), staleTestData("x"))
+	}
+	if n := store.len(); n != 100 {
+		t.Errorf("len = %d, want 100", n)
+	}
+}
+
+func TestStaleStoreOverwrite(t *testing.T) {
+	store := newStaleStore(time.Hour, 2)
+	store.set("a", staleTestData("old"))
+	store.set("a", staleTestData("new"))
+
+	data, ok := store.get("a")
+	if !ok || data[0].Title != "new" {
+		t.Errorf("get(a) = %v, %v", data, ok)
+	}
+	if n := store.len(); n != 1 {
+		t.Errorf("len = %d, want 1", n)
+	}
+}
+
+func TestStaleStoreExpire(t *testing.T) {
+	store := newStaleStore(time.Millisecond, 10)
+	store.set("a", staleTestData("a"))
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := store.get("a"); ok {
+		t.Error("超过保留时间的数据不应返回")
+	}
+	if n := store.len(); n != 0 {
+		t.Errorf("过期条目应被删除, len = %d", n)
+	}
+}
+
+func TestStaleStoreCopies(t *testing.T) {
+	store := newStaleStore(time.Hour, 10)
+	data := staleTestData("original")
+	store.set("a", data)
+
+	// 修改调用方的数据不影响存储
+	data[0].Title = "changed"
+	data[0].Extra["rank"] = 99
+
+	got, _ := store.get("a")
+	if got[0].Title != "original" || got[0].Extra["rank"] != 1 {
+		t.Fatalf("set 未复制数据: %+v", got[0])
+	}
+
+	// 修改读取到的数据不影响存储
+	got[0].Title = "changed"
+	got[0].Extra["hot_score"] = 100.0
+
+	again, _ := store.get("a")
+	if again[0].Title != "original" {
+		t.Errorf("get 未复制数据: %+v", again[0])
+	}
+	if _, ok := again[0].Extra["hot_score"]; ok {
+		t.Errorf("get 未复制 Extra: %+v", again[0].Extra)
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/http"
+)
+
+// startTime 服务启动时间,用于计算运行时长
+var startTime = time.Now()
+
+// PlatformStat 单个平台的抓取统计
+// 记录最近一次抓取的时间、耗时和结果,便于排查上游异常
+type PlatformStat struct {
+	Platform      string `json:"platform"`        // 平台名称,如 "bilibili"
+	Requests      int64  `json:"requests"`        // 累计请求次数
+	Failures      int64  `json:"failures"`        // 累计失败次数
+	LastFetchTime string `json:"last_fetch_time"` // 最近一次抓取时间(RFC3339)
+	LastLatencyMs int64  `json:"last_latency_ms"` // 最近一次抓取耗时(毫秒)
+	LastSuccess   bool   `json:"last_success"`    // 最近一次抓取是否成功
+}
+
+// platformStats 平台抓取统计表
+// 多个请求会并发写入,需要加锁保护
+type platformStats struct {
+	mu    sync.RWMutex
+	stats map[string]*PlatformStat
+}
+
+// newPlatformStats 创建平台统计表
+func newPlatformStats() *platformStats {
+	return &platformStats{
+		stats: make(map[string]*PlatformStat),
+	}
+}
+
+// record 记录一次抓取结果
+func (s *platformStats) record(platform string, latency time.Duration, success bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stat, ok := s.stats[platform]
+	if !ok {
+		stat = &PlatformStat{Platform: platform}
+		s.stats[platform] = stat
+	}
+
+	stat.Requests++
+	if !success {
+		stat.Failures++
+	}
+	stat.LastFetchTime = time.Now().Format(time.RFC3339)
+	stat.LastLatencyMs = latency.Milliseconds()
+	stat.LastSuccess = success
+}
+
+// snapshot 返回统计数据的副本(按平台名排序)
+func (s *platformStats) snapshot() []PlatformStat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]PlatformStat, 0, len(s.stats))
+	for _, stat := range s.stats {
+		result = append(result, *stat)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Platform < result[j].Platform
+	})
+	return result
+}
+
+// UpstreamStat 单个上游域名的请求统计
+// 由 HTTP 客户端的请求埋点汇总而来,用于观察上游的耗时、重试和流量
+type UpstreamStat struct {
+	Host          string `json:"host"`            // 上游域名,如 "api.bilibili.com"
+	Requests      int64  `json:"requests"`        // 累计请求次数
+	Failures      int64  `json:"failures"`        // 累计失败次数
+	Retries       int64  `json:"retries"`         // 累计重试次数
+	Throttled     int64  `json:"throttled"`       // 累计触发风控(429)或被冷却拒绝的次数
+	Bytes         int64  `json:"bytes"`           // 累计响应字节数
+	LastStatus    int    `json:"last_status"`     // 最近一次响应状态码
+	LastLatencyMs int64  `json:"last_latency_ms"` // 最近一次请求耗时(毫秒)
+}
+
+// upstreamStats 上游请求统计表
+type upstreamStats struct {
+	mu    sync.RWMutex
+	stats map[string]*UpstreamStat
+}
+
+// newUpstreamStats 创建上游统计表
+func newUpstreamStats() *upstreamStats {
+	return &upstreamStats{
+		stats: make(map[string]*UpstreamStat),
+	}
+}
+
+// record 记录一次上游请求
+func (s *upstreamStats) record(host string, metrics http.RequestMetrics, success bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stat, ok := s.stats[host]
+	if !ok {
+		stat = &UpstreamStat{Host: host}
+		s.stats[host] = stat
+	}
+
+	stat.Requests++
+	if !success {
+		stat.Failures++
+	}
+	if metrics.Throttled {
+		stat.Throttled++
+	}
+	stat.Retries += int64(metrics.Retries)
+	stat.Bytes += int64(metrics.Size)
+	stat.LastStatus = metrics.StatusCode
+	stat.LastLatencyMs = metrics.Duration.Milliseconds()
+}
+
+// snapshot 返回统计数据的副本(按域名排序)
+func (s *upstreamStats) snapshot() []UpstreamStat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]UpstreamStat, 0, len(s.stats))
+	for _, stat := range s.stats {
+		result = append(result, *stat)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Host < result[j].Host
+	})
+	return result
+}
+
+// RuntimeStats 获取 Go 运行时指标
+// 包括 goroutine 数量、内存使用、GC 次数等,便于排查内存泄漏
+func RuntimeStats() map[string]interface{} {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return map[string]interface{}{
+		"uptime_seconds": int64(time.Since(startTime).Seconds()),
+		"goroutines":     runtime.NumGoroutine(),
+		"num_cpu":        runtime.NumCPU(),
+		"go_version":     runtime.Version(),
+		"memory": map[string]interface{}{
+			"alloc_bytes":       mem.Alloc,       // 当前堆上分配的字节数
+			"total_alloc_bytes": mem.TotalAlloc,  // 累计分配的字节数
+			"sys_bytes":         mem.Sys,         // 从系统申请的总内存
+			"heap_inuse_bytes":  mem.HeapInuse,   // 正在使用的堆内存
+			"heap_objects":      mem.HeapObjects, // 堆上对象数量
+			"num_gc":            mem.NumGC,       // GC 次数
+		},
+	}
+}
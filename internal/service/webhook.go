@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dailyhot/api/internal/config"
+	"github.com/dailyhot/api/internal/http"
+	"github.com/dailyhot/api/internal/logger"
+	"github.com/dailyhot/api/internal/models"
+	"go.uber.org/zap"
+)
+
+// WebhookPayload 推送给 webhook 的变更内容
+type WebhookPayload struct {
+	Platform  string           `json:"platform"`  // 平台路由名,如 "weibo"
+	Added     []models.HotData `json:"added"`     // 新进入 Top N 的条目
+	Removed   []string         `json:"removed"`   // 跌出 Top N 的条目 ID
+	Top       []models.HotData `json:"top"`       // 当前 Top N 完整列表
+	Timestamp int64            `json:"timestamp"` // 检测到变化的时间(毫秒)
+}
+
+// WebhookNotifier 热榜变化推送器
+// 对比每次刷新的 Top N 条目 ID 集合,有变化时推送到订阅的 webhook
+type WebhookNotifier struct {
+	cfg    config.WebhookConfig
+	client *http.Client
+
+	mu      sync.Mutex
+	lastIDs map[string][]string             // 平台 -> 上一次的 Top N ID 列表
+	sentAt  map[string]map[string]time.Time // 平台 -> ID 集合签名 -> 推送时间(防抖)
+	seen    *SeenSet                        // 已见条目集合,nil 表示不去重
+
+	inflight sync.WaitGroup // 在途推送,见 Wait
+}
+
+// NewWebhookNotifier 创建 webhook 推送器
+func NewWebhookNotifier(cfg config.WebhookConfig) *WebhookNotifier {
+	// 推送使用独立的客户端,由推送器自己控制重试节奏
+	client := http.NewClient().
+		SetTimeout(cfg.Timeout).
+		SetRetry(0, 0)
+
+	var seen *SeenSet
+	if cfg.SeenTTL > 0 {
+		seen = NewSeenSet(cfg.SeenTTL, cfg.SeenCapacity)
+	}
+
+	return &WebhookNotifier{
+		cfg:     cfg,
+		client:  client,
+		lastIDs: make(map[string][]string),
+		sentAt:  make(map[string]map[string]time.Time),
+		seen:    seen,
+	}
+}
+
+// Platforms 返回有订阅的平台列表
+func (n *WebhookNotifier) Platforms() []string {
+	platforms := make([]string, 0, len(n.cfg.Subscriptions))
+	for platform, urls := range n.cfg.Subscriptions {
+		if len(urls) > 0 {
+			platforms = append(platforms, platform)
+		}
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
+// HandleRefresh 处理一次刷新结果,可直接注册为 Refresher 的监听器
+// ctx 取消(服务关闭)后推送中止且不再重试
+func (n *WebhookNotifier) HandleRefresh(ctx context.Context, platform string, data []models.HotData) {
+	urls := n.cfg.Subscriptions[platform]
+	if len(urls) == 0 {
+		return
+	}
+
+	payload, ok := n.detectChange(platform, data)
+	if !ok {
+		return
+	}
+
+	logger.Info("检测到热榜变化,准备推送",
+		zap.String("platform", platform),
+		zap.Int("added", len(payload.Added)),
+		zap.Int("removed", len(payload.Removed)),
+	)
+
+	// 异步推送,避免重试等待阻塞后台刷新
+	for _, url := range urls {
+		n.inflight.Add(1)
+		go func(url string) {
+			defer n.inflight.Done()
+			n.send(ctx, url, payload)
+		}(url)
+	}
+}
+
+// Wait 等待在途推送结束
+// 服务关闭时在刷新器停止后调用,推送随刷新器的 ctx 一起取消,不会长时间阻塞
+func (n *WebhookNotifier) Wait() {
+	n.inflight.Wait()
+}
+
+// detectChange 对比 Top N 条目 ID 集合
+// 首次刷新只记录基线不推送;防抖窗口内推送过的相同 ID 集合不会重复推送
+// (榜单在两个状态间来回抖动时,可以避免反复推送同样的变化);
+// 开启 seen_ttl 时,记忆期内出现过的条目重新进入 Top N 不算新增
+func (n *WebhookNotifier) detectChange(platform string, data []models.HotData) (*WebhookPayload, bool) {
+	topN := n.cfg.TopN
+	if topN <= 0 || topN > len(data) {
+		topN = len(data)
+	}
+	top := data[:topN]
+
+	ids := make([]string, 0, len(top))
+	for _, item := range top {
+		ids = append(ids, hotDataKey(item))
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// 每次刷新都标记当前 Top N,持续在榜的条目会一直保持"已见"
+	var fresh map[string]bool
+	if n.seen != nil {
+		newIDs := n.seen.MarkNew(platform, ids...)
+		fresh = make(map[string]bool, len(newIDs))
+		for _, id := range newIDs {
+			fresh[id] = true
+		}
+	}
+
+	prevIDs, ok := n.lastIDs[platform]
+	n.lastIDs[platform] = ids
+	if !ok {
+		return nil, false
+	}
+
+	// 计算新增与移除
+	prevSet := make(map[string]bool, len(prevIDs))
+	for _, id := range prevIDs {
+		prevSet[id] = true
+	}
+	currSet := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		currSet[id] = true
+	}
+
+	added := make([]models.HotData, 0)
+	for i, id := range ids {
+		if !prevSet[id] && (fresh == nil || fresh[id]) {
+			added = append(added, top[i])
+		}
+	}
+	removed := make([]string, 0)
+	for _, id := range prevIDs {
+		if !currSet[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil, false
+	}
+
+	// 防抖: 清理过期记录后,检查窗口内是否推送过相同的集合
+	now := time.Now()
+	sent, ok := n.sentAt[platform]
+	if !ok {
+		sent = make(map[string]time.Time)
+		n.sentAt[platform] = sent
+	}
+	for sig, at := range sent {
+		if now.Sub(at) > n.cfg.Debounce {
+			delete(sent, sig)
+		}
+	}
+
+	signature := idSetSignature(ids)
+	if _, dup := sent[signature]; dup {
+		return nil, false
+	}
+	sent[signature] = now
+
+	return &WebhookPayload{
+		Platform:  platform,
+		Added:     added,
+		Removed:   removed,
+		Top:       top,
+		Timestamp: now.UnixMilli(),
+	}, true
+}
+
+// send 推送变更,失败时按递增间隔重试
+// ctx 取消时中止当前请求并放弃剩余重试
+func (n *WebhookNotifier) send(ctx context.Context, url string, payload *WebhookPayload) {
+	attempts := n.cfg.Retries + 1
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				logger.Warn("服务关闭,放弃 webhook 推送",
+					zap.String("platform", payload.Platform),
+					zap.String("url", url),
+					zap.Error(lastErr),
+				)
+				return
+			}
+		}
+
+		_, err := n.client.PostContext(ctx, url, payload, map[string]string{
+			"Content-Type": "application/json",
+			"User-Agent":   "DailyHotApi/Webhook",
+		})
+		if err == nil {
+			logger.Info("webhook 推送成功",
+				zap.String("platform", payload.Platform),
+				zap.String("url", url),
+			)
+			return
+		}
+		lastErr = err
+	}
+
+	logger.Error("webhook 推送失败",
+		zap.String("platform", payload.Platform),
+		zap.String("url", url),
+		zap.Int("attempts", attempts),
+		zap.Error(lastErr),
+	)
+}
+
+// hotDataKey 获取条目的唯一标识,没有 ID 时退回 URL
+func hotDataKey(item models.HotData) string {
+	if item.ID != "" {
+		return item.ID
+	}
+	return item.URL
+}
+
+// idSetSignature 计算 ID 集合签名(与顺序无关)
+func idSetSignature(ids []string) string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "\x00")
+}
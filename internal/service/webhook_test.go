@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dailyhot/api/internal/config"
+	"github.com/dailyhot/api/internal/models"
+)
+
+func TestWebhookSendStopsOnCancel(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(config.WebhookConfig{
+		TopN:          10,
+		Retries:       5,
+		Timeout:       time.Second,
+		Debounce:      time.Minute,
+		Subscriptions: map[string][]string{"weibo": {srv.URL}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.HandleRefresh(ctx, "weibo", []models.HotData{{ID: "1"}})
+	n.HandleRefresh(ctx, "weibo", []models.HotData{{ID: "2"}})
+
+	// 首次推送失败后进入重试等待,取消后应立即放弃剩余重试
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&requests) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		n.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after ctx was canceled")
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
@@ -29,8 +29,18 @@ func GetRandomDeviceID() string {
 
 // GetAppToken 获取酷安APP Token
 func GetAppToken() string {
-	deviceID := GetRandomDeviceID()
-	now := time.Now().Unix()
+	return GetAppTokenAt(time.Now())
+}
+
+// GetAppTokenAt 使用指定的时间基准生成酷安APP Token
+// 签名中包含时间戳,本地时钟与酷安服务器不同步时可传入校正后的时间
+func GetAppTokenAt(now time.Time) string {
+	return buildAppToken(GetRandomDeviceID(), now.Unix())
+}
+
+// buildAppToken 根据设备ID和秒级时间戳计算签名
+// 拆出纯函数便于对签名算法做确定性校验
+func buildAppToken(deviceID string, now int64) string {
 	hexNow := fmt.Sprintf("0x%x", now)
 
 	// MD5(now)
@@ -51,10 +61,15 @@ func GetAppToken() string {
 
 // GenCoolapkHeaders 生成酷安请求头
 func GenCoolapkHeaders() map[string]string {
+	return GenCoolapkHeadersAt(time.Now())
+}
+
+// GenCoolapkHeadersAt 使用指定的时间基准生成酷安请求头
+func GenCoolapkHeadersAt(now time.Time) map[string]string {
 	return map[string]string{
 		"X-Requested-With": "XMLHttpRequest",
 		"X-App-Id":         "com.coolapk.market",
-		"X-App-Token":      GetAppToken(),
+		"X-App-Token":      GetAppTokenAt(now),
 		"X-Sdk-Int":        "29",
 		"X-Sdk-Locale":     "zh-CN",
 		"X-App-Version":    "11.0",
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 期望值由原 TypeScript 实现(src/utils/getToken/coolapk.ts)的算法计算得到
+func TestBuildAppToken(t *testing.T) {
+	deviceID := "abcdefghij-klmnop-qrstuv-wxyz01-23456789abcdef"
+	want := "1c499f6e02e5c1cae85fcd93530b8e95" + deviceID + "0x6553f100"
+	if got := buildAppToken(deviceID, 1700000000); got != want {
+		t.Errorf("buildAppToken = %q, want %q", got, want)
+	}
+}
+
+func TestGetRandomDeviceID(t *testing.T) {
+	parts := strings.Split(GetRandomDeviceID(), "-")
+	lengths := []int{10, 6, 6, 6, 14}
+	if len(parts) != len(lengths) {
+		t.Fatalf("parts = %v, want %d parts", parts, len(lengths))
+	}
+	for i, part := range parts {
+		if len(part) != lengths[i] {
+			t.Errorf("part %d = %q, want length %d", i, part, lengths[i])
+		}
+	}
+}
+
+func TestGenCoolapkHeadersAt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	headers := GenCoolapkHeadersAt(now)
+
+	token := headers["X-App-Token"]
+	// token = md5(32 位) + 设备ID(46 位) + 十六进制时间戳
+	if len(token) != 32+46+len(fmt.Sprintf("0x%x", now.Unix())) {
+		t.Errorf("len(token) = %d, token = %q", len(token), token)
+	}
+	if !strings.HasSuffix(token, fmt.Sprintf("0x%x", now.Unix())) {
+		t.Errorf("token = %q, want suffix of timestamp %d", token, now.Unix())
+	}
+	if headers["X-App-Id"] != "com.coolapk.market" {
+		t.Errorf("X-App-Id = %q", headers["X-App-Id"])
+	}
+}
@@ -13,31 +13,51 @@ var (
 	yesterdayPattern     = regexp.MustCompile(`^昨日\s*\d{1,2}:\d{2}$`)
 	monthDayPattern      = regexp.MustCompile(`^\d{1,2}月\d{1,2}日$`)
 	monthDayTimePattern  = regexp.MustCompile(`^\d{1,2}月\d{1,2}日\s+\d{1,2}:\d{2}$`)
-	monthDayDashPattern  = regexp.MustCompile(`^\d{1,2}-\d{1,2}$`)
+	monthDayDashPattern  = regexp.MustCompile(`^(\d{1,2})[-/.](\d{1,2})(?:\s+(\d{1,2}):(\d{2})(?::(\d{2}))?)?$`)
 	hoursAgoPattern      = regexp.MustCompile(`^(\d+)\s*小时前$`)
 	minutesAgoPattern    = regexp.MustCompile(`^(\d+)\s*分钟前$`)
+	daysAgoPattern       = regexp.MustCompile(`^(\d+)\s*天前$`)
 	numericPattern       = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
 	whitespaceCondenseRe = regexp.MustCompile(`\s+`)
 	defaultTimeLayouts   = []string{
 		time.RFC3339Nano,
 		time.RFC3339,
-		"2006-01-02 15:04:05",
-		"2006-01-02 15:04",
-		"2006-01-02 15",
-		"2006-01-02",
-		"2006/01/02 15:04:05",
-		"2006/01/02 15:04",
-		"2006/01/02",
-		"2006.01.02 15:04:05",
-		"2006.01.02 15:04",
-		"2006.01.02",
+		// 月、日、时不要求补零,如 "2024-1-5 8:30"
+		"2006-1-2 15:04:05",
+		"2006-1-2 15:04",
+		"2006-1-2 15",
+		"2006-1-2",
+		"2006/1/2 15:04:05",
+		"2006/1/2 15:04",
+		"2006/1/2",
+		"2006.1.2 15:04:05",
+		"2006.1.2 15:04",
+		"2006.1.2",
 		"20060102",
 	}
 )
 
+// Beijing 北京时间,国内平台返回的不带时区的时间按此解析
+// 运行环境缺少时区数据库时退化为固定的 UTC+8
+var Beijing = loadBeijing()
+
+func loadBeijing() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Shanghai"); err == nil {
+		return loc
+	}
+	return time.FixedZone("CST", 8*3600)
+}
+
 // ParseTime 尝试将各种格式的时间字符串/数字转换为毫秒级 Unix 时间戳
 // 逻辑参考 Node 版本的 getTime 工具,保持输入兼容性
+// 不带时区的时间按本地时区解析,等价于 ParseTimeIn(val, time.Local, time.Now())
 func ParseTime(val interface{}) int64 {
+	return ParseTimeIn(val, time.Local, time.Now())
+}
+
+// ParseTimeIn 与 ParseTime 相同,但不带时区的时间按 loc 解析,"3小时前"、"昨天" 等相对时间以 now 为基准
+// 缺少年份的日期(如 "1-5 08:30"、"1月5日")按 now 所在年份补全,补全后晚于 now 一天以上时(跨年)回退一年
+func ParseTimeIn(val interface{}, loc *time.Location, now time.Time) int64 {
 	switch v := val.(type) {
 	case int:
 		return normalizeTimestamp(int64(v))
@@ -57,12 +77,13 @@ func ParseTime(val interface{}) int64 {
 			return normalizeTimestamp(int64(f))
 		}
 	case string:
-		return parseStringTime(v)
+		return parseStringTime(v, now.In(loc))
 	}
 	return 0
 }
 
-func parseStringTime(input string) int64 {
+// parseStringTime 解析时间字符串,now 的时区即解析使用的时区
+func parseStringTime(input string, now time.Time) int64 {
 	s := strings.TrimSpace(input)
 	if s == "" {
 		return 0
@@ -79,7 +100,6 @@ func parseStringTime(input string) int64 {
 		}
 	}
 
-	now := time.Now()
 	loc := now.Location()
 
 	// HH:mm -> 当天
@@ -105,19 +125,19 @@ func parseStringTime(input string) int64 {
 	if monthDayPattern.MatchString(s) {
 		month, day := parseMonthDay(s)
 		if month > 0 && day > 0 {
-			return time.Date(now.Year(), time.Month(month), day, 0, 0, 0, 0, loc).UnixMilli()
+			return dateInYear(now, month, day, 0, 0, 0)
 		}
 	}
 
-	// MM-DD
-	if monthDayDashPattern.MatchString(s) {
-		parts := strings.Split(s, "-")
-		if len(parts) == 2 {
-			month, _ := strconv.Atoi(parts[0])
-			day, _ := strconv.Atoi(parts[1])
-			if month > 0 && day > 0 {
-				return time.Date(now.Year(), time.Month(month), day, 0, 0, 0, 0, loc).UnixMilli()
-			}
+	// MM-DD、MM/DD、MM.DD,可带 HH:mm[:ss]
+	if matches := monthDayDashPattern.FindStringSubmatch(s); matches != nil {
+		month, _ := strconv.Atoi(matches[1])
+		day, _ := strconv.Atoi(matches[2])
+		hour, _ := strconv.Atoi(matches[3])
+		minute, _ := strconv.Atoi(matches[4])
+		sec, _ := strconv.Atoi(matches[5])
+		if month > 0 && day > 0 {
+			return dateInYear(now, month, day, hour, minute, sec)
 		}
 	}
 
@@ -131,7 +151,7 @@ func parseStringTime(input string) int64 {
 			if month > 0 && day > 0 && len(hourMinute) == 2 {
 				hour, _ := strconv.Atoi(hourMinute[0])
 				minute, _ := strconv.Atoi(hourMinute[1])
-				return time.Date(now.Year(), time.Month(month), day, hour, minute, 0, 0, loc).UnixMilli()
+				return dateInYear(now, month, day, hour, minute, 0)
 			}
 		}
 	}
@@ -171,6 +191,12 @@ func parseStringTime(input string) int64 {
 		return now.Add(-time.Duration(minutes) * time.Minute).UnixMilli()
 	}
 
+	// X天前
+	if matches := daysAgoPattern.FindStringSubmatch(s); len(matches) == 2 {
+		days, _ := strconv.Atoi(matches[1])
+		return now.AddDate(0, 0, -days).UnixMilli()
+	}
+
 	// 中文日期格式: YYYY年MM月DD日 HH:mm:ss
 	if strings.Contains(s, "年") && strings.Contains(s, "月") && strings.Contains(s, "日") {
 		clean := strings.NewReplacer("年", "-", "月", "-", "日", " ").Replace(s)
@@ -188,6 +214,16 @@ func parseStringTime(input string) int64 {
 	return 0
 }
 
+// dateInYear 按 now 所在年份补全缺少年份的日期
+// 补全后晚于 now 一天以上时视为去年,如 1 月初读到 12 月底的内容
+func dateInYear(now time.Time, month, day, hour, minute, sec int) int64 {
+	t := time.Date(now.Year(), time.Month(month), day, hour, minute, sec, 0, now.Location())
+	if t.After(now.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t.UnixMilli()
+}
+
 func parseMonthDay(input string) (int, int) {
 	clean := strings.ReplaceAll(input, "日", "")
 	clean = strings.ReplaceAll(clean, "月", "-")
@@ -224,3 +260,49 @@ func normalizeTimestamp(ts int64) int64 {
 	}
 	return ts * 1000
 }
+
+// Humanize 将毫秒时间戳转换为中文相对时间文案
+// 一分钟内为"刚刚",一小时内为"X分钟前",一天内为"X小时前",
+// 前一天为"昨天 HH:mm",今年内为"MM-DD HH:mm",更早为"YYYY-MM-DD"
+// 时间戳为 0 时返回空串;未来时间(如服务器时钟偏差)一分钟内视为"刚刚",否则返回具体日期
+// loc 为 nil 时使用本地时区
+func Humanize(ms int64, loc *time.Location) string {
+	return humanizeAt(ms, time.Now(), loc)
+}
+
+func humanizeAt(ms int64, now time.Time, loc *time.Location) string {
+	if ms <= 0 {
+		return ""
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	t := time.UnixMilli(ms).In(loc)
+	now = now.In(loc)
+	diff := now.Sub(t)
+
+	switch {
+	case diff < -time.Minute:
+		// 未来时间不做相对描述,直接给出日期
+		if t.Year() == now.Year() {
+			return t.Format("01-02 15:04")
+		}
+		return t.Format("2006-01-02")
+	case diff < time.Minute:
+		return "刚刚"
+	case diff < time.Hour:
+		return strconv.Itoa(int(diff/time.Minute)) + "分钟前"
+	case diff < 24*time.Hour:
+		return strconv.Itoa(int(diff/time.Hour)) + "小时前"
+	}
+
+	yesterday := now.AddDate(0, 0, -1)
+	if t.Year() == yesterday.Year() && t.YearDay() == yesterday.YearDay() {
+		return "昨天 " + t.Format("15:04")
+	}
+	if t.Year() == now.Year() {
+		return t.Format("01-02 15:04")
+	}
+	return t.Format("2006-01-02")
+}
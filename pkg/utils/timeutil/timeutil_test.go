@@ -0,0 +1,100 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanize(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, Beijing)
+	at := func(d time.Duration) int64 { return now.Add(d).UnixMilli() }
+	date := func(year int, month time.Month, day, hour, minute int) int64 {
+		return time.Date(year, month, day, hour, minute, 0, 0, Beijing).UnixMilli()
+	}
+
+	tests := []struct {
+		name string
+		ms   int64
+		want string
+	}{
+		{"0 值", 0, ""},
+		{"负数", -1, ""},
+		{"刚刚", at(-30 * time.Second), "刚刚"},
+		{"恰好一分钟", at(-time.Minute), "1分钟前"},
+		{"59 分钟", at(-59*time.Minute - 59*time.Second), "59分钟前"},
+		{"恰好一小时", at(-time.Hour), "1小时前"},
+		{"23 小时", at(-23*time.Hour - 59*time.Minute), "23小时前"},
+		{"昨天", date(2024, 5, 9, 8, 5), "昨天 08:05"},
+		{"前天", date(2024, 5, 8, 20, 0), "05-08 20:00"},
+		{"去年", date(2023, 12, 31, 23, 0), "2023-12-31"},
+		{"一分钟内的未来时间", at(30 * time.Second), "刚刚"},
+		{"未来时间", at(2 * time.Hour), "05-10 14:00"},
+		{"明年", date(2025, 1, 1, 0, 0), "2025-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := humanizeAt(tt.ms, now, Beijing); got != tt.want {
+				t.Errorf("humanizeAt(%d) = %q, want %q", tt.ms, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHumanizeLocation(t *testing.T) {
+	// UTC 2024-05-09 16:30 为北京时间 2024-05-10 00:30,跨天按 loc 判断
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, Beijing)
+	ms := time.Date(2024, 5, 9, 16, 30, 0, 0, time.UTC).UnixMilli()
+	if got := humanizeAt(ms, now, Beijing); got != "11小时前" {
+		t.Errorf("Beijing = %q, want 11小时前", got)
+	}
+	ms = time.Date(2024, 5, 8, 16, 30, 0, 0, time.UTC).UnixMilli()
+	if got := humanizeAt(ms, now, Beijing); got != "昨天 00:30" {
+		t.Errorf("Beijing = %q, want 昨天 00:30", got)
+	}
+	if got := humanizeAt(ms, now, time.UTC); got != "05-08 16:30" {
+		t.Errorf("UTC = %q, want 05-08 16:30", got)
+	}
+}
+
+func TestParseTimeIn(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, Beijing)
+	date := func(year int, month time.Month, day, hour, minute, sec int) int64 {
+		return time.Date(year, month, day, hour, minute, sec, 0, Beijing).UnixMilli()
+	}
+
+	tests := []struct {
+		name string
+		val  interface{}
+		want int64
+	}{
+		{"空串", "", 0},
+		{"无法解析", "很久以前", 0},
+		{"秒级时间戳", int64(1715313600), 1715313600000},
+		{"毫秒级时间戳", int64(1715313600000), 1715313600000},
+		{"浮点数", float64(1715313600), 1715313600000},
+		{"数字字符串", "1715313600", 1715313600000},
+		{"HH:mm", "08:30", date(2024, 5, 10, 8, 30, 0)},
+		{"今天", "今天 08:30", date(2024, 5, 10, 8, 30, 0)},
+		{"昨天", "昨天 23:10", date(2024, 5, 9, 23, 10, 0)},
+		{"昨日", "昨日 23:10", date(2024, 5, 9, 23, 10, 0)},
+		{"分钟前", "5分钟前", now.Add(-5 * time.Minute).UnixMilli()},
+		{"小时前", "3 小时前", now.Add(-3 * time.Hour).UnixMilli()},
+		{"天前", "2天前", date(2024, 5, 8, 12, 0, 0)},
+		{"天前带空格", "7 天前", date(2024, 5, 3, 12, 0, 0)},
+		{"X月X日", "5月1日", date(2024, 5, 1, 0, 0, 0)},
+		{"X月X日 HH:mm", "5月1日 10:30", date(2024, 5, 1, 10, 30, 0)},
+		{"MM-DD HH:mm", "05-01 10:30", date(2024, 5, 1, 10, 30, 0)},
+		{"跨年的 MM-DD", "12-31", date(2023, 12, 31, 0, 0, 0)},
+		{"中文日期", "2024年5月1日 10:30", date(2024, 5, 1, 10, 30, 0)},
+		{"不补零的日期", "2024-5-1 8:30", date(2024, 5, 1, 8, 30, 0)},
+		{"斜杠日期", "2024/05/01", date(2024, 5, 1, 0, 0, 0)},
+		{"RFC3339 按自带时区", "2024-05-01T10:30:00Z", time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC).UnixMilli()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseTimeIn(tt.val, Beijing, now); got != tt.want {
+				t.Errorf("ParseTimeIn(%v) = %d, want %d", tt.val, got, tt.want)
+			}
+		})
+	}
+}